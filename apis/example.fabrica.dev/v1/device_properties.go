@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package v1
+
+// Well-known keys of DeviceSpec.Properties. The collector fills them from the
+// Redfish resource of each device; reports and list filters read them.
+const (
+	// PropertyRedfishURI is the device's Redfish @odata.id, or for devices
+	// collected from the host OS a locator such as host://<uuid>/cpu/CPU1.
+	PropertyRedfishURI = "redfish_uri"
+	// PropertyRedfishParentURI is the @odata.id or locator of the device's
+	// parent.
+	PropertyRedfishParentURI = "redfish_parent_uri"
+
+	// PropertyFirmwareVersion is the firmware running on the device.
+	PropertyFirmwareVersion = "firmware_version"
+	// PropertySKU is the vendor stock-keeping unit.
+	PropertySKU = "sku"
+	// PropertyHealth is the Redfish Status.Health: OK, Warning or Critical.
+	PropertyHealth = "health"
+	// PropertyState is the Redfish Status.State, e.g. Enabled or Absent.
+	PropertyState = "state"
+	// PropertyLocation is the service label of the slot or bay holding the device.
+	PropertyLocation = "location"
+	// PropertyPhysicalContext is the area of the enclosure the device sits in, e.g. CPU or Memory.
+	PropertyPhysicalContext = "physical_context"
+
+	// PropertyCapacityMiB is the size of a DIMM.
+	PropertyCapacityMiB = "capacity_mib"
+	// PropertyCapacityBytes is the size of a drive.
+	PropertyCapacityBytes = "capacity_bytes"
+	// PropertyTotalCores is the core count of a CPU or GPU.
+	PropertyTotalCores = "total_cores"
+	// PropertyTotalThreads is the thread count of a CPU or GPU.
+	PropertyTotalThreads = "total_threads"
+)
+
+// HealthOK is the PropertyHealth value of a device with no known problems.
+const HealthOK = "OK"
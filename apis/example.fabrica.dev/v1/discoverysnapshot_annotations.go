@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package v1
+
+// Annotations the collector records on the DiscoverySnapshots it creates.
+// They describe the collection itself, so they survive a snapshot being saved
+// to a file and uploaded later.
+const (
+	// AnnotationCollectedAt is the RFC3339 time the BMC was walked.
+	AnnotationCollectedAt = "example.fabrica.dev/collected-at"
+	// AnnotationSource lists the collector sources the devices came from,
+	// e.g. "redfish" or "dmidecode,lspci".
+	AnnotationSource = "example.fabrica.dev/source"
+	// AnnotationSourceBMC is the BMC the devices were collected from, for
+	// Redfish collections.
+	AnnotationSourceBMC = "example.fabrica.dev/source-bmc"
+	// AnnotationCollectorHost is the host the collector ran on.
+	AnnotationCollectorHost = "example.fabrica.dev/collector-host"
+)
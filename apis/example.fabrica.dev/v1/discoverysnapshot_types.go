@@ -35,13 +35,7 @@ type DiscoverySnapshotStatus struct {
 
 // Validate implements custom validation logic for DiscoverySnapshot
 func (r *DiscoverySnapshot) Validate(ctx context.Context) error {
-	// Add custom validation logic here
-	// Example:
-	// if r.Spec.Description == "forbidden" {
-	//     return errors.New("description 'forbidden' is not allowed")
-	// }
-
-	return nil
+	return ValidateSnapshotData(r.Spec.RawData)
 }
 
 // GetKind returns the kind of the resource
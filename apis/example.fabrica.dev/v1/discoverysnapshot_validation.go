@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Limits on the devices a DiscoverySnapshot may carry. They are far above
+// what a rack of hardware reports and only keep out malformed uploads.
+const (
+	// MaxSnapshotDevices is the most devices one snapshot may list.
+	MaxSnapshotDevices = 100000
+	// MaxDeviceFieldLength is the longest a device type, manufacturer, part
+	// number, serial number or locator may be.
+	MaxDeviceFieldLength = 256
+	// MaxDeviceProperties is the most properties one device may have.
+	MaxDeviceProperties = 128
+	// MaxPropertySize is the largest encoded size of one property value.
+	MaxPropertySize = 16 << 10
+)
+
+// maxFieldErrors bounds the problems reported for one snapshot, so a badly
+// broken upload does not get a response larger than itself.
+const maxFieldErrors = 100
+
+// sizeProperties hold counts and capacities, which must be non-negative
+// whole numbers.
+var sizeProperties = []string{PropertyCapacityMiB, PropertyCapacityBytes, PropertyTotalCores, PropertyTotalThreads}
+
+// FieldError is one problem with a field of a resource.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors lists every problem found validating a resource.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	problems := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		problems = append(problems, fieldErr.Field+": "+fieldErr.Message)
+	}
+	return strings.Join(problems, "; ")
+}
+
+// ValidateSnapshotData checks that rawData is a list of devices the
+// reconciler can process: each entry has a device type and a serial number
+// or redfish_uri to be identified by, fields and properties of sane sizes,
+// and no two entries claim the same serial number or redfish_uri. It returns
+// nil or ValidationErrors.
+func ValidateSnapshotData(rawData json.RawMessage) error {
+	const field = "spec.rawData"
+
+	var specs []DeviceSpec
+	if err := json.Unmarshal(rawData, &specs); err != nil {
+		return ValidationErrors{{Field: field, Message: fmt.Sprintf("must be a JSON array of devices: %v", err)}}
+	}
+	switch {
+	case len(specs) == 0:
+		return ValidationErrors{{Field: field, Message: "must list at least one device"}}
+	case len(specs) > MaxSnapshotDevices:
+		return ValidationErrors{{Field: field, Message: fmt.Sprintf("lists %d devices, more than the limit of %d", len(specs), MaxSnapshotDevices)}}
+	}
+
+	var errs ValidationErrors
+	serials := make(map[string]int)
+	uris := make(map[string]int)
+	for i, spec := range specs {
+		entry := fmt.Sprintf("%s[%d]", field, i)
+		fail := func(name, format string, args ...any) {
+			errs = append(errs, FieldError{Field: entry + name, Message: fmt.Sprintf(format, args...)})
+		}
+
+		if strings.TrimSpace(spec.DeviceType) == "" {
+			fail(".deviceType", "is required")
+		}
+		for _, f := range []struct{ name, value string }{
+			{".deviceType", spec.DeviceType},
+			{".manufacturer", spec.Manufacturer},
+			{".partNumber", spec.PartNumber},
+			{".serialNumber", spec.SerialNumber},
+			{".parentSerialNumber", spec.ParentSerialNumber},
+		} {
+			if len(f.value) > MaxDeviceFieldLength {
+				fail(f.name, "is %d bytes long, more than the limit of %d", len(f.value), MaxDeviceFieldLength)
+			}
+		}
+
+		if len(spec.Properties) > MaxDeviceProperties {
+			fail(".properties", "has %d entries, more than the limit of %d", len(spec.Properties), MaxDeviceProperties)
+		}
+		for _, name := range slices.Sorted(maps.Keys(spec.Properties)) {
+			if size := len(spec.Properties[name]); size > MaxPropertySize {
+				fail(".properties."+name, "is %d bytes, more than the limit of %d", size, MaxPropertySize)
+			}
+		}
+		uri, uriOK := stringProperty(spec.Properties, PropertyRedfishURI)
+		if !uriOK {
+			fail(".properties."+PropertyRedfishURI, "must be a string")
+		} else if len(uri) > MaxDeviceFieldLength {
+			fail(".properties."+PropertyRedfishURI, "is %d bytes long, more than the limit of %d", len(uri), MaxDeviceFieldLength)
+		}
+		if _, ok := stringProperty(spec.Properties, PropertyRedfishParentURI); !ok {
+			fail(".properties."+PropertyRedfishParentURI, "must be a string")
+		}
+		for _, name := range sizeProperties {
+			raw, ok := spec.Properties[name]
+			if !ok {
+				continue
+			}
+			var size float64
+			if err := json.Unmarshal(raw, &size); err != nil || size < 0 || size != math.Trunc(size) {
+				fail(".properties."+name, "must be a non-negative whole number")
+			}
+		}
+
+		if spec.SerialNumber == "" && uri == "" {
+			fail("", "needs a serialNumber or a %s property to be identified by", PropertyRedfishURI)
+		}
+		// The reconciler matches devices by either, so a repeat would fold
+		// two devices into one
+		if spec.SerialNumber != "" {
+			if first, ok := serials[spec.SerialNumber]; ok {
+				fail(".serialNumber", "%q is also the serial number of %s[%d]", spec.SerialNumber, field, first)
+			} else {
+				serials[spec.SerialNumber] = i
+			}
+		}
+		if uri != "" {
+			if first, ok := uris[uri]; ok {
+				fail(".properties."+PropertyRedfishURI, "%q is also the %s of %s[%d]", uri, PropertyRedfishURI, field, first)
+			} else {
+				uris[uri] = i
+			}
+		}
+
+		if len(errs) >= maxFieldErrors {
+			errs = append(errs[:maxFieldErrors], FieldError{Field: field, Message: "too many problems, only the first ones are listed"})
+			break
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// stringProperty returns a property holding a string. It reports false when
+// the property is set to anything but a string or null.
+func stringProperty(props map[string]json.RawMessage, key string) (string, bool) {
+	raw, ok := props[key]
+	if !ok {
+		return "", true
+	}
+	var value *string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", false
+	}
+	if value == nil {
+		return "", true
+	}
+	return *value, true
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package v1
+
+// Inventory event types describe changes to the physical hardware, as opposed
+// to the resource lifecycle events (created, updated, ...) of the API itself.
+const (
+	// DeviceAddedEventType is published when discovery finds a device for the first time.
+	DeviceAddedEventType = "fru-tracker.inventory.device.added"
+	// DeviceRemovedEventType is published when a device is deleted from the inventory.
+	DeviceRemovedEventType = "fru-tracker.inventory.device.removed"
+	// DeviceReparentedEventType is published when a device moves to a different parent.
+	DeviceReparentedEventType = "fru-tracker.inventory.device.reparented"
+	// DevicePartNumberChangedEventType is published when a device reports a new part number.
+	DevicePartNumberChangedEventType = "fru-tracker.inventory.device.partnumber.changed"
+)
+
+// InventoryEventTypes lists every inventory event type.
+var InventoryEventTypes = []string{
+	DeviceAddedEventType,
+	DeviceRemovedEventType,
+	DeviceReparentedEventType,
+	DevicePartNumberChangedEventType,
+}
+
+// IsInventoryEventType reports whether eventType is one of InventoryEventTypes.
+func IsInventoryEventType(eventType string) bool {
+	for _, known := range InventoryEventTypes {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DeviceChange is the data of every inventory event.
+type DeviceChange struct {
+	DeviceUID    string `json:"deviceUid"`
+	DeviceName   string `json:"deviceName,omitempty"`
+	DeviceType   string `json:"deviceType,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+	PartNumber   string `json:"partNumber,omitempty"`
+	ParentID     string `json:"parentID,omitempty"`
+
+	// PreviousPartNumber is set on part number changes.
+	PreviousPartNumber string `json:"previousPartNumber,omitempty"`
+	// PreviousParentID is set when a device is re-parented.
+	PreviousParentID string `json:"previousParentID,omitempty"`
+	// SnapshotUID is the discovery snapshot that observed the change, if any.
+	SnapshotUID string `json:"snapshotUid,omitempty"`
+}
+
+// NewDeviceChange fills a DeviceChange with the current state of device.
+func NewDeviceChange(device *Device) DeviceChange {
+	return DeviceChange{
+		DeviceUID:    device.Metadata.UID,
+		DeviceName:   device.Metadata.Name,
+		DeviceType:   device.Spec.DeviceType,
+		Manufacturer: device.Spec.Manufacturer,
+		SerialNumber: device.Spec.SerialNumber,
+		PartNumber:   device.Spec.PartNumber,
+		ParentID:     device.Spec.ParentID,
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/fabrica"
+)
+
+// WebhookDeliveryLogSize is the number of deliveries kept in a subscription's status.
+const WebhookDeliveryLogSize = 50
+
+// WebhookSubscription represents a webhooksubscription resource
+type WebhookSubscription struct {
+	APIVersion string                    `json:"apiVersion"`
+	Kind       string                    `json:"kind"`
+	Metadata   fabrica.Metadata          `json:"metadata"`
+	Spec       WebhookSubscriptionSpec   `json:"spec" validate:"required"`
+	Status     WebhookSubscriptionStatus `json:"status,omitempty"`
+}
+
+// WebhookSubscriptionSpec defines the desired state of WebhookSubscription
+type WebhookSubscriptionSpec struct {
+	// URL receives a CloudEvents (structured mode) POST for every matching inventory event.
+	URL string `json:"url" validate:"required"`
+	// EventTypes limits deliveries to these inventory event types. Empty means all of them.
+	EventTypes []string `json:"eventTypes,omitempty"`
+	// DeviceTypes limits deliveries to devices of these types (e.g. "DIMM"). Empty means all.
+	DeviceTypes []string `json:"deviceTypes,omitempty"`
+	// Secret, when set, signs each body with HMAC-SHA256 in the X-Fru-Tracker-Signature header.
+	// It is write-only: the API never returns it, see Redacted.
+	Secret string `json:"secret,omitempty"`
+	// SecretSet reports in API responses that a Secret is configured.
+	SecretSet bool `json:"secretSet,omitempty"`
+	// MaxAttempts caps delivery attempts per event. Zero uses the server default.
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+	// Paused stops deliveries without deleting the subscription.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// UnmarshalJSON replaces the whole spec, rather than only the fields data
+// names, so that a patch removing the secret clears it.
+func (s *WebhookSubscriptionSpec) UnmarshalJSON(data []byte) error {
+	type plain WebhookSubscriptionSpec
+	var spec plain
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return err
+	}
+	*s = WebhookSubscriptionSpec(spec)
+	return nil
+}
+
+// WebhookSubscriptionStatus defines the observed state of WebhookSubscription
+type WebhookSubscriptionStatus struct {
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+	Ready   bool   `json:"ready"`
+
+	// ConsecutiveFailures counts events in a row that exhausted every attempt.
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+	// Deliveries is the delivery log, newest first, one entry per attempt.
+	Deliveries []WebhookDelivery `json:"deliveries,omitempty"`
+}
+
+// WebhookDelivery records one attempt to deliver an event to a subscription.
+type WebhookDelivery struct {
+	EventID    string    `json:"eventId"`
+	EventType  string    `json:"eventType"`
+	DeviceUID  string    `json:"deviceUid,omitempty"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Succeeded  bool      `json:"succeeded"`
+	Time       time.Time `json:"time"`
+}
+
+// Validate implements custom validation logic for WebhookSubscription
+func (r *WebhookSubscription) Validate(ctx context.Context) error {
+	u, err := url.Parse(r.Spec.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL: %q", r.Spec.URL)
+	}
+	for _, eventType := range r.Spec.EventTypes {
+		if !IsInventoryEventType(eventType) {
+			return fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+	if r.Spec.MaxAttempts < 0 {
+		return fmt.Errorf("maxAttempts must not be negative")
+	}
+
+	return nil
+}
+
+// Redacted returns a copy of the subscription to show API clients, with the
+// Secret removed and SecretSet reporting whether one is configured.
+func (r *WebhookSubscription) Redacted() *WebhookSubscription {
+	redacted := *r
+	redacted.Spec.SecretSet = r.Spec.Secret != ""
+	redacted.Spec.Secret = ""
+	return &redacted
+}
+
+// GetKind returns the kind of the resource
+func (r *WebhookSubscription) GetKind() string {
+	return "WebhookSubscription"
+}
+
+// GetName returns the name of the resource
+func (r *WebhookSubscription) GetName() string {
+	return r.Metadata.Name
+}
+
+// GetUID returns the UID of the resource
+func (r *WebhookSubscription) GetUID() string {
+	return r.Metadata.UID
+}
+
+// IsHub marks this as the hub/storage version
+func (r *WebhookSubscription) IsHub() {}
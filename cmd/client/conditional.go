@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// etags carries ETags between the server and the commands: the ETag a write
+// must match, and the ETag of the last response.
+var etags = &etagTransport{base: http.DefaultTransport}
+
+// etagTransport adds If-Match to writes and records the ETag of responses.
+type etagTransport struct {
+	base    http.RoundTripper
+	ifMatch string
+	last    string
+}
+
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.ifMatch != "" && req.Method != http.MethodGet && req.Header.Get("If-Match") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-Match", t.ifMatch)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		t.last = resp.Header.Get("ETag")
+	}
+	return resp, err
+}
+
+func httpClient() *http.Client {
+	return &http.Client{Transport: etags}
+}
+
+// init lets get print the ETag of a resource and update, patch and delete
+// refuse to act on a resource changed since then:
+//
+//	client device get <uid> -o json > device.json   # prints ETag: W/"..."
+//	client device update <uid> --if-match 'W/"..."' < device.json
+func init() {
+	for _, cmd := range []*cobra.Command{deviceGetCmd, discoverysnapshotGetCmd, webhooksubscriptionGetCmd} {
+		get := cmd.RunE
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			if err := get(cmd, args); err != nil {
+				return err
+			}
+			// On stderr, so the resource on stdout can still be piped
+			if etags.last != "" {
+				fmt.Fprintln(os.Stderr, "ETag:", etags.last)
+			}
+			return nil
+		}
+	}
+
+	for _, cmd := range []*cobra.Command{
+		deviceUpdateCmd, devicePatchCmd, deviceDeleteCmd,
+		discoverysnapshotUpdateCmd, discoverysnapshotPatchCmd, discoverysnapshotDeleteCmd,
+		webhooksubscriptionUpdateCmd, webhooksubscriptionPatchCmd, webhooksubscriptionDeleteCmd,
+	} {
+		cmd.Flags().String("if-match", "", "Only proceed if the resource still has this ETag, as printed by get")
+		write := cmd.RunE
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			etags.ifMatch, _ = cmd.Flags().GetString("if-match")
+			return write(cmd, args)
+		}
+	}
+}
@@ -14,6 +14,7 @@
 // Generated commands for each resource:
 //   - client device [list|get|create|update|patch|delete]
 //   - client discoverysnapshot [list|get|create|update|patch|delete]
+//   - client webhooksubscription [list|get|create|update|patch|delete]
 //
 // Global flags (available for all commands):
 //
@@ -127,6 +128,7 @@ func init() {
 	// Add resource commands
 	rootCmd.AddCommand(deviceCmd)
 	rootCmd.AddCommand(discoverysnapshotCmd)
+	rootCmd.AddCommand(webhooksubscriptionCmd)
 
 }
 
@@ -152,7 +154,7 @@ func initConfig() {
 
 func getClient() (*client.Client, error) {
 	serverURL := viper.GetString("server")
-	c, err := client.NewClient(serverURL, nil)
+	c, err := client.NewClient(serverURL, httpClient())
 	if err != nil {
 		return nil, err
 	}
@@ -907,3 +909,347 @@ func init() {
 	discoverysnapshotPatchCmd.Flags().StringArray("add", nil, "Add value to array field (field=value)")
 	discoverysnapshotPatchCmd.Flags().StringArray("remove", nil, "Remove value from array field (field=value)")
 }
+
+// WebhookSubscription commands
+var webhooksubscriptionCmd = &cobra.Command{
+	Use:   "webhooksubscription",
+	Short: "Manage webhooksubscriptions",
+	Long:  `Create, read, update, patch, and delete webhooksubscriptions.`,
+}
+
+var webhooksubscriptionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all webhooksubscriptions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		items, err := c.GetWebhookSubscriptions(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list webhooksubscriptions: %w", err)
+		}
+
+		return printOutput(items)
+	},
+}
+
+var webhooksubscriptionGetCmd = &cobra.Command{
+	Use:   "get [uid]",
+	Short: "Get a WebhookSubscription by UID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.GetWebhookSubscription(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get WebhookSubscription: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var webhooksubscriptionCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new WebhookSubscription",
+	Long: `Create a new WebhookSubscription.
+
+Examples:
+  # Create from stdin
+  echo '{"url": "https://example.com/hooks/fru", "eventTypes": ["fru-tracker.inventory.device.removed"], "deviceTypes": ["DIMM"], "secret": "s3cret"}' | client webhooksubscription create
+
+  # Create with --spec flag
+  client webhooksubscription create --spec '{"url": "https://example.com/hooks/fru", "eventTypes": ["fru-tracker.inventory.device.removed"], "deviceTypes": ["DIMM"], "secret": "s3cret"}'
+
+Spec fields:
+  url (string) [required]
+  eventTypes ([]string)
+  deviceTypes ([]string)
+  secret (string)
+  maxAttempts (int)
+  paused (bool)
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.CreateWebhookSubscriptionRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.CreateWebhookSubscription(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create WebhookSubscription: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var webhooksubscriptionUpdateCmd = &cobra.Command{
+	Use:   "update [uid]",
+	Short: "Update an existing WebhookSubscription",
+	Long: `Update an existing WebhookSubscription.
+
+Examples:
+  # Update from stdin
+  echo '{"url": "https://example.com/hooks/fru", "eventTypes": ["fru-tracker.inventory.device.removed"], "deviceTypes": ["DIMM"], "secret": "s3cret"}' | client webhooksubscription update <uid>
+
+  # Update with --spec flag
+  client webhooksubscription update <uid> --spec '{"url": "https://example.com/hooks/fru", "eventTypes": ["fru-tracker.inventory.device.removed"], "deviceTypes": ["DIMM"], "secret": "s3cret"}'
+
+Spec fields:
+  url (string) [required]
+  eventTypes ([]string)
+  deviceTypes ([]string)
+  secret (string)
+  maxAttempts (int)
+  paused (bool)
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.UpdateWebhookSubscriptionRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.UpdateWebhookSubscription(ctx, args[0], req)
+		if err != nil {
+			return fmt.Errorf("failed to update WebhookSubscription: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var webhooksubscriptionPatchCmd = &cobra.Command{
+	Use:   "patch [uid]",
+	Short: "Patch a WebhookSubscription",
+	Long: `Patch an existing WebhookSubscription spec using various patch formats.
+
+IMPORTANT: Only the spec portion of the resource can be patched.
+Metadata (name, labels, annotations) and status are managed by the API.
+
+Examples:
+  # JSON Merge Patch (simple merge) - patch spec fields
+  client webhooksubscription patch <uid> --spec '{"manufacturer":"Intel","model":"Updated Model"}'
+
+  # Shorthand patch (dot notation - most convenient)
+  client webhooksubscription patch <uid> --set manufacturer=Intel --set model="Updated Model" --unset customField
+
+  # JSON Patch (RFC 6902 - most powerful)
+  client webhooksubscription patch <uid> --json-patch '[
+    {"op":"replace","path":"/manufacturer","value":"Intel"},
+    {"op":"add","path":"/properties/newField","value":"newValue"}
+  ]'
+
+  # From stdin (JSON Merge Patch format)
+  echo '{"manufacturer":"AMD","partNumber":"RYZEN-9000"}' | client webhooksubscription patch <uid>
+
+Patch Formats:
+  --spec        JSON Merge Patch (RFC 7386) - simple object merge
+  --set/--unset Shorthand patch - dot notation for convenience
+  --json-patch  JSON Patch (RFC 6902) - operation-based patches
+  stdin         JSON Merge Patch format
+
+Shorthand Operations (spec fields only):
+  --set field=value     Set a spec field value (supports dot notation)
+  --unset field         Remove a spec field (supports dot notation)
+  --add field=value     Add to spec array field (field must end with '.-')
+  --remove field=value  Remove from spec array field
+
+Note: All patch operations target the resource spec only.
+Attempts to patch metadata or status fields will be ignored.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		uid := args[0]
+
+		// Get patch flags
+		specPatch, _ := cmd.Flags().GetString("spec")
+		jsonPatch, _ := cmd.Flags().GetString("json-patch")
+		setPairs, _ := cmd.Flags().GetStringArray("set")
+		unsetFields, _ := cmd.Flags().GetStringArray("unset")
+		addPairs, _ := cmd.Flags().GetStringArray("add")
+		removePairs, _ := cmd.Flags().GetStringArray("remove")
+
+		var patchData []byte
+		var contentType string
+
+		// Determine patch format and build patch data
+		if jsonPatch != "" {
+			// JSON Patch (RFC 6902)
+			patchData = []byte(jsonPatch)
+			contentType = "application/json-patch+json"
+		} else if len(setPairs) > 0 || len(unsetFields) > 0 || len(addPairs) > 0 || len(removePairs) > 0 {
+			// Shorthand patch - convert to JSON Merge Patch
+			patch := make(map[string]interface{})
+
+			// Process --set flags
+			for _, setPair := range setPairs {
+				parts := strings.SplitN(setPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --set format: %s (expected field=value)", setPair)
+				}
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --unset flags
+			for _, field := range unsetFields {
+				setNestedField(patch, field, nil)
+			}
+
+			// Process --add flags (add to arrays)
+			for _, addPair := range addPairs {
+				parts := strings.SplitN(addPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --add format: %s (expected field=value)", addPair)
+				}
+				// For arrays, we'll use JSON Merge Patch append syntax if possible
+				// Otherwise convert to JSON Patch
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --remove flags
+			for _, removePair := range removePairs {
+				parts := strings.SplitN(removePair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --remove format: %s (expected field=value)", removePair)
+				}
+				// Remove operations are complex and might need JSON Patch
+				// For now, we'll handle simple cases
+				return fmt.Errorf("--remove operations require --json-patch format")
+			}
+
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal shorthand patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		} else if specPatch != "" {
+			// JSON Merge Patch from --spec
+			patchData = []byte(specPatch)
+			contentType = "application/merge-patch+json"
+		} else {
+			// Read from stdin (default to JSON Merge Patch)
+			decoder := json.NewDecoder(os.Stdin)
+			var patch interface{}
+			if err := decoder.Decode(&patch); err != nil {
+				return fmt.Errorf("failed to decode patch from stdin: %w", err)
+			}
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.PatchWebhookSubscription(ctx, uid, patchData, contentType)
+		if err != nil {
+			return fmt.Errorf("failed to patch WebhookSubscription: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var webhooksubscriptionDeleteCmd = &cobra.Command{
+	Use:   "delete [uid]",
+	Short: "Delete a WebhookSubscription",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := c.DeleteWebhookSubscription(ctx, args[0]); err != nil {
+			return fmt.Errorf("failed to delete WebhookSubscription: %w", err)
+		}
+
+		fmt.Printf("WebhookSubscription %s deleted successfully\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	webhooksubscriptionCmd.AddCommand(webhooksubscriptionListCmd)
+	webhooksubscriptionCmd.AddCommand(webhooksubscriptionGetCmd)
+	webhooksubscriptionCmd.AddCommand(webhooksubscriptionCreateCmd)
+	webhooksubscriptionCmd.AddCommand(webhooksubscriptionUpdateCmd)
+	webhooksubscriptionCmd.AddCommand(webhooksubscriptionPatchCmd)
+	webhooksubscriptionCmd.AddCommand(webhooksubscriptionDeleteCmd)
+
+	// Add spec flag for create and update commands
+	webhooksubscriptionCreateCmd.Flags().String("spec", "", "WebhookSubscription specification in JSON format")
+	webhooksubscriptionUpdateCmd.Flags().String("spec", "", "WebhookSubscription specification in JSON format")
+
+	// Add patch command flags
+	webhooksubscriptionPatchCmd.Flags().String("spec", "", "JSON Merge Patch specification")
+	webhooksubscriptionPatchCmd.Flags().String("json-patch", "", "JSON Patch operations (RFC 6902)")
+	webhooksubscriptionPatchCmd.Flags().StringArray("set", nil, "Set field value using dot notation (field=value)")
+	webhooksubscriptionPatchCmd.Flags().StringArray("unset", nil, "Unset field using dot notation")
+	webhooksubscriptionPatchCmd.Flags().StringArray("add", nil, "Add value to array field (field=value)")
+	webhooksubscriptionPatchCmd.Flags().StringArray("remove", nil, "Remove value from array field (field=value)")
+}
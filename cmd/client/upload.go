@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/pkg/collector"
+	"github.com/spf13/cobra"
+)
+
+var discoverysnapshotUploadCmd = &cobra.Command{
+	Use:   "upload <file...>",
+	Short: "Upload DiscoverySnapshots saved by the collector",
+	Long: `Upload snapshots written by 'collector --output', for example after carrying
+them out of an air-gapped BMC network. Each file becomes one DiscoverySnapshot
+with its original name and its collected-at, source-bmc and collector-host
+annotations, so it is attributed to the time and BMC it was collected from.
+
+A file that fails to upload does not stop the others.
+
+Examples:
+  client discoverysnapshot upload snapshot.json
+  client discoverysnapshot upload snapshots/*.json`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		failed := 0
+		for _, path := range args {
+			createReq, err := collector.ReadSnapshotFile(path)
+			if err == nil {
+				ctx, cancel := context.WithTimeout(context.Background(), timeout)
+				var snapshot *v1.DiscoverySnapshot
+				snapshot, err = c.CreateDiscoverySnapshot(ctx, createReq)
+				cancel()
+				if err == nil {
+					fmt.Printf("Uploaded %s as %s (collected %s from %s)\n", path, snapshot.Metadata.UID,
+						createReq.Metadata.Annotations[v1.AnnotationCollectedAt], createReq.Metadata.Annotations[v1.AnnotationSourceBMC])
+					continue
+				}
+			}
+			failed++
+			fmt.Fprintf(os.Stderr, "Warning: failed to upload %s: %v\n", path, err)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d snapshots failed to upload", failed, len(args))
+		}
+		return nil
+	},
+}
+
+func init() {
+	discoverysnapshotCmd.AddCommand(discoverysnapshotUploadCmd)
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/example/fru-tracker/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// watchRetryDelay is how long to wait before reconnecting a dropped watch.
+const watchRetryDelay = 2 * time.Second
+
+func init() {
+	deviceListCmd.Flags().BoolP("watch", "w", false, "After listing, stream device changes until interrupted")
+
+	list := deviceListCmd.RunE
+	deviceListCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			return watchDevices()
+		}
+		return list(cmd, args)
+	}
+}
+
+// watchDevices prints the current devices and then every change, reconnecting
+// from the last seen revision when the stream drops.
+func watchDevices() error {
+	c, err := getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var revision uint64
+	listed := false
+	for {
+		err := c.WatchDevices(ctx, revision, func(event client.WatchEvent) error {
+			revision = event.Revision
+			if event.Action != client.WatchActionBookmark {
+				return printOutput(event)
+			}
+			if listed {
+				return nil
+			}
+
+			// List once the stream is open so no change falls between the two
+			listCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			items, err := c.GetDevices(listCtx)
+			if err != nil {
+				return fmt.Errorf("failed to list devices: %w", err)
+			}
+			listed = true
+			return printOutput(items)
+		})
+
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case errors.Is(err, client.ErrWatchExpired):
+			// Too far behind to resume: start over with a fresh list
+			fmt.Fprintln(os.Stderr, "Watch revision expired; re-listing")
+			revision = 0
+			listed = false
+		case errors.Is(err, client.ErrWatchClosed):
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Warning: watch interrupted: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(watchRetryDelay):
+		}
+	}
+}
@@ -0,0 +1,510 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Command collector gathers hardware inventory from BMCs over Redfish and
+// posts it to the fru-tracker API as DiscoverySnapshots, one per BMC.
+//
+// Configuration sources (in order of precedence):
+//  1. Command-line flags
+//  2. Environment variables (FRU_COLLECTOR_*, e.g. FRU_COLLECTOR_BMC_PASSWORD)
+//  3. Config file (~/.fru-collector.yaml)
+//  4. Default values
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	fabricaclient "github.com/example/fru-tracker/pkg/client"
+	"github.com/example/fru-tracker/pkg/collector"
+	"github.com/example/fru-tracker/pkg/collector/host"
+)
+
+// Config holds all configuration for the collector
+type Config struct {
+	// API Configuration
+	Server    string `mapstructure:"server"`
+	Token     string `mapstructure:"token"`
+	TokenFile string `mapstructure:"token-file"`
+	Output    string `mapstructure:"output"`
+
+	// Source Configuration
+	Sources []string `mapstructure:"source"`
+
+	// BMC Configuration
+	BMCs            []string `mapstructure:"bmc"`
+	BMCFile         string   `mapstructure:"bmc-file"`
+	CIDRs           []string `mapstructure:"cidr"`
+	SMDURL          string   `mapstructure:"smd-url"`
+	SMDToken        string   `mapstructure:"smd-token"`
+	CredentialsFile string   `mapstructure:"credentials-file"`
+	BMCUsername     string   `mapstructure:"bmc-username"`
+	BMCPassword     string   `mapstructure:"bmc-password"`
+	Auth            string   `mapstructure:"auth"`
+
+	// Connection Configuration
+	CABundle           string        `mapstructure:"ca-bundle"`
+	InsecureSkipVerify bool          `mapstructure:"insecure-skip-verify"`
+	Timeout            time.Duration `mapstructure:"timeout"`
+	Retries            int           `mapstructure:"retries"`
+	RetryDelay         time.Duration `mapstructure:"retry-delay"`
+	Concurrency        int           `mapstructure:"concurrency"`
+	NoExpand           bool          `mapstructure:"no-expand"`
+
+	// Sweep Configuration
+	Workers     int           `mapstructure:"workers"`
+	HostTimeout time.Duration `mapstructure:"host-timeout"`
+
+	// Daemon Configuration
+	Daemon        bool          `mapstructure:"daemon"`
+	Interval      time.Duration `mapstructure:"interval"`
+	Jitter        float64       `mapstructure:"jitter"`
+	RetryInterval time.Duration `mapstructure:"retry-interval"`
+	MaxBackoff    time.Duration `mapstructure:"max-backoff"`
+	Listen        string        `mapstructure:"listen"`
+}
+
+// DefaultConfig returns the default configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Server:  "http://localhost:8080",
+		Sources: []string{collector.RedfishSourceName},
+		Auth:    collector.AuthSession,
+		Timeout: 30 * time.Second,
+
+		Retries:     3,
+		RetryDelay:  time.Second,
+		Concurrency: 4,
+
+		Workers:     collector.DefaultWorkers,
+		HostTimeout: 10 * time.Minute,
+
+		Interval:      time.Hour,
+		Jitter:        0.1,
+		RetryInterval: time.Minute,
+		MaxBackoff:    time.Hour,
+		Listen:        ":9101",
+	}
+}
+
+var (
+	cfgFile string
+	config  *Config
+)
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+var rootCmd = &cobra.Command{
+	Use:   "collector",
+	Short: "Gathers hardware inventory via Redfish and posts it to the fru-tracker API",
+	Long: `Walks the Redfish Chassis, Systems and Managers trees of each BMC and posts
+the devices found to the fru-tracker API as one DiscoverySnapshot per BMC.
+
+BMCs come from --bmc, a --bmc-file with one per line, --cidr ranges and the
+RedfishEndpoints of an OpenCHAMI SMD (--smd-url), in any combination. They are
+collected --workers at a time, each within --host-timeout, and a summary of
+successes and failures is printed at the end. The exit status is non-zero if
+any BMC failed.
+
+With --output the snapshots are saved instead of posted, for BMC networks
+that cannot reach the API: to that file for a single BMC, or as one
+<snapshot name>.json per BMC when the path is a directory or several BMCs are
+collected. Upload them later with 'client discoverysnapshot upload'.
+
+With --source the collector inventories the Linux host it runs on instead of
+BMCs, for nodes without a reachable BMC: sysfs reads /sys/class/dmi, and
+dmidecode, lshw and lspci parse the output of those tools. "host" selects all
+of them; what they report about the same device is merged into one snapshot
+of the node. Run it as root, or serial numbers will be missing.
+
+With --daemon the collector keeps running instead: each BMC is re-collected
+every --interval (moved by up to --jitter of it at random), an unreachable BMC
+is retried after --retry-interval, doubling up to --max-backoff, and a snapshot
+is only posted when the inventory differs from the last one posted. Health and
+Prometheus metrics are served on --listen at /healthz and /metrics.
+
+BMC passwords are never taken as flags. Put them in a credentials file:
+
+  default:
+    username: root
+    password: secret
+  bmcs:
+    10.0.0.5:
+      username: admin
+      password: other
+
+or set FRU_COLLECTOR_BMC_USERNAME and FRU_COLLECTOR_BMC_PASSWORD.
+
+Examples:
+  # Collect one BMC with a private CA
+  collector --bmc 10.0.0.5 --ca-bundle /etc/pki/bmc-ca.pem --credentials-file ~/.bmc-credentials.yaml
+
+  # Post to a secured API
+  collector --bmc 10.0.0.5 --server https://fru-tracker.example.com --token-file /run/secrets/fru-token
+
+  # Collect on an air-gapped network, then upload from a connected host
+  collector --bmc 10.0.0.5 --output snapshot.json
+  client discoverysnapshot upload snapshot.json
+
+  # Sweep two racks of BMCs, 64 at a time
+  collector --cidr 10.1.0.0/24,10.2.0.0/24 --workers 64
+
+  # Sweep every BMC SMD knows about
+  FRU_COLLECTOR_SMD_TOKEN=$(cat /run/secrets/smd-token) collector --smd-url https://smd.example.com
+
+  # Inventory the node the collector runs on
+  sudo collector --source host --server https://fru-tracker.example.com
+
+  # Keep a rack's inventory fresh, re-collecting every 30 minutes
+  collector --daemon --interval 30m --bmc-file rack1.txt
+`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runCollector,
+}
+
+func init() {
+	cobra.OnInitialize(initConfig)
+
+	flags := rootCmd.Flags()
+	flags.StringVar(&cfgFile, "config", "", "config file (default is $HOME/.fru-collector.yaml)")
+	flags.String("server", "http://localhost:8080", "fru-tracker API URL")
+	flags.String("token", "", "Bearer token for the fru-tracker API")
+	flags.String("token-file", "", "File holding the bearer token for the fru-tracker API")
+	flags.String("output", "", "Save snapshots to this file or directory instead of posting them")
+	flags.StringSlice("source", []string{collector.RedfishSourceName},
+		"Inventory sources: redfish to collect BMCs, or host sources (sysfs, dmidecode, lshw, lspci, or host for all) to collect this machine")
+	flags.StringSlice("bmc", nil, "BMCs to collect, as host, host:port or URL")
+	flags.String("bmc-file", "", "File listing BMCs to collect, one per line")
+	flags.StringSlice("cidr", nil, "CIDR ranges whose addresses to collect as BMCs")
+	flags.String("smd-url", "", "OpenCHAMI SMD URL to list BMCs (RedfishEndpoints) from")
+	flags.String("credentials-file", "", "YAML file of BMC credentials (default and per BMC)")
+	flags.String("bmc-username", "", "BMC username for BMCs without an entry in the credentials file")
+	flags.String("auth", collector.AuthSession, "Redfish authentication: session (SessionService token) or basic")
+	flags.String("ca-bundle", "", "PEM file of CAs to trust for BMC and API certificates")
+	flags.Bool("insecure-skip-verify", false, "Skip BMC certificate verification (lab use only)")
+	flags.Duration("timeout", 30*time.Second, "Timeout for each Redfish and API request")
+	flags.Int("retries", 3, "Retries of a Redfish request answered with 429 or a 5xx status")
+	flags.Duration("retry-delay", time.Second, "Delay before the first retry of a Redfish request, doubling on each retry")
+	flags.Int("concurrency", 4, "Redfish requests in flight to each BMC")
+	flags.Bool("no-expand", false, "Do not request Redfish collections with $expand")
+	flags.Int("workers", collector.DefaultWorkers, "Number of BMCs collected at once")
+	flags.Duration("host-timeout", 10*time.Minute, "Time allowed to collect and post each BMC")
+	flags.Bool("daemon", false, "Keep running and re-collect every BMC on a schedule")
+	flags.Duration("interval", time.Hour, "Time between collections of each BMC in daemon mode")
+	flags.Float64("jitter", 0.1, "Fraction of the interval each delay is moved by at random")
+	flags.Duration("retry-interval", time.Minute, "Delay before retrying a failed BMC, doubling on each failure")
+	flags.Duration("max-backoff", time.Hour, "Longest delay between retries of a failing BMC")
+	flags.String("listen", ":9101", "Address to serve /healthz and /metrics on in daemon mode")
+
+	// Bind flags to viper
+	viper.BindPFlags(flags)
+}
+
+func initConfig() {
+	config = DefaultConfig()
+
+	if cfgFile != "" {
+		viper.SetConfigFile(cfgFile)
+	} else {
+		// Search for config in home directory
+		home, err := os.UserHomeDir()
+		cobra.CheckErr(err)
+
+		viper.AddConfigPath(home)
+		viper.AddConfigPath(".")
+		viper.SetConfigType("yaml")
+		viper.SetConfigName(".fru-collector")
+	}
+
+	// Environment variables, with dashes in keys as underscores
+	viper.SetEnvPrefix("FRU_COLLECTOR")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv()
+	// Secrets have no flag, so AutomaticEnv alone would not surface them in Unmarshal
+	viper.BindEnv("bmc-password")
+	viper.BindEnv("smd-token")
+
+	// Read config file if it exists
+	if err := viper.ReadInConfig(); err == nil {
+		log.Printf("Using config file: %s", viper.ConfigFileUsed())
+	}
+
+	// Unmarshal config
+	if err := viper.Unmarshal(config); err != nil {
+		log.Fatalf("Unable to decode into config struct: %v", err)
+	}
+}
+
+func runCollector(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c, serviceClient, err := newCollector(config)
+	if err != nil {
+		return err
+	}
+	sources, err := hostSources(config.Sources)
+	if err != nil {
+		return err
+	}
+	if len(sources) > 0 {
+		return runHost(ctx, c, sources, config)
+	}
+	bmcs, err := loadTargets(ctx, config, serviceClient)
+	if err != nil {
+		return err
+	}
+	if len(bmcs) == 0 {
+		return fmt.Errorf("no BMCs to collect: set --bmc, --bmc-file, --cidr or --smd-url")
+	}
+
+	if config.Daemon {
+		if config.Output != "" {
+			return fmt.Errorf("--output cannot be combined with --daemon")
+		}
+		return runDaemon(ctx, c, bmcs, config)
+	}
+	if config.Output != "" {
+		info, err := os.Stat(config.Output)
+		c.SetOutput(config.Output, len(bmcs) > 1 || (err == nil && info.IsDir()))
+	}
+
+	log.Printf("Starting inventory collection for %d BMCs with %d workers", len(bmcs), config.Workers)
+	summary := c.Sweep(ctx, bmcs, config.Workers, config.HostTimeout)
+	if err := summary.Write(os.Stdout); err != nil {
+		return err
+	}
+	if failed := len(summary.Failed()); failed > 0 {
+		return fmt.Errorf("%d of %d BMCs failed", failed, len(bmcs))
+	}
+	return nil
+}
+
+// runDaemon re-collects bmcs until ctx is cancelled, serving health and
+// metrics alongside.
+func runDaemon(ctx context.Context, c *collector.Collector, bmcs []string, cfg *Config) error {
+	daemon := collector.NewDaemon(c, bmcs, collector.DaemonOptions{
+		Interval:      cfg.Interval,
+		Jitter:        cfg.Jitter,
+		RetryInterval: cfg.RetryInterval,
+		MaxBackoff:    cfg.MaxBackoff,
+		Workers:       cfg.Workers,
+		HostTimeout:   cfg.HostTimeout,
+	})
+
+	// Listen up front so a taken port fails the start instead of a goroutine
+	listener, err := net.Listen("tcp", cfg.Listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", cfg.Listen, err)
+	}
+	server := &http.Server{
+		Handler:           daemon.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		log.Printf("Serving health and metrics on %s", listener.Addr())
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("Warning: Health server failed: %v", err)
+		}
+	}()
+
+	log.Printf("Collecting %d BMCs every %s with %d workers", len(bmcs), cfg.Interval, cfg.Workers)
+	daemon.Run(ctx)
+	log.Println("Collector shutting down...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to stop health server: %w", err)
+	}
+	return nil
+}
+
+// hostSources resolves the --source names to host sources. It returns none
+// for a Redfish collection, and "host" stands for every host source.
+func hostSources(names []string) ([]string, error) {
+	var sources []string
+	redfish := false
+	for _, name := range names {
+		switch name = strings.TrimSpace(name); name {
+		case collector.RedfishSourceName:
+			redfish = true
+		case "host":
+			sources = append(sources, host.Names()...)
+		default:
+			sources = append(sources, name)
+		}
+	}
+	if redfish && len(sources) > 0 {
+		return nil, fmt.Errorf("the redfish source cannot be combined with host sources")
+	}
+	return collector.UniqueTargets(sources), nil
+}
+
+// runHost collects the machine the collector runs on from the named sources
+// and posts or saves the result as one snapshot.
+func runHost(ctx context.Context, c *collector.Collector, names []string, cfg *Config) error {
+	if cfg.Daemon {
+		return fmt.Errorf("--daemon only collects BMCs")
+	}
+	if len(cfg.BMCs) > 0 || cfg.BMCFile != "" || len(cfg.CIDRs) > 0 || cfg.SMDURL != "" {
+		return fmt.Errorf("BMCs cannot be collected with host sources")
+	}
+
+	node := host.DetectNode("/")
+	sources := make([]collector.Source, 0, len(names))
+	for _, name := range names {
+		source, err := host.New(name, node)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, source)
+	}
+
+	log.Printf("Collecting %s from %s", node.Locator(), strings.Join(names, ", "))
+	specs, err := collector.CollectSources(ctx, sources)
+	if err != nil {
+		return err
+	}
+	createReq, err := collector.NewHostSnapshotRequest(node.Hostname, names, specs, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if cfg.Output != "" {
+		path := cfg.Output
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			path = filepath.Join(path, createReq.Metadata.Name+".json")
+		}
+		if err := collector.WriteSnapshotFile(path, createReq); err != nil {
+			return err
+		}
+		fmt.Printf("Saved %d devices to %s\n", len(specs), path)
+		return nil
+	}
+	snapshot, err := c.Submit(ctx, createReq)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Posted %d devices as snapshot %s\n", len(specs), snapshot.Metadata.Name)
+	return nil
+}
+
+// loadTargets gathers the BMCs to collect from every configured source,
+// dropping repeats.
+func loadTargets(ctx context.Context, cfg *Config, httpClient *http.Client) ([]string, error) {
+	bmcs := append([]string(nil), cfg.BMCs...)
+	if cfg.BMCFile != "" {
+		listed, err := collector.ReadTargetsFile(cfg.BMCFile)
+		if err != nil {
+			return nil, err
+		}
+		bmcs = append(bmcs, listed...)
+	}
+	for _, cidr := range cfg.CIDRs {
+		expanded, err := collector.ExpandCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		bmcs = append(bmcs, expanded...)
+	}
+	if cfg.SMDURL != "" {
+		registered, err := collector.LoadSMDTargets(ctx, httpClient, cfg.SMDURL, cfg.SMDToken)
+		if err != nil {
+			return nil, err
+		}
+		bmcs = append(bmcs, registered...)
+	}
+	return collector.UniqueTargets(bmcs), nil
+}
+
+// newCollector builds a Collector from the configuration, along with the
+// verifying HTTP client used for other OpenCHAMI services such as SMD.
+func newCollector(cfg *Config) (*collector.Collector, *http.Client, error) {
+	credentials, err := loadCredentials(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	token, err := loadToken(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// BMCs are often self-signed; the opt-out never applies to the API
+	bmcTLS, err := collector.NewTLSConfig(cfg.CABundle, cfg.InsecureSkipVerify)
+	if err != nil {
+		return nil, nil, err
+	}
+	apiTLS, err := collector.NewTLSConfig(cfg.CABundle, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	bmcClient := &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: &http.Transport{TLSClientConfig: bmcTLS, Proxy: http.ProxyFromEnvironment},
+	}
+	apiHTTPClient := &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: &http.Transport{TLSClientConfig: apiTLS, Proxy: http.ProxyFromEnvironment},
+	}
+
+	api, err := fabricaclient.NewClientWithBearerToken(cfg.Server, token, apiHTTPClient)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create fabrica client: %w", err)
+	}
+	c := collector.New(api, bmcClient, credentials, cfg.Auth)
+	c.SetRedfishOptions(collector.RedfishOptions{
+		Retries:     cfg.Retries,
+		RetryDelay:  cfg.RetryDelay,
+		Concurrency: cfg.Concurrency,
+		NoExpand:    cfg.NoExpand,
+	})
+	return c, apiHTTPClient, nil
+}
+
+// loadCredentials reads the credentials file, if any. A username from a flag
+// or the environment replaces the file's default.
+func loadCredentials(cfg *Config) (*collector.CredentialStore, error) {
+	store := collector.NewCredentialStore(collector.Credentials{})
+	if cfg.CredentialsFile != "" {
+		var err error
+		if store, err = collector.LoadCredentialsFile(cfg.CredentialsFile); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.BMCUsername != "" {
+		store.SetDefault(collector.Credentials{Username: cfg.BMCUsername, Password: cfg.BMCPassword})
+	}
+	return store, nil
+}
+
+// loadToken returns the API bearer token, preferring --token over --token-file.
+func loadToken(cfg *Config) (string, error) {
+	if cfg.Token != "" || cfg.TokenFile == "" {
+		return cfg.Token, nil
+	}
+	data, err := os.ReadFile(cfg.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
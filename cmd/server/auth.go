@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/example/fru-tracker/internal/auth"
+)
+
+// Authenticate requires every request to carry a bearer token the verifier
+// accepts, granting a role allowed to make it. Requests without a valid token
+// are answered with 401 and those the token's roles do not allow with 403.
+func Authenticate(verifier *auth.Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="fru-tracker"`)
+				respondError(w, http.StatusUnauthorized, errors.New("a bearer token is required"))
+				return
+			}
+			claims, err := verifier.Verify(r.Context(), token)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="fru-tracker", error="invalid_token"`)
+				respondError(w, http.StatusUnauthorized, err)
+				return
+			}
+
+			roles := requiredRoles(r)
+			allowed := false
+			for _, role := range roles {
+				allowed = allowed || claims.HasRole(role)
+			}
+			if !allowed {
+				respondError(w, http.StatusForbidden, fmt.Errorf("%s %s requires the %s role", r.Method, r.URL.Path, strings.Join(roles, " or ")))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), claims)))
+		})
+	}
+}
+
+// requiredRoles returns the roles that may make a request. Reads, exports and
+// the change feed included, are open to readers, except webhook subscriptions,
+// whose URLs may carry credentials. Collectors may only post snapshots.
+// Everything else, such as editing or deleting devices directly and managing
+// webhooks, is for admins.
+func requiredRoles(r *http.Request) []string {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		if path == "/webhooksubscriptions" || strings.HasPrefix(path, "/webhooksubscriptions/") {
+			return []string{auth.RoleAdmin}
+		}
+		return []string{auth.RoleReader, auth.RoleAdmin}
+	case r.Method == http.MethodPost && path == "/discoverysnapshots":
+		return []string{auth.RoleCollector, auth.RoleAdmin}
+	}
+	return []string{auth.RoleAdmin}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/example/fru-tracker/internal/auth"
+)
+
+func newAuthCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Create keys and tokens for API authentication",
+		Long: `Create a signing key and tokens for API authentication without an
+identity provider, for local testing or small deployments.
+
+Examples:
+  # Create a signing key and the JWKS the server verifies tokens with
+  fru_tracker auth keygen --key signing-key.pem --jwks jwks.json
+  fru_tracker serve --auth-jwks jwks.json
+
+  # Issue a token for a collector, valid for 30 days
+  fru_tracker auth token --key signing-key.pem --subject rack12-collector --roles collector --ttl 720h
+`,
+	}
+	cmd.AddCommand(newAuthKeygenCommand(), newAuthTokenCommand())
+	return cmd
+}
+
+func newAuthKeygenCommand() *cobra.Command {
+	var keyFile, jwksFile, kid string
+	cmd := &cobra.Command{
+		Use:   "keygen",
+		Short: "Create a signing key and its JWKS",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				return fmt.Errorf("failed to generate key: %w", err)
+			}
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			if err != nil {
+				return fmt.Errorf("failed to encode key: %w", err)
+			}
+			jwk, err := auth.NewJWK(key.Public(), kid)
+			if err != nil {
+				return err
+			}
+			if jwk.Kid == "" {
+				jwk.Kid = jwk.Thumbprint()
+			}
+			jwks, err := json.MarshalIndent(auth.JWKS{Keys: []auth.JWK{jwk}}, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+				return fmt.Errorf("failed to write key: %w", err)
+			}
+			if err := os.WriteFile(jwksFile, append(jwks, '\n'), 0o644); err != nil {
+				return fmt.Errorf("failed to write JWKS: %w", err)
+			}
+			fmt.Printf("Wrote signing key to %s and JWKS to %s\n", keyFile, jwksFile)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&keyFile, "key", "signing-key.pem", "File to write the private key to")
+	cmd.Flags().StringVar(&jwksFile, "jwks", "jwks.json", "File to write the JWKS to")
+	cmd.Flags().StringVar(&kid, "kid", "", "Key ID to publish the key under (default: the key's RFC 7638 thumbprint)")
+	return cmd
+}
+
+func newAuthTokenCommand() *cobra.Command {
+	var (
+		keyFile  string
+		kid      string
+		subject  string
+		roles    []string
+		ttl      time.Duration
+		issuer   string
+		audience string
+	)
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Issue a token signed with a key from keygen",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(keyFile)
+			if err != nil {
+				return fmt.Errorf("failed to read key: %w", err)
+			}
+			block, _ := pem.Decode(data)
+			if block == nil {
+				return fmt.Errorf("%s holds no PEM key", keyFile)
+			}
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return fmt.Errorf("failed to parse key: %w", err)
+			}
+			signer, ok := key.(crypto.Signer)
+			if !ok {
+				return fmt.Errorf("%s does not hold a signing key", keyFile)
+			}
+			// Tokens name their key, so the JWKS may hold several
+			if kid == "" {
+				jwk, err := auth.NewJWK(signer.Public(), "")
+				if err != nil {
+					return err
+				}
+				kid = jwk.Thumbprint()
+			}
+
+			now := time.Now()
+			claims := map[string]any{
+				"sub":   subject,
+				"roles": roles,
+				"iat":   now.Unix(),
+				"exp":   now.Add(ttl).Unix(),
+			}
+			if issuer != "" {
+				claims["iss"] = issuer
+			}
+			if audience != "" {
+				claims["aud"] = audience
+			}
+			token, err := auth.Sign(signer, kid, claims)
+			if err != nil {
+				return err
+			}
+			fmt.Println(token)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&keyFile, "key", "signing-key.pem", "Private key from keygen")
+	cmd.Flags().StringVar(&kid, "kid", "", "Key ID, if keygen was given one (default: the key's thumbprint)")
+	cmd.Flags().StringVar(&subject, "subject", "", "Who the token is for")
+	cmd.Flags().StringSliceVar(&roles, "roles", []string{auth.RoleReader}, "Roles to grant: reader, collector, admin")
+	cmd.Flags().DurationVar(&ttl, "ttl", 24*time.Hour, "How long the token is valid")
+	cmd.Flags().StringVar(&issuer, "issuer", "", "iss claim, to match --auth-issuer")
+	cmd.Flags().StringVar(&audience, "audience", "", "aud claim, to match --auth-audience")
+	return cmd
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/fru-tracker/internal/auth"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticate(t *testing.T) {
+	client := storagetest.Open(t)
+	storage.SetEntClient(client)
+	require.NoError(t, registerResourcePrefixes())
+
+	// A local key stands in for the identity provider
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	jwk, err := auth.NewJWK(key.Public(), "test")
+	require.NoError(t, err)
+	jwks, err := json.Marshal(auth.JWKS{Keys: []auth.JWK{jwk}})
+	require.NoError(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, jwks, 0o644))
+	keys, err := auth.NewKeySet(context.Background(), jwksFile)
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	registerRoutes(r, Authenticate(auth.NewVerifier(keys, auth.Options{Audience: "fru-tracker"})))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	tokenFor := func(roles ...string) string {
+		t.Helper()
+		token, err := auth.Sign(key, "test", map[string]any{
+			"sub":   "tester",
+			"aud":   "fru-tracker",
+			"roles": roles,
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		})
+		require.NoError(t, err)
+		return token
+	}
+	send := func(method, path, token, body string) int {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	reader := tokenFor(auth.RoleReader)
+	collector := tokenFor(auth.RoleCollector)
+	admin := tokenFor(auth.RoleAdmin)
+	snapshot := `{"metadata": {"name": "snap"}, "spec": {"rawData": [{"deviceType": "Node", "serialNumber": "NODE-1"}]}}`
+	device := `{"metadata": {"name": "dev"}, "spec": {"deviceType": "Node", "serialNumber": "NODE-2"}}`
+
+	// The health check and documentation are public, the API is not
+	assert.Equal(t, http.StatusOK, send(http.MethodGet, "/health", "", ""))
+	assert.Equal(t, http.StatusOK, send(http.MethodGet, "/openapi.json", "", ""))
+	assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, "/devices", "", ""))
+	assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, "/devices?format=csv", "", ""))
+	assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, "/devices", "garbage", ""))
+	otherAudience, err := auth.Sign(key, "test", map[string]any{"aud": "other", "roles": []string{"admin"}, "exp": time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, "/devices", otherAudience, ""))
+
+	// Readers read and export everything except webhook subscriptions
+	assert.Equal(t, http.StatusOK, send(http.MethodGet, "/devices", reader, ""))
+	for _, export := range []string{
+		"/devices?format=csv",
+		"/devices?format=bom&groupBy=rack",
+		"/devices?format=firmware",
+		"/devices?format=health",
+		"/changes",
+		"/changes?kinds=Device",
+	} {
+		assert.Equal(t, http.StatusOK, send(http.MethodGet, export, reader, ""), export)
+	}
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/webhooksubscriptions", reader, ""))
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/webhooksubscriptions/webhooksubscription-1", reader, ""))
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/discoverysnapshots", reader, snapshot))
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/devices", reader, device))
+
+	// Collectors only post snapshots
+	assert.Equal(t, http.StatusCreated, send(http.MethodPost, "/discoverysnapshots", collector, snapshot))
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/discoverysnapshots", collector, ""))
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/devices", collector, device))
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/webhooksubscriptions", collector, "{}"))
+
+	// Admins do everything
+	assert.Equal(t, http.StatusCreated, send(http.MethodPost, "/devices", admin, device))
+	assert.Equal(t, http.StatusOK, send(http.MethodGet, "/changes", admin, ""))
+	assert.Equal(t, http.StatusOK, send(http.MethodGet, "/discoverysnapshots", admin, ""))
+	assert.Equal(t, http.StatusOK, send(http.MethodGet, "/webhooksubscriptions", admin, ""))
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/example/fru-tracker/internal/storage"
+)
+
+// exportableKinds lists the resource kinds covered by export and change feeds.
+var exportableKinds = []string{"Device", "DiscoverySnapshot"}
+
+// GetChanges returns the resources created, updated or deleted after the
+// `since` query parameter (RFC3339). The optional `kinds` parameter takes a
+// comma-separated list of resource kinds. Callers should pass the returned
+// `until` value as `since` on their next request, which repeats the changes
+// of the overlap (see storage.ChangeSet), and start over without `since` when
+// answered 410 Gone.
+func GetChanges(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	since, err := parseSince(query.Get("since"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	kinds, err := parseKinds(query.Get("kinds"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	changes, err := storage.LoadChangesSince(r.Context(), kinds, since)
+	if errors.Is(err, storage.ErrChangesExpired) {
+		respondError(w, http.StatusGone, err)
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load changes: %w", err))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, changes)
+}
+
+// parseSince parses an RFC3339 timestamp. An empty value means "since the beginning".
+func parseSince(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	since, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid since timestamp %q: must be RFC3339", value)
+	}
+	return since, nil
+}
+
+// parseKinds splits a comma-separated kinds list, defaulting to every exportable kind.
+func parseKinds(value string) ([]string, error) {
+	if value == "" {
+		return exportableKinds, nil
+	}
+	kinds := splitList(value)
+	for _, kind := range kinds {
+		if !isExportableKind(kind) {
+			return nil, fmt.Errorf("unknown resource kind: %s", kind)
+		}
+	}
+	return kinds, nil
+}
+
+func isExportableKind(kind string) bool {
+	for _, known := range exportableKinds {
+		if known == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/fabrica"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChangesReportsUpdatesAndTombstones(t *testing.T) {
+	client := storagetest.Open(t)
+	storage.RecordTombstones(client, storage.DefaultTombstoneRetention)
+	storage.SetEntClient(client)
+
+	ctx := context.Background()
+	saveDevice := func(uid, serial string) {
+		require.NoError(t, storage.SaveDevice(ctx, &v1.Device{
+			APIVersion: "example.fabrica.dev/v1",
+			Kind:       "Device",
+			Metadata:   fabrica.Metadata{UID: uid, Name: serial, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+			Spec:       v1.DeviceSpec{DeviceType: "DIMM", SerialNumber: serial},
+		}))
+	}
+
+	saveDevice("device-old", "dimm-old")
+	saveDevice("device-doomed", "dimm-doomed")
+
+	time.Sleep(10 * time.Millisecond)
+	checkpoint := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	saveDevice("device-new", "dimm-new")
+	require.NoError(t, storage.DeleteDevice(ctx, "device-doomed"))
+
+	r := chi.NewRouter()
+	RegisterCustomRoutes(r)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantUIDs   []string
+		wantGone   []string
+	}{
+		{
+			name:       "changes since checkpoint",
+			query:      "?since=" + checkpoint.UTC().Format(time.RFC3339Nano),
+			wantStatus: http.StatusOK,
+			wantUIDs:   []string{"device-new"},
+			wantGone:   []string{"device-doomed"},
+		},
+		{
+			name:       "everything without since",
+			query:      "?kinds=Device",
+			wantStatus: http.StatusOK,
+			wantUIDs:   []string{"device-old", "device-new"},
+			wantGone:   []string{"device-doomed"},
+		},
+		{
+			name:       "since before the tombstone retention",
+			query:      "?since=" + time.Now().Add(-storage.DefaultTombstoneRetention-time.Hour).UTC().Format(time.RFC3339),
+			wantStatus: http.StatusGone,
+		},
+		{
+			name:       "invalid timestamp",
+			query:      "?since=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown kind",
+			query:      "?kinds=Rack",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/changes"+tt.query, nil))
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var changes struct {
+				Since      time.Time            `json:"since"`
+				Until      time.Time            `json:"until"`
+				Resources  []v1.Device          `json:"resources"`
+				Tombstones []*storage.Tombstone `json:"tombstones"`
+			}
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&changes))
+			// Writes committing late are covered by the next request, but
+			// until never goes back past since
+			wantUntil := time.Now().Add(-storage.ChangesOverlap)
+			if wantUntil.Before(changes.Since) {
+				wantUntil = changes.Since
+			}
+			assert.WithinDuration(t, wantUntil, changes.Until, 5*time.Second)
+
+			var uids []string
+			for _, device := range changes.Resources {
+				uids = append(uids, device.Metadata.UID)
+			}
+			assert.ElementsMatch(t, tt.wantUIDs, uids)
+
+			var gone []string
+			for _, tombstone := range changes.Tombstones {
+				assert.Equal(t, storage.TombstoneKind, tombstone.Kind)
+				assert.Equal(t, "Device", tombstone.ResourceKind)
+				gone = append(gone, tombstone.UID)
+			}
+			assert.ElementsMatch(t, tt.wantGone, gone)
+		})
+	}
+}
+
+func TestTombstonesPrunedAfterRetention(t *testing.T) {
+	client := storagetest.Open(t)
+	storage.RecordTombstones(client, time.Hour)
+	t.Cleanup(func() { storage.RecordTombstones(client, storage.DefaultTombstoneRetention) })
+	storage.SetEntClient(client)
+
+	ctx := context.Background()
+	require.NoError(t, client.Tombstone.Create().
+		SetUID("device-ancient").
+		SetName("dimm-ancient").
+		SetKind("Device").
+		SetDeletedAt(time.Now().Add(-2*time.Hour)).
+		Exec(ctx))
+	require.NoError(t, storage.SaveDevice(ctx, &v1.Device{
+		APIVersion: "example.fabrica.dev/v1",
+		Kind:       "Device",
+		Metadata:   fabrica.Metadata{UID: "device-doomed", Name: "dimm-doomed", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		Spec:       v1.DeviceSpec{DeviceType: "DIMM", SerialNumber: "dimm-doomed"},
+	}))
+	require.NoError(t, storage.DeleteDevice(ctx, "device-doomed"))
+
+	var uids []string
+	for _, tombstone := range client.Tombstone.Query().AllX(ctx) {
+		uids = append(uids, tombstone.UID)
+	}
+	assert.Equal(t, []string{"device-doomed"}, uids)
+
+	r := chi.NewRouter()
+	RegisterCustomRoutes(r)
+	get := func(since time.Time) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/changes?since="+since.UTC().Format(time.RFC3339), nil))
+		return w
+	}
+
+	w := get(time.Now().Add(-3 * time.Hour))
+	assert.Equal(t, http.StatusGone, w.Code, w.Body.String())
+	w = get(time.Now().Add(-30 * time.Minute))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
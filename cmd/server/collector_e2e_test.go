@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/redfishmock"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	fabricaclient "github.com/example/fru-tracker/pkg/client"
+	"github.com/example/fru-tracker/pkg/collector"
+	"github.com/example/fru-tracker/pkg/reconcilers"
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCollectorEndToEnd runs the collector against a mock BMC and posts to
+// the API served in-process, then reconciles the snapshot into devices.
+func TestCollectorEndToEnd(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.Open(t)
+	storage.SetEntClient(client)
+	require.NoError(t, registerResourcePrefixes())
+
+	r := chi.NewRouter()
+	RegisterGeneratedRoutes(r)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	resources, err := redfishmock.Fixture("standard")
+	require.NoError(t, err)
+	bmc := redfishmock.New(resources)
+	defer bmc.Close()
+	bmc.Username, bmc.Password, bmc.Sessions = "root", "secret", true
+	bmc.PageSize = 2
+
+	api, err := fabricaclient.NewClient(server.URL, server.Client())
+	require.NoError(t, err)
+	c := collector.New(api, bmc.Client(), collector.NewCredentialStore(collector.Credentials{Username: "root", Password: "secret"}), collector.AuthSession)
+
+	bus := events.NewInMemoryEventBus(10, 10)
+	bus.Start()
+	t.Cleanup(func() {
+		_ = bus.Close()
+	})
+	reconciler := reconcilers.NewDefaultDiscoverySnapshotReconciler(storage.NewStorageClient(), bus)
+
+	// collect posts one snapshot and reconciles it as the controller would
+	collect := func() {
+		t.Helper()
+		snapshot, err := c.CollectAndPost(ctx, bmc.URL)
+		require.NoError(t, err)
+		persisted, err := storage.LoadDiscoverySnapshot(ctx, snapshot.Metadata.UID)
+		require.NoError(t, err)
+		raw, err := json.Marshal(persisted)
+		require.NoError(t, err)
+		_, err = reconciler.Reconcile(ctx, json.RawMessage(raw))
+		require.NoError(t, err)
+	}
+
+	collect()
+	devices, err := api.GetDevices(ctx)
+	require.NoError(t, err)
+	require.Len(t, devices, 16)
+
+	bySerial := make(map[string]v1.Device, len(devices))
+	for _, device := range devices {
+		bySerial[device.Spec.SerialNumber] = device
+	}
+	node := bySerial["SYS-0001"]
+	assert.Equal(t, "Node", node.Spec.DeviceType)
+	assert.Empty(t, node.Spec.ParentID)
+	for _, serial := range []string{"CPU-0001", "GPU-0001", "DIMM-0004", "DRIVE-0002", "PSU-0002", "FAN-0001", "NIC-0001", "BMC-0001"} {
+		assert.Equal(t, node.Metadata.UID, bySerial[serial].Spec.ParentID, serial)
+	}
+
+	// A DIMM swapped between collections is a new FRU in the same slot
+	bmc.Set("/redfish/v1/Systems/1/Memory/DIMM2", json.RawMessage(
+		`{"Manufacturer": "Contoso", "PartNumber": "M386A4G40DM0", "SerialNumber": "DIMM-0099", "CapacityMiB": 32768}`))
+	collect()
+	devices, err = api.GetDevices(ctx)
+	require.NoError(t, err)
+	require.Len(t, devices, 17)
+	var replacement *v1.Device
+	for i := range devices {
+		if devices[i].Spec.SerialNumber == "DIMM-0099" {
+			replacement = &devices[i]
+		}
+	}
+	require.NotNil(t, replacement)
+	assert.NotEqual(t, bySerial["DIMM-0002"].Metadata.UID, replacement.Metadata.UID)
+	assert.Equal(t, node.Metadata.UID, replacement.Spec.ParentID)
+	assert.JSONEq(t, `"/Systems/1/Memory/DIMM2"`, string(replacement.Spec.Properties[v1.PropertyRedfishURI]))
+}
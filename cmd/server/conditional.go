@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	mw "github.com/example/fru-tracker/internal/middleware"
+	"github.com/example/fru-tracker/internal/storage"
+)
+
+// conditionalResources loads the current version of a resource by the
+// collection it is served under, to check If-Match against.
+var conditionalResources = map[string]func(ctx context.Context, uid string) (any, error){
+	"devices": func(ctx context.Context, uid string) (any, error) {
+		return storage.LoadDevice(ctx, uid)
+	},
+	"discoverysnapshots": func(ctx context.Context, uid string) (any, error) {
+		return storage.LoadDiscoverySnapshot(ctx, uid)
+	},
+	"webhooksubscriptions": func(ctx context.Context, uid string) (any, error) {
+		subscription, err := storage.LoadWebhookSubscription(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		// Clients only ever see, and so only send ETags of, the redacted form
+		return subscription.Redacted(), nil
+	},
+}
+
+// resourceLocks serialize writes to one resource through the API, so two
+// writers sending the same If-Match cannot both pass the check before either
+// has saved. Resources share the locks by hash of their UID.
+var resourceLocks [64]sync.Mutex
+
+func lockResource(uid string) func() {
+	h := fnv.New32a()
+	h.Write([]byte(uid))
+	lock := &resourceLocks[h.Sum32()%uint32(len(resourceLocks))]
+	lock.Lock()
+	return lock.Unlock
+}
+
+// ConditionalRequests implements optimistic concurrency for the generated
+// handlers, which know nothing of ETags. Every GET response carries an ETag
+// and is answered with 304 when it matches If-None-Match. PUT, PATCH and
+// DELETE of a resource or its status are refused with 412 when If-Match does
+// not match the resource's current ETag, and successful writes return the new
+// ETag so clients can chain read-modify-write cycles.
+//
+// The ETag of a resource is GenerateETag of the resource, which is the same
+// whether it is read from a GET response or loaded from storage.
+func ConditionalRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if streaming(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			serveWithETag(w, r, next)
+
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+			collection, uid, ok := resourcePath(r.URL.Path)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			unlock := lockResource(uid)
+			defer unlock()
+
+			// A resource that does not exist is left for the handler to 404
+			if r.Header.Get("If-Match") != "" {
+				if current, err := conditionalResources[collection](r.Context(), uid); err == nil {
+					etag, err := mw.GenerateETag(current)
+					if err != nil {
+						respondError(w, http.StatusInternalServerError, err)
+						return
+					}
+					if !mw.CheckIfMatch(w, r, etag) {
+						return
+					}
+				}
+			}
+			if r.Method == http.MethodDelete {
+				next.ServeHTTP(w, r)
+				return
+			}
+			serveWithETag(w, r, next)
+
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// serveWithETag buffers the response of next and adds the ETag of a
+// successful one.
+func serveWithETag(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	buffered := &bufferedResponse{ResponseWriter: w, status: http.StatusOK}
+	next.ServeHTTP(buffered, r)
+
+	body := buffered.body.Bytes()
+	if buffered.status == http.StatusOK {
+		if etag, err := bodyETag(body); err == nil {
+			mw.SetETag(w, etag)
+			if r.Method == http.MethodGet && !mw.CheckIfNoneMatch(w, r, etag) {
+				return
+			}
+		}
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(buffered.status)
+	_, _ = w.Write(body)
+}
+
+// bodyETag returns the ETag of a response body. JSON bodies get the ETag of
+// the value they encode, so a resource read with GET has the ETag If-Match is
+// later checked against.
+func bodyETag(body []byte) (string, error) {
+	if json.Valid(body) {
+		return mw.GenerateETag(json.RawMessage(body))
+	}
+	return mw.GenerateETag(body)
+}
+
+// resourcePath splits /{collection}/{uid} and /{collection}/{uid}/status for
+// the collections with conditional writes.
+func resourcePath(path string) (collection, uid string, ok bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != "status") {
+		return "", "", false
+	}
+	if _, ok := conditionalResources[parts[0]]; !ok || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// streaming reports whether a GET opens an event stream, which must reach
+// the client as it is written.
+func streaming(r *http.Request) bool {
+	if strings.TrimSuffix(r.URL.Path, "/") == "/events" {
+		return true
+	}
+	watch, _ := strconv.ParseBool(r.URL.Query().Get("watch"))
+	return watch
+}
+
+// bufferedResponse holds back the status and body of a response. Headers go
+// straight to the underlying writer.
+type bufferedResponse struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if !b.wroteHeader {
+		b.status = status
+		b.wroteHeader = true
+	}
+}
+
+func (b *bufferedResponse) Write(data []byte) (int, error) {
+	b.wroteHeader = true
+	return b.body.Write(data)
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	mw "github.com/example/fru-tracker/internal/middleware"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/example/fru-tracker/pkg/client"
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/fabrica"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	entClient := storagetest.Open(t)
+	storage.SetEntClient(entClient)
+	require.NoError(t, registerResourcePrefixes())
+
+	r := chi.NewRouter()
+	r.Use(ConditionalRequests)
+	r.Use(DeviceListVariants)
+	RegisterGeneratedRoutes(r)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	ctx := context.Background()
+	c, err := client.NewClient(server.URL, client.WithConditionalRequests(server.Client()))
+	require.NoError(t, err)
+	created, err := c.CreateDevice(ctx, client.CreateDeviceRequest{
+		Metadata: fabrica.Metadata{Name: "NODE-1"},
+		Spec:     v1.DeviceSpec{DeviceType: "Node", SerialNumber: "NODE-1"},
+	})
+	require.NoError(t, err)
+	uid := created.Metadata.UID
+
+	// The ETag of a GET is the ETag of the stored resource
+	var etag string
+	device, err := c.GetDevice(client.CaptureETag(ctx, &etag), uid)
+	require.NoError(t, err)
+	stored, err := storage.LoadDevice(ctx, uid)
+	require.NoError(t, err)
+	want, err := mw.GenerateETag(stored)
+	require.NoError(t, err)
+	assert.Equal(t, want, etag)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/devices/"+uid, nil)
+	require.NoError(t, err)
+	req.Header.Set("If-None-Match", etag)
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, etag, resp.Header.Get("ETag"))
+
+	var listETag string
+	_, err = c.GetDevices(client.CaptureETag(ctx, &listETag))
+	require.NoError(t, err)
+	assert.NotEmpty(t, listETag)
+
+	// An update with the current ETag succeeds and returns the next one
+	update := client.UpdateDeviceRequest{Metadata: device.Metadata, Spec: device.Spec}
+	update.Spec.Manufacturer = "Contoso"
+	var next string
+	_, err = c.UpdateDevice(client.CaptureETag(client.IfMatch(ctx, etag), &next), uid, update)
+	require.NoError(t, err)
+	assert.NotEqual(t, etag, next)
+
+	// Writes based on the old version are refused
+	stale := client.IfMatch(ctx, etag)
+	_, err = c.UpdateDevice(stale, uid, update)
+	assert.True(t, errors.Is(err, client.ErrPreconditionFailed), "got %v", err)
+	_, err = c.PatchDevice(stale, uid, []byte(`{"partNumber": "P-1"}`), "application/merge-patch+json")
+	assert.True(t, errors.Is(err, client.ErrPreconditionFailed), "got %v", err)
+	_, err = c.UpdateDeviceStatus(stale, uid, v1.DeviceStatus{})
+	assert.True(t, errors.Is(err, client.ErrPreconditionFailed), "got %v", err)
+	assert.True(t, errors.Is(c.DeleteDevice(stale, uid), client.ErrPreconditionFailed))
+
+	// ModifyDevice starts over when another writer gets in between
+	calls := 0
+	modified, err := c.ModifyDevice(ctx, uid, func(device *v1.Device) error {
+		calls++
+		if calls == 1 {
+			_, err := c.PatchDevice(ctx, uid, []byte(`{"partNumber": "P-2"}`), "application/merge-patch+json")
+			require.NoError(t, err)
+		}
+		device.Spec.Properties = map[string]json.RawMessage{"rack": json.RawMessage(`"R12"`)}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "P-2", modified.Spec.PartNumber)
+	assert.JSONEq(t, `"R12"`, string(modified.Spec.Properties["rack"]))
+
+	// Unconditional writes still work
+	require.NoError(t, c.DeleteDevice(ctx, uid))
+}
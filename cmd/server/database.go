@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/ent"
+	"github.com/example/fru-tracker/internal/storage/ent/migrate"
+)
+
+// openDatabase connects to databaseURL, a PostgreSQL URL or a SQLite file,
+// migrates the schema and hands the client to the storage package, keeping
+// tombstones for tombstoneRetention. The caller owns closing the returned
+// client.
+func openDatabase(ctx context.Context, databaseURL string, tombstoneRetention time.Duration) (*ent.Client, error) {
+	client, err := storage.OpenClient(databaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.Schema.Create(
+		ctx,
+		migrate.WithDropIndex(true),
+		migrate.WithDropColumn(true),
+	); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed creating schema resources: %w", err)
+	}
+
+	storage.RecordTombstones(client, tombstoneRetention)
+	storage.EnforceDeviceIdentities(client)
+	storage.SetEntClient(client)
+
+	claimed, err := storage.ClaimExistingDeviceIdentities(ctx)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to claim identities of existing devices: %w", err)
+	}
+	if claimed > 0 {
+		slog.InfoContext(ctx, "Claimed serial numbers of existing devices", "devices", claimed)
+	}
+	return client, nil
+}
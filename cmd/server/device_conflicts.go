@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/example/fru-tracker/internal/storage"
+)
+
+// DeviceConflicts answers a device write refused for taking another device's
+// serial number with 409 Conflict. The generated handlers answer every failed
+// save with 500, but the conflict is the caller's, not the server's.
+func DeviceConflicts(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/")
+		write := r.Method == http.MethodPost || r.Method == http.MethodPut || r.Method == http.MethodPatch
+		if !write || (path != "/devices" && !strings.HasPrefix(path, "/devices/")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var conflict error
+		cw := &conflictWriter{ResponseWriter: w, conflict: &conflict}
+		next.ServeHTTP(cw, r.WithContext(storage.NoteConflicts(r.Context(), &conflict)))
+	})
+}
+
+// conflictWriter replaces a 500 response with a 409 once a conflict was
+// noted, dropping the body the handler writes after it.
+type conflictWriter struct {
+	http.ResponseWriter
+	conflict *error
+	refused  bool
+}
+
+func (w *conflictWriter) WriteHeader(status int) {
+	if status == http.StatusInternalServerError && *w.conflict != nil {
+		w.refused = true
+		respondError(w.ResponseWriter, http.StatusConflict, *w.conflict)
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *conflictWriter) Write(b []byte) (int, error) {
+	if w.refused {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceConflicts(t *testing.T) {
+	client := storagetest.Open(t)
+	storage.EnforceDeviceIdentities(client)
+	storage.SetEntClient(client)
+	require.NoError(t, registerResourcePrefixes())
+
+	r := chi.NewRouter()
+	r.Use(DeviceConflicts)
+	RegisterGeneratedRoutes(r)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	send := func(method, path, serial string) (int, ErrorResponse, string) {
+		t.Helper()
+		body := `{"metadata": {"name": "` + serial + `"}, "spec": {"deviceType": "DIMM", "serialNumber": "` + serial + `"}}`
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result struct {
+			ErrorResponse
+			Metadata struct {
+				UID string `json:"uid"`
+			} `json:"metadata"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result.ErrorResponse, result.Metadata.UID
+	}
+
+	status, _, first := send(http.MethodPost, "/devices", "DIMM-1")
+	require.Equal(t, http.StatusCreated, status)
+	status, _, second := send(http.MethodPost, "/devices", "DIMM-2")
+	require.Equal(t, http.StatusCreated, status)
+
+	status, resp, _ := send(http.MethodPost, "/devices", "DIMM-1")
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Contains(t, resp.Error, first)
+
+	status, resp, _ = send(http.MethodPut, "/devices/"+second, "DIMM-1")
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Contains(t, resp.Error, first)
+
+	status, _, _ = send(http.MethodPut, "/devices/"+second, "DIMM-3")
+	assert.Equal(t, http.StatusOK, status)
+}
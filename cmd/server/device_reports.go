@@ -0,0 +1,519 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage"
+)
+
+// Report formats supported by the CLI export and GET /devices?format=.
+const (
+	reportFormatCSV      = "csv"
+	reportFormatBOM      = "bom"
+	reportFormatFirmware = "firmware"
+	reportFormatHealth   = "health"
+)
+
+// propertyFilterPrefix marks list query parameters that filter on a device
+// property, as in ?property.health=Warning,Critical.
+const propertyFilterPrefix = "property."
+
+// BOM grouping keys. Rack grouping reads the "rack" property of each device's top-level node.
+const (
+	bomGroupByNode = "node"
+	bomGroupByRack = "rack"
+)
+
+// DeviceListVariants serves the alternate representations of GET /devices
+// ahead of the generated handler, which only knows how to return JSON:
+// spreadsheet reports (?format=csv, bom, firmware and health), filtered lists
+// (?deviceType=, ?node= and ?property.<key>=) and the watch stream
+// (?watch=true).
+func DeviceListVariants(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || strings.TrimSuffix(r.URL.Path, "/") != "/devices" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		if watch, _ := strconv.ParseBool(query.Get("watch")); watch {
+			serveWatch(w, r, []string{"Device"})
+			return
+		}
+
+		format := query.Get("format")
+		filter := parseDeviceFilter(query)
+		if (format == "" || format == "json") && filter.empty() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		devices, err := storage.LoadAllDevices(r.Context())
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+			return
+		}
+
+		switch format {
+		case "", "json":
+			respondJSON(w, http.StatusOK, filterDevices(devices, filter))
+			return
+		case reportFormatCSV:
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", `attachment; filename="devices.csv"`)
+			err = writeDeviceCSV(w, devices, filter, splitList(query.Get("properties")))
+		case reportFormatBOM:
+			groupBy := query.Get("groupBy")
+			if groupBy == "" {
+				groupBy = bomGroupByNode
+			}
+			if groupBy != bomGroupByNode && groupBy != bomGroupByRack {
+				respondError(w, http.StatusBadRequest, fmt.Errorf("unsupported groupBy: %s (use 'node' or 'rack')", groupBy))
+				return
+			}
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", `attachment; filename="bom.csv"`)
+			err = writeBOMCSV(w, devices, filter, groupBy)
+		case reportFormatFirmware:
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", `attachment; filename="firmware.csv"`)
+			err = writeFirmwareCSV(w, devices, filter)
+		case reportFormatHealth:
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", `attachment; filename="health.csv"`)
+			err = writeHealthCSV(w, devices, filter)
+		default:
+			respondError(w, http.StatusBadRequest, fmt.Errorf("unsupported format: %s (use 'json', 'csv', 'bom', 'firmware' or 'health')", format))
+			return
+		}
+		if err != nil {
+			// Headers are already sent; all we can do is log
+			slog.WarnContext(r.Context(), "Failed to write report", "format", format, "error", err)
+		}
+	})
+}
+
+// isReportFormat reports whether format is one of the spreadsheet reports.
+func isReportFormat(format string) bool {
+	switch format {
+	case reportFormatCSV, reportFormatBOM, reportFormatFirmware, reportFormatHealth:
+		return true
+	}
+	return false
+}
+
+// deviceFilter selects devices by type, top-level node and property values.
+// Each criterion accepts any of its values; all criteria must match. Values
+// are compared case-insensitively.
+type deviceFilter struct {
+	deviceTypes []string
+	nodes       []string
+	properties  map[string][]string
+}
+
+// parseDeviceFilter reads ?deviceType=, ?node= and ?property.<key>= parameters.
+// Each takes a comma-separated list and may be repeated.
+func parseDeviceFilter(query url.Values) deviceFilter {
+	var filter deviceFilter
+	for key, values := range query {
+		var parsed []string
+		for _, value := range values {
+			parsed = append(parsed, splitList(value)...)
+		}
+		if len(parsed) == 0 {
+			continue
+		}
+
+		switch {
+		case key == "deviceType":
+			filter.deviceTypes = parsed
+		case key == "node":
+			filter.nodes = parsed
+		case strings.HasPrefix(key, propertyFilterPrefix) && len(key) > len(propertyFilterPrefix):
+			if filter.properties == nil {
+				filter.properties = make(map[string][]string)
+			}
+			filter.properties[strings.TrimPrefix(key, propertyFilterPrefix)] = parsed
+		}
+	}
+	return filter
+}
+
+// empty reports whether the filter selects every device.
+func (f deviceFilter) empty() bool {
+	return len(f.deviceTypes) == 0 && len(f.nodes) == 0 && len(f.properties) == 0
+}
+
+// matches reports whether a flattened device passes the filter.
+func (f deviceFilter) matches(row deviceRow) bool {
+	if len(f.deviceTypes) > 0 && !containsFold(f.deviceTypes, row.device.Spec.DeviceType) {
+		return false
+	}
+	if len(f.nodes) > 0 && !containsFold(f.nodes, deviceIdentity(row.node)) {
+		return false
+	}
+	for key, values := range f.properties {
+		if !containsFold(values, propertyValue(row.device.Spec.Properties, key)) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterDevices returns the devices passing filter, in their original order.
+func filterDevices(devices []*v1.Device, filter deviceFilter) []*v1.Device {
+	selected := make(map[*v1.Device]bool, len(devices))
+	for _, row := range flattenDevices(devices, filter) {
+		selected[row.device] = true
+	}
+
+	filtered := make([]*v1.Device, 0, len(selected))
+	for _, device := range devices {
+		if selected[device] {
+			filtered = append(filtered, device)
+		}
+	}
+	return filtered
+}
+
+// deviceRow is one flattened device with its place in the hierarchy resolved.
+type deviceRow struct {
+	device       *v1.Device
+	parentSerial string
+	node         *v1.Device
+}
+
+// flattenDevices resolves each device's parent serial and top-level node and
+// keeps the rows passing filter. The whole inventory must be passed in so the
+// hierarchy resolves even for devices whose node is filtered out.
+// Rows are ordered by node, device type and serial number.
+func flattenDevices(devices []*v1.Device, filter deviceFilter) []deviceRow {
+	byUID := make(map[string]*v1.Device, len(devices))
+	for _, device := range devices {
+		byUID[device.Metadata.UID] = device
+	}
+
+	rows := make([]deviceRow, 0, len(devices))
+	for _, device := range devices {
+		row := deviceRow{device: device, parentSerial: device.Spec.ParentSerialNumber}
+		if parent, ok := byUID[device.Spec.ParentID]; ok && parent.Spec.SerialNumber != "" {
+			row.parentSerial = parent.Spec.SerialNumber
+		}
+
+		// Walk up to the root, guarding against cycles in bad data
+		node := device
+		seen := map[string]bool{device.Metadata.UID: true}
+		for {
+			parent, ok := byUID[node.Spec.ParentID]
+			if !ok || seen[parent.Metadata.UID] {
+				break
+			}
+			seen[parent.Metadata.UID] = true
+			node = parent
+		}
+		row.node = node
+
+		if filter.matches(row) {
+			rows = append(rows, row)
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if an, bn := deviceIdentity(a.node), deviceIdentity(b.node); an != bn {
+			return an < bn
+		}
+		// Keep the node itself ahead of its components
+		if (a.device == a.node) != (b.device == b.node) {
+			return a.device == a.node
+		}
+		if a.device.Spec.DeviceType != b.device.Spec.DeviceType {
+			return a.device.Spec.DeviceType < b.device.Spec.DeviceType
+		}
+		return deviceIdentity(a.device) < deviceIdentity(b.device)
+	})
+
+	return rows
+}
+
+// writeDeviceCSV writes one row per device, with a column for each requested property.
+func writeDeviceCSV(w io.Writer, devices []*v1.Device, filter deviceFilter, properties []string) error {
+	out := newReportWriter(w)
+
+	header := []string{"uid", "name", "deviceType", "manufacturer", "partNumber", "serialNumber", "parentSerialNumber", "nodeSerialNumber"}
+	header = append(header, properties...)
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range flattenDevices(devices, filter) {
+		spec := row.device.Spec
+		record := []string{
+			row.device.Metadata.UID,
+			row.device.Metadata.Name,
+			spec.DeviceType,
+			spec.Manufacturer,
+			spec.PartNumber,
+			spec.SerialNumber,
+			row.parentSerial,
+			deviceIdentity(row.node),
+		}
+		for _, key := range properties {
+			record = append(record, propertyValue(spec.Properties, key))
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// writeBOMCSV writes a bill of materials: the number of devices per part
+// number within each node (or rack).
+func writeBOMCSV(w io.Writer, devices []*v1.Device, filter deviceFilter, groupBy string) error {
+	type bomKey struct {
+		group, deviceType, manufacturer, partNumber string
+	}
+
+	counts := make(map[bomKey]int)
+	for _, row := range flattenDevices(devices, filter) {
+		group := deviceIdentity(row.node)
+		if groupBy == bomGroupByRack {
+			group = propertyValue(row.node.Spec.Properties, "rack")
+		}
+		key := bomKey{
+			group:        group,
+			deviceType:   row.device.Spec.DeviceType,
+			manufacturer: row.device.Spec.Manufacturer,
+			partNumber:   row.device.Spec.PartNumber,
+		}
+		counts[key]++
+	}
+
+	keys := make([]bomKey, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.group != b.group {
+			return a.group < b.group
+		}
+		if a.deviceType != b.deviceType {
+			return a.deviceType < b.deviceType
+		}
+		if a.manufacturer != b.manufacturer {
+			return a.manufacturer < b.manufacturer
+		}
+		return a.partNumber < b.partNumber
+	})
+
+	out := newReportWriter(w)
+	if err := out.Write([]string{groupBy, "deviceType", "manufacturer", "partNumber", "count"}); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		record := []string{key.group, key.deviceType, key.manufacturer, key.partNumber, strconv.Itoa(counts[key])}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// writeFirmwareCSV writes the number of devices and nodes running each
+// firmware version of each part. A part whose versions column is above one
+// has drifted. Devices that report no firmware version are left out.
+func writeFirmwareCSV(w io.Writer, devices []*v1.Device, filter deviceFilter) error {
+	type partKey struct {
+		deviceType, manufacturer, partNumber string
+	}
+	type firmwareKey struct {
+		part    partKey
+		version string
+	}
+
+	counts := make(map[firmwareKey]int)
+	nodes := make(map[firmwareKey]map[string]bool)
+	versions := make(map[partKey]map[string]bool)
+	for _, row := range flattenDevices(devices, filter) {
+		version := propertyValue(row.device.Spec.Properties, v1.PropertyFirmwareVersion)
+		if version == "" {
+			continue
+		}
+		part := partKey{
+			deviceType:   row.device.Spec.DeviceType,
+			manufacturer: row.device.Spec.Manufacturer,
+			partNumber:   row.device.Spec.PartNumber,
+		}
+		key := firmwareKey{part: part, version: version}
+
+		counts[key]++
+		if nodes[key] == nil {
+			nodes[key] = make(map[string]bool)
+		}
+		nodes[key][deviceIdentity(row.node)] = true
+		if versions[part] == nil {
+			versions[part] = make(map[string]bool)
+		}
+		versions[part][version] = true
+	}
+
+	keys := make([]firmwareKey, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.part.deviceType != b.part.deviceType {
+			return a.part.deviceType < b.part.deviceType
+		}
+		if a.part.manufacturer != b.part.manufacturer {
+			return a.part.manufacturer < b.part.manufacturer
+		}
+		if a.part.partNumber != b.part.partNumber {
+			return a.part.partNumber < b.part.partNumber
+		}
+		return a.version < b.version
+	})
+
+	out := newReportWriter(w)
+	if err := out.Write([]string{"deviceType", "manufacturer", "partNumber", "firmwareVersion", "count", "nodes", "versions"}); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		record := []string{
+			key.part.deviceType,
+			key.part.manufacturer,
+			key.part.partNumber,
+			key.version,
+			strconv.Itoa(counts[key]),
+			strconv.Itoa(len(nodes[key])),
+			strconv.Itoa(len(versions[key.part])),
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// writeHealthCSV writes one row per degraded device: any device reporting a
+// health other than OK. Rows are grouped by node.
+func writeHealthCSV(w io.Writer, devices []*v1.Device, filter deviceFilter) error {
+	out := newReportWriter(w)
+	if err := out.Write([]string{"node", "deviceType", "serialNumber", "partNumber", "location", "health", "state"}); err != nil {
+		return err
+	}
+
+	for _, row := range flattenDevices(devices, filter) {
+		properties := row.device.Spec.Properties
+		health := propertyValue(properties, v1.PropertyHealth)
+		if health == "" || strings.EqualFold(health, v1.HealthOK) {
+			continue
+		}
+		record := []string{
+			deviceIdentity(row.node),
+			row.device.Spec.DeviceType,
+			deviceIdentity(row.device),
+			row.device.Spec.PartNumber,
+			propertyValue(properties, v1.PropertyLocation),
+			health,
+			propertyValue(properties, v1.PropertyState),
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// reportWriter writes report rows as CSV. The strings in them come from BMCs
+// and collectors, so cells a spreadsheet would read as a formula are written
+// as text instead.
+type reportWriter struct {
+	*csv.Writer
+}
+
+func newReportWriter(w io.Writer) reportWriter {
+	return reportWriter{csv.NewWriter(w)}
+}
+
+// Write writes record as one row, prefixing with ' every cell that starts
+// with =, +, -, @, a tab or a carriage return.
+func (w reportWriter) Write(record []string) error {
+	cells := make([]string, len(record))
+	for i, cell := range record {
+		if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			cell = "'" + cell
+		}
+		cells[i] = cell
+	}
+	return w.Writer.Write(cells)
+}
+
+// deviceIdentity returns the serial number of a device, falling back to its name.
+func deviceIdentity(device *v1.Device) string {
+	if device.Spec.SerialNumber != "" {
+		return device.Spec.SerialNumber
+	}
+	return device.Metadata.Name
+}
+
+// propertyValue renders a property for a spreadsheet cell: strings unquoted,
+// anything else as compact JSON.
+func propertyValue(properties map[string]json.RawMessage, key string) string {
+	raw, ok := properties[key]
+	if !ok || len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value
+	}
+	return string(raw)
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// containsFold reports whether values holds value, ignoring case.
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
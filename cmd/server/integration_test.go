@@ -14,9 +14,8 @@ import (
 
 	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
 	"github.com/example/fru-tracker/internal/storage"
-	"github.com/example/fru-tracker/internal/storage/ent/enttest"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
 	"github.com/go-chi/chi/v5"
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/openchami/fabrica/pkg/fabrica"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,10 +23,7 @@ import (
 
 func TestDiscoverySnapshotIntegration(t *testing.T) {
 	// 1. Setup in-memory Ent storage for the test
-	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
-	t.Cleanup(func() {
-		require.NoError(t, client.Close())
-	})
+	client := storagetest.Open(t)
 	storage.SetEntClient(client)
 
 	// 2. Register resource prefixes (required for CreateDiscoverySnapshot)
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/example/fru-tracker/pkg/client"
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/fabrica"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeviceEditsRaiseInventoryEvents checks that moving a device or
+// replacing its part number through the API raises the same inventory events
+// as a discovery snapshot reporting it.
+func TestDeviceEditsRaiseInventoryEvents(t *testing.T) {
+	entClient := storagetest.Open(t)
+	storage.SetEntClient(entClient)
+	require.NoError(t, registerResourcePrefixes())
+
+	memoryBus := events.NewInMemoryEventBus(10, 1)
+	memoryBus.Start()
+	bus := storage.NewDurableEventBus(memoryBus, 0)
+	bus.RecordInventoryChanges(entClient)
+	t.Cleanup(func() { _ = bus.Close() })
+	received := make(chan events.Event, 10)
+	for _, eventType := range v1.InventoryEventTypes {
+		_, err := bus.Subscribe(eventType, func(ctx context.Context, event events.Event) error {
+			received <- event
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	bus.Start()
+	next := func() (string, v1.DeviceChange) {
+		t.Helper()
+		select {
+		case event := <-received:
+			var change v1.DeviceChange
+			require.NoError(t, event.DataAs(&change))
+			return event.Type(), change
+		case <-time.After(5 * time.Second):
+			t.Fatal("no inventory event")
+			return "", v1.DeviceChange{}
+		}
+	}
+
+	r := chi.NewRouter()
+	RegisterGeneratedRoutes(r)
+	server := httptest.NewServer(r)
+	defer server.Close()
+	ctx := context.Background()
+	c, err := client.NewClient(server.URL, server.Client())
+	require.NoError(t, err)
+
+	create := func(serial, deviceType, parentID string) *v1.Device {
+		t.Helper()
+		device, err := c.CreateDevice(ctx, client.CreateDeviceRequest{
+			Metadata: fabrica.Metadata{Name: serial},
+			Spec:     v1.DeviceSpec{DeviceType: deviceType, SerialNumber: serial, PartNumber: "PN-1", ParentID: parentID},
+		})
+		require.NoError(t, err)
+		return device
+	}
+	nodeA := create("NODE-A", "Node", "")
+	nodeB := create("NODE-B", "Node", "")
+	dimm := create("DIMM-1", "DIMM", nodeA.GetUID())
+
+	_, err = c.PatchDevice(ctx, dimm.GetUID(), []byte(`{"parentID": "`+nodeB.GetUID()+`"}`), "application/merge-patch+json")
+	require.NoError(t, err)
+	eventType, change := next()
+	assert.Equal(t, v1.DeviceReparentedEventType, eventType)
+	assert.Equal(t, dimm.GetUID(), change.DeviceUID)
+	assert.Equal(t, nodeB.GetUID(), change.ParentID)
+	assert.Equal(t, nodeA.GetUID(), change.PreviousParentID)
+	assert.Empty(t, change.SnapshotUID)
+
+	dimm.Spec.ParentID = nodeB.GetUID()
+	dimm.Spec.PartNumber = "PN-2"
+	_, err = c.UpdateDevice(ctx, dimm.GetUID(), client.UpdateDeviceRequest{Metadata: dimm.Metadata, Spec: dimm.Spec})
+	require.NoError(t, err)
+	eventType, change = next()
+	assert.Equal(t, v1.DevicePartNumberChangedEventType, eventType)
+	assert.Equal(t, "PN-2", change.PartNumber)
+	assert.Equal(t, "PN-1", change.PreviousPartNumber)
+
+	select {
+	case event := <-received:
+		t.Errorf("unexpected %s event", event.Type())
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/example/fru-tracker/internal/logging"
+)
+
+// LogRequests logs one line per request once it has been served. Handlers
+// below it log with the request's context, so their lines carry the same
+// request_id, and a trace_id when tracing is on. It belongs after RequestID
+// and TraceRequests.
+func LogRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ctx := r.Context()
+		if requestID := middleware.GetReqID(ctx); requestID != "" {
+			ctx = logging.With(ctx, "request_id", requestID)
+		}
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := responseStatus(ww)
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.Log(ctx, level, "Served request",
+			"method", r.Method,
+			"route", requestRoute(r),
+			"path", r.URL.Path,
+			"status", status,
+			"bytes", ww.BytesWritten(),
+			"duration", time.Since(start),
+			"remote_addr", r.RemoteAddr)
+	})
+}
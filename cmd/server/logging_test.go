@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/example/fru-tracker/internal/logging"
+)
+
+// lockedBuffer is a bytes.Buffer safe for the logger and the test to share.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestLogRequests checks that the request line and the lines handlers log
+// while serving it carry the same request ID.
+func TestLogRequests(t *testing.T) {
+	var logs lockedBuffer
+	logger, err := logging.New(&logs, logging.FormatJSON, slog.LevelInfo)
+	require.NoError(t, err)
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(LogRequests)
+	r.Get("/devices/{uid}", func(w http.ResponseWriter, r *http.Request) {
+		slog.WarnContext(r.Context(), "Handler warning", "device_uid", chi.URLParam(r, "uid"))
+		w.WriteHeader(http.StatusNotFound)
+	})
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/devices/dev-1", nil)
+	require.NoError(t, err)
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	var records []map[string]any
+	scanner := bufio.NewScanner(strings.NewReader(logs.String()))
+	for scanner.Scan() {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 2)
+
+	handler, served := records[0], records[1]
+	assert.Equal(t, "Handler warning", handler["msg"])
+	assert.Equal(t, "req-1", handler["request_id"])
+	assert.Equal(t, "dev-1", handler["device_uid"])
+
+	assert.Equal(t, "Served request", served["msg"])
+	assert.Equal(t, "INFO", served["level"])
+	assert.Equal(t, "req-1", served["request_id"])
+	assert.Equal(t, "GET", served["method"])
+	assert.Equal(t, "/devices/{uid}", served["route"])
+	assert.Equal(t, "/devices/dev-1", served["path"])
+	assert.Equal(t, float64(http.StatusNotFound), served["status"])
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/example/fru-tracker/internal/metrics"
+	"github.com/example/fru-tracker/internal/storage"
+)
+
+// unmatchedRoute is the route label of requests no route answered, so that
+// scans of random paths cannot create a series per path.
+const unmatchedRoute = "unmatched"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fru_tracker_http_requests_total",
+		Help: "HTTP requests by method, route pattern and status code.",
+	}, []string{"method", "route", "code"})
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fru_tracker_http_request_duration_seconds",
+		Help:    "Time taken to answer HTTP requests, by method and route pattern. Watch streams are counted when they end.",
+		Buckets: metrics.DefaultBuckets,
+	}, []string{"method", "route"})
+	watchStreamsDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "fru_tracker_watch_streams_dropped_total",
+		Help: "Watch streams disconnected for falling behind the event bus.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		metrics.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "fru_tracker_devices",
+			Help: "Devices in the inventory by deviceType.",
+		}, "device_type", func(ctx context.Context) (map[string]float64, error) {
+			counts, err := storage.CountDevicesByType(ctx)
+			return floatCounts(counts), err
+		}),
+		metrics.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "fru_tracker_discovery_snapshots",
+			Help: "Discovery snapshots by status phase.",
+		}, "phase", func(ctx context.Context) (map[string]float64, error) {
+			counts, err := storage.CountDiscoverySnapshotsByPhase(ctx)
+			return floatCounts(counts), err
+		}),
+	)
+}
+
+func floatCounts(counts map[string]int) map[string]float64 {
+	values := make(map[string]float64, len(counts))
+	for key, count := range counts {
+		values[key] = float64(count)
+	}
+	return values
+}
+
+// requestRoute is the route pattern that answered r, once it has been served.
+func requestRoute(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		return rctx.RoutePattern()
+	}
+	return unmatchedRoute
+}
+
+// responseStatus is the status code written to ww, which is 200 when the
+// handler wrote a body without calling WriteHeader or wrote nothing at all.
+func responseStatus(ww middleware.WrapResponseWriter) int {
+	if status := ww.Status(); status != 0 {
+		return status
+	}
+	return http.StatusOK
+}
+
+// RequestMetrics counts requests and times them by route pattern rather than
+// path, so /devices/{uid} is one series however many devices there are.
+func RequestMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		route, status := requestRoute(r), responseStatus(ww)
+		httpRequests.WithLabelValues(r.Method, route, strconv.Itoa(status)).Inc()
+		httpRequestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
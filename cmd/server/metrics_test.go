@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	client := storagetest.Open(t)
+	storage.SetEntClient(client)
+	require.NoError(t, registerResourcePrefixes())
+
+	r := chi.NewRouter()
+	r.Use(RequestMetrics)
+	registerRoutes(r, nil)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	send := func(method, path, body string) {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	send(http.MethodPost, "/devices", `{"metadata": {"name": "n1"}, "spec": {"deviceType": "Node", "serialNumber": "N1"}}`)
+	send(http.MethodPost, "/devices", `{"metadata": {"name": "d1"}, "spec": {"deviceType": "DIMM", "serialNumber": "D1"}}`)
+	send(http.MethodPost, "/devices", `{"metadata": {"name": "d2"}, "spec": {"deviceType": "DIMM", "serialNumber": "D2"}}`)
+	send(http.MethodGet, "/devices/device-does-not-exist", "")
+	send(http.MethodPost, "/discoverysnapshots", `{"metadata": {"name": "snap"}, "spec": {"rawData": [{"deviceType": "Node", "serialNumber": "N2"}]}}`)
+	send(http.MethodGet, "/no/such/route", "")
+
+	resp, err := server.Client().Get(server.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	body := string(data)
+
+	// Requests are labelled by route pattern, not path
+	assert.Contains(t, body, `fru_tracker_http_requests_total{code="201",method="POST",route="/devices"} 3`)
+	assert.Contains(t, body, `fru_tracker_http_requests_total{code="404",method="GET",route="/devices/{uid}"} 1`)
+	assert.Contains(t, body, `fru_tracker_http_requests_total{code="404",method="GET",route="unmatched"} 1`)
+	assert.Contains(t, body, `fru_tracker_http_request_duration_seconds_count{method="POST",route="/devices"} 3`)
+
+	// Inventory gauges come from the database
+	assert.Contains(t, body, `fru_tracker_devices{device_type="DIMM"} 2`)
+	assert.Contains(t, body, `fru_tracker_devices{device_type="Node"} 1`)
+	assert.Contains(t, body, `fru_tracker_discovery_snapshots{phase="Pending"} 1`)
+	assert.Contains(t, body, "# TYPE fru_tracker_events_pending gauge")
+}
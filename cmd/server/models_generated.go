@@ -100,6 +100,35 @@ func (r *UpdateDiscoverySnapshotRequest) AsSpec() v1.DiscoverySnapshotSpec {
 	return r.Spec
 }
 
+// WebhookSubscriptionResponse represents the response for WebhookSubscription operations
+type WebhookSubscriptionResponse = v1.WebhookSubscription
+
+// CreateWebhookSubscriptionRequest represents a request to create a WebhookSubscription
+type CreateWebhookSubscriptionRequest struct {
+	Metadata    fabrica.Metadata           `json:"metadata" validate:"required"`
+	Spec        v1.WebhookSubscriptionSpec `json:"spec" validate:"required"`
+	Labels      map[string]string          `json:"labels,omitempty"`
+	Annotations map[string]string          `json:"annotations,omitempty"`
+}
+
+// AsSpec converts the request fields to a spec object
+func (r *CreateWebhookSubscriptionRequest) AsSpec() v1.WebhookSubscriptionSpec {
+	return r.Spec
+}
+
+// UpdateWebhookSubscriptionRequest represents a request to update a WebhookSubscription
+type UpdateWebhookSubscriptionRequest struct {
+	Metadata    fabrica.Metadata           `json:"metadata,omitempty"`
+	Spec        v1.WebhookSubscriptionSpec `json:"spec,omitempty" validate:"omitempty"`
+	Labels      map[string]string          `json:"labels,omitempty"`
+	Annotations map[string]string          `json:"annotations,omitempty"`
+}
+
+// AsSpec converts the request fields to a spec object
+func (r *UpdateWebhookSubscriptionRequest) AsSpec() v1.WebhookSubscriptionSpec {
+	return r.Spec
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -25,23 +25,343 @@
 //	}
 package main
 
-import "github.com/getkin/kin-openapi/openapi3"
+import (
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+)
 
 // registerCustomOpenAPIPaths is called by GenerateOpenAPISpec after all
 // Fabrica-generated resource paths have been registered.
 // Add your custom / non-generated route definitions here.
 func registerCustomOpenAPIPaths(spec *openapi3.T) {
-	// Add custom route definitions here.
-	// Example (uncomment and extend as needed):
-	//
-	// op := openapi3.NewOperation()
-	// op.OperationID = "myCustomEndpoint"
-	// op.Summary   = "My custom endpoint"
-	// op.Tags      = []string{"Custom"}
-	// op.Responses = openapi3.NewResponses()
-	// op.Responses.Set("200", &openapi3.ResponseRef{
-	//     Value: openapi3.NewResponse().WithDescription("OK"),
-	// })
-	// spec.Paths.Set("/my-endpoint", &openapi3.PathItem{Get: op})
-	_ = spec
+	registerChangesPath(spec)
+	registerDeviceListFormats(spec)
+	registerEventsPath(spec)
+	registerPatchAndStatusPaths(spec)
+	registerSnapshotValidation(spec)
+	registerDeviceConflicts(spec)
+	// These apply to every API path registered above
+	registerBearerAuth(spec)
+	registerConditionalRequests(spec)
+	registerServicePaths(spec)
+}
+
+// registerPatchAndStatusPaths documents the PATCH and status subresource
+// routes the generated handlers serve but the generated spec leaves out.
+func registerPatchAndStatusPaths(spec *openapi3.T) {
+	resources := []struct {
+		kind, collection string
+		status           any
+	}{
+		{"Device", "/devices", &v1.DeviceStatus{}},
+		{"DiscoverySnapshot", "/discoverysnapshots", &v1.DiscoverySnapshotStatus{}},
+		{"WebhookSubscription", "/webhooksubscriptions", &v1.WebhookSubscriptionStatus{}},
+	}
+	for _, res := range resources {
+		item := spec.Paths.Value(res.collection + "/{uid}")
+		if item == nil {
+			continue
+		}
+		statusSchema, err := openapi3gen.NewSchemaRefForValue(res.status, spec.Components.Schemas)
+		if err != nil {
+			continue
+		}
+		spec.Components.Schemas[res.kind+"Status"] = statusSchema
+		resourceRef := &openapi3.SchemaRef{Ref: "#/components/schemas/" + res.kind}
+
+		item.Patch = patchOperation("patch"+res.kind, "Patch the spec of a "+res.kind+" resource", res.kind, resourceRef)
+
+		put := openapi3.NewOperation()
+		put.OperationID = "update" + res.kind + "Status"
+		put.Summary = "Replace the status of a " + res.kind + " resource"
+		put.Description = "For controllers and reconcilers; the spec and metadata are left unchanged"
+		put.Tags = []string{res.kind}
+		put.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/schemas/" + res.kind + "Status"})}
+		put.Responses = openapi3.NewResponses()
+		put.Responses.Set("200", &openapi3.ResponseRef{Value: openapi3.NewResponse().
+			WithDescription("Status updated successfully").
+			WithJSONSchemaRef(resourceRef)})
+		put.Responses.Set("400", errorResponse())
+		put.Responses.Set("404", errorResponse())
+		put.Responses.Set("500", errorResponse())
+
+		spec.Paths.Set(res.collection+"/{uid}/status", &openapi3.PathItem{
+			Put:        put,
+			Patch:      patchOperation("patch"+res.kind+"Status", "Patch the status of a "+res.kind+" resource", res.kind, resourceRef),
+			Parameters: item.Parameters,
+		})
+	}
+}
+
+// patchOperation documents a PATCH in the three formats the generated
+// handlers accept.
+func patchOperation(id, summary, tag string, result *openapi3.SchemaRef) *openapi3.Operation {
+	op := openapi3.NewOperation()
+	op.OperationID = id
+	op.Summary = summary
+	op.Description = "The format is chosen by Content-Type: application/merge-patch+json (RFC 7386), " +
+		"application/json-patch+json (RFC 6902) or application/fabrica-patch+json (shorthand)"
+	op.Tags = []string{tag}
+	op.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().
+		WithRequired(true).
+		WithContent(openapi3.Content{
+			"application/merge-patch+json":   openapi3.NewMediaType().WithSchema(openapi3.NewObjectSchema()),
+			"application/json-patch+json":    openapi3.NewMediaType().WithSchema(openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema())),
+			"application/fabrica-patch+json": openapi3.NewMediaType().WithSchema(openapi3.NewObjectSchema()),
+		})}
+	op.Responses = openapi3.NewResponses()
+	op.Responses.Set("200", &openapi3.ResponseRef{Value: openapi3.NewResponse().
+		WithDescription("Resource patched successfully").
+		WithJSONSchemaRef(result)})
+	op.Responses.Set("400", errorResponse())
+	op.Responses.Set("404", errorResponse())
+	op.Responses.Set("422", errorResponse())
+	op.Responses.Set("500", errorResponse())
+	return op
+}
+
+// registerServicePaths documents the health check and the documentation
+// itself, which are served without authentication (see routes.go).
+func registerServicePaths(spec *openapi3.T) {
+	public := openapi3.NewSecurityRequirements()
+	service := func(id, summary, contentType string, schema *openapi3.Schema) *openapi3.PathItem {
+		op := openapi3.NewOperation()
+		op.OperationID = id
+		op.Summary = summary
+		op.Tags = []string{"Service"}
+		op.Security = public
+		op.Responses = openapi3.NewResponses()
+		op.Responses.Set("200", &openapi3.ResponseRef{Value: openapi3.NewResponse().
+			WithDescription("OK").
+			WithContent(openapi3.NewContentWithSchema(schema, []string{contentType}))})
+		return &openapi3.PathItem{Get: op}
+	}
+	spec.Paths.Set("/health", service("getHealth", "Report that the server is up", "application/json",
+		openapi3.NewObjectSchema().
+			WithProperty("status", openapi3.NewStringSchema()).
+			WithProperty("service", openapi3.NewStringSchema())))
+	probe := openapi3.NewObjectSchema().
+		WithProperty("status", openapi3.NewStringSchema().WithEnum("ok", "unavailable")).
+		WithProperty("service", openapi3.NewStringSchema()).
+		WithProperty("checks", openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema().
+			WithProperty("name", openapi3.NewStringSchema()).
+			WithProperty("ok", openapi3.NewBoolSchema()).
+			WithProperty("error", openapi3.NewStringSchema())))
+	spec.Paths.Set("/livez", service("getLivez", "Report that the server process is up", "application/json", probe))
+	readyz := service("getReadyz", "Report whether the database, event relay and reconciler are working", "application/json", probe)
+	verbose := openapi3.NewQueryParameter("verbose").
+		WithDescription("List every check, not only the failed ones").
+		WithSchema(openapi3.NewBoolSchema())
+	verbose.AllowEmptyValue = true
+	readyz.Get.AddParameter(verbose)
+	readyz.Get.Responses.Set("503", &openapi3.ResponseRef{Value: openapi3.NewResponse().
+		WithDescription("A check failed").
+		WithContent(openapi3.NewContentWithJSONSchema(probe))})
+	spec.Paths.Set("/readyz", readyz)
+	spec.Paths.Set("/metrics", service("getMetrics", "Prometheus metrics for the API, reconciler and inventory", "text/plain", openapi3.NewStringSchema()))
+	spec.Paths.Set("/openapi.json", service("getOpenAPISpec", "This OpenAPI document", "application/json", openapi3.NewObjectSchema()))
+	spec.Paths.Set("/docs", service("getAPIDocs", "Swagger UI for this document", "text/html", openapi3.NewStringSchema()))
+}
+
+// registerConditionalRequests documents ETags, If-None-Match and If-Match
+// (see conditional.go).
+func registerConditionalRequests(spec *openapi3.T) {
+	etag := &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+		Description: "Version of the response, to send as If-None-Match or If-Match",
+		Schema:      openapi3.NewStringSchema().NewRef(),
+	}}}
+	ifNoneMatch := openapi3.NewHeaderParameter("If-None-Match").
+		WithDescription("Answer with 304 if the response still has this ETag").
+		WithSchema(openapi3.NewStringSchema())
+	ifMatch := openapi3.NewHeaderParameter("If-Match").
+		WithDescription("Only write if the resource still has this ETag").
+		WithSchema(openapi3.NewStringSchema())
+
+	for path, item := range spec.Paths.Map() {
+		if path == "/events" {
+			continue
+		}
+		for method, op := range item.Operations() {
+			if op.Responses == nil {
+				continue
+			}
+			if ok := op.Responses.Value("200"); ok != nil && ok.Value != nil && method != http.MethodDelete {
+				if ok.Value.Headers == nil {
+					ok.Value.Headers = openapi3.Headers{}
+				}
+				ok.Value.Headers["ETag"] = etag
+			}
+			switch {
+			case method == http.MethodGet:
+				op.AddParameter(ifNoneMatch)
+				op.Responses.Set("304", &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("Not modified")})
+			case strings.Contains(path, "{uid}") && method != http.MethodPost:
+				op.AddParameter(ifMatch)
+				op.Responses.Set("412", errorResponse())
+			}
+		}
+	}
+}
+
+// registerBearerAuth documents the bearer tokens the API requires when the
+// server runs with --auth-jwks (see auth.go).
+func registerBearerAuth(spec *openapi3.T) {
+	scheme := openapi3.NewJWTSecurityScheme()
+	scheme.Description = "A JWT granting the reader role for reads other than of WebhookSubscriptions, " +
+		"collector or admin to post DiscoverySnapshots, and admin for everything else."
+	if spec.Components.SecuritySchemes == nil {
+		spec.Components.SecuritySchemes = make(openapi3.SecuritySchemes)
+	}
+	spec.Components.SecuritySchemes["bearerAuth"] = &openapi3.SecuritySchemeRef{Value: scheme}
+	spec.Security = *openapi3.NewSecurityRequirements().With(openapi3.NewSecurityRequirement().Authenticate("bearerAuth"))
+
+	for _, item := range spec.Paths.Map() {
+		for _, op := range item.Operations() {
+			op.Responses.Set("401", errorResponse())
+			op.Responses.Set("403", errorResponse())
+		}
+	}
+}
+
+// registerSnapshotValidation documents the 422 responses of DiscoverySnapshot
+// writes (see snapshot_validation.go).
+func registerSnapshotValidation(spec *openapi3.T) {
+	response := &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("rawData is not a valid device list; details lists each problem by field, e.g. spec.rawData[3].serialNumber").
+			WithJSONSchema(openapi3.NewObjectSchema().
+				WithProperty("error", openapi3.NewStringSchema()).
+				WithProperty("code", openapi3.NewIntegerSchema()).
+				WithProperty("details", openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema().
+					WithProperty("field", openapi3.NewStringSchema()).
+					WithProperty("message", openapi3.NewStringSchema())))),
+	}
+	for _, path := range []string{"/discoverysnapshots", "/discoverysnapshots/{uid}"} {
+		item := spec.Paths.Value(path)
+		if item == nil {
+			continue
+		}
+		for _, op := range []*openapi3.Operation{item.Post, item.Put, item.Patch} {
+			if op != nil && op.Responses != nil {
+				op.Responses.Set("422", response)
+			}
+		}
+	}
+}
+
+// registerDeviceConflicts documents the 409 answered to device writes that
+// would take the serial number of another device.
+func registerDeviceConflicts(spec *openapi3.T) {
+	response := errorResponse()
+	response.Value.WithDescription("Another device already has this serialNumber")
+	for _, path := range []string{"/devices", "/devices/{uid}"} {
+		item := spec.Paths.Value(path)
+		if item == nil {
+			continue
+		}
+		for _, op := range []*openapi3.Operation{item.Post, item.Put, item.Patch} {
+			if op != nil && op.Responses != nil {
+				op.Responses.Set("409", response)
+			}
+		}
+	}
+}
+
+// registerEventsPath documents the watch stream GET /events (see watch.go).
+func registerEventsPath(spec *openapi3.T) {
+	op := openapi3.NewOperation()
+	op.OperationID = "watchEvents"
+	op.Summary = "Stream resource lifecycle events"
+	op.Description = "Server-Sent Events stream. The first event is a bookmark carrying the current revision. " +
+		"Resume with `revision` or the Last-Event-ID header; 410 means the revision has expired and the client must re-list."
+	op.Tags = []string{"Watch"}
+	op.AddParameter(openapi3.NewQueryParameter("kinds").
+		WithDescription("Comma-separated resource kinds (default: all)").
+		WithSchema(openapi3.NewStringSchema()))
+	op.AddParameter(openapi3.NewQueryParameter("revision").
+		WithDescription("Resume after this revision").
+		WithSchema(openapi3.NewIntegerSchema().WithMin(0)))
+	op.Responses = openapi3.NewResponses()
+	op.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Event stream; each data line is a JSON watch event").
+			WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema(), []string{"text/event-stream"})),
+	})
+	op.Responses.Set("400", errorResponse())
+	op.Responses.Set("410", errorResponse())
+	spec.Paths.Set("/events", &openapi3.PathItem{Get: op})
+}
+
+// registerDeviceListFormats documents the report, filter and watch variants of GET /devices (see device_reports.go).
+func registerDeviceListFormats(spec *openapi3.T) {
+	path := spec.Paths.Value("/devices")
+	if path == nil || path.Get == nil {
+		return
+	}
+	op := path.Get
+	op.Description += ". Devices can be filtered with `deviceType`, `node` and `property.<key>` parameters, " +
+		"e.g. `property.health=Warning,Critical` or `property.firmware_version=2.10`. " +
+		"Each takes a comma-separated list of values, matched case-insensitively; filters apply to every format."
+	op.AddParameter(openapi3.NewQueryParameter("format").
+		WithDescription("Response format: json (default), csv (one row per device), bom (count per part number), " +
+			"firmware (count per part number and firmware version) or health (devices not reporting OK health)").
+		WithSchema(openapi3.NewStringSchema().WithEnum("json", "csv", "bom", "firmware", "health")))
+	op.AddParameter(openapi3.NewQueryParameter("deviceType").
+		WithDescription("Comma-separated device types to include").
+		WithSchema(openapi3.NewStringSchema()))
+	op.AddParameter(openapi3.NewQueryParameter("node").
+		WithDescription("Comma-separated serial numbers of the top-level nodes whose devices to include").
+		WithSchema(openapi3.NewStringSchema()))
+	op.AddParameter(openapi3.NewQueryParameter("properties").
+		WithDescription("Comma-separated device properties to add as columns (format=csv)").
+		WithSchema(openapi3.NewStringSchema()))
+	op.AddParameter(openapi3.NewQueryParameter("watch").
+		WithDescription("Stream Device events as Server-Sent Events instead of listing (see /events)").
+		WithSchema(openapi3.NewBoolSchema()))
+	op.AddParameter(openapi3.NewQueryParameter("groupBy").
+		WithDescription("Bill of materials grouping (format=bom)").
+		WithSchema(openapi3.NewStringSchema().WithEnum("node", "rack")))
+	op.Responses.Value("200").Value.Content["text/csv"] = openapi3.NewMediaType().
+		WithSchema(openapi3.NewStringSchema())
+}
+
+// registerChangesPath documents GET /changes (see changes_handlers.go).
+func registerChangesPath(spec *openapi3.T) {
+	op := openapi3.NewOperation()
+	op.OperationID = "getChanges"
+	op.Summary = "List resources created, updated or deleted since a timestamp"
+	op.Description = "Returns changed resources plus tombstones for deleted ones. Pass the returned `until` as `since` on the next call; it trails the request by a minute, so de-duplicate resources by `metadata.uid` (keeping the latest `metadata.updatedAt`) and tombstones by `uid`. A `since` older than the tombstone retention is answered 410: list again without it."
+	op.Tags = []string{"Export"}
+	op.AddParameter(openapi3.NewQueryParameter("since").
+		WithDescription("RFC3339 timestamp; omit for a full export").
+		WithSchema(openapi3.NewDateTimeSchema()))
+	op.AddParameter(openapi3.NewQueryParameter("kinds").
+		WithDescription("Comma-separated resource kinds (default: all)").
+		WithSchema(openapi3.NewStringSchema()))
+	op.Responses = openapi3.NewResponses()
+	op.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Change set").
+			WithJSONSchema(openapi3.NewObjectSchema().
+				WithProperty("since", openapi3.NewDateTimeSchema()).
+				WithProperty("until", openapi3.NewDateTimeSchema()).
+				WithProperty("resources", openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema())).
+				WithProperty("tombstones", openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema().
+					WithProperty("kind", openapi3.NewStringSchema()).
+					WithProperty("resourceKind", openapi3.NewStringSchema()).
+					WithProperty("uid", openapi3.NewStringSchema()).
+					WithProperty("name", openapi3.NewStringSchema()).
+					WithProperty("deletedAt", openapi3.NewDateTimeSchema()).
+					WithProperty("spec", openapi3.NewObjectSchema())))),
+	})
+	op.Responses.Set("400", errorResponse())
+	op.Responses.Set("410", errorResponse())
+	spec.Paths.Set("/changes", &openapi3.PathItem{Get: op})
 }
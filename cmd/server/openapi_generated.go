@@ -106,6 +106,7 @@ func GenerateOpenAPISpec() *openapi3.T {
 	// Register all resource paths
 	registerDevicePaths(spec)
 	registerDiscoverySnapshotPaths(spec)
+	registerWebhookSubscriptionPaths(spec)
 
 	// Register custom (non-Fabrica-generated) paths.
 	// Defined in openapi_extensions.go – safe to edit, never overwritten.
@@ -416,6 +417,157 @@ func registerDiscoverySnapshotPaths(spec *openapi3.T) {
 	spec.Paths.Set("/discoverysnapshots/{uid}", itemPath)
 }
 
+// registerWebhookSubscriptionPaths registers OpenAPI paths for WebhookSubscription resources
+func registerWebhookSubscriptionPaths(spec *openapi3.T) {
+	// Generate schemas from Go types - NO ANNOTATIONS NEEDED
+	resourceSchema, _ := openapi3gen.NewSchemaRefForValue(&v1.WebhookSubscription{}, spec.Components.Schemas)
+	spec.Components.Schemas["WebhookSubscription"] = resourceSchema
+
+	createReqSchema, _ := openapi3gen.NewSchemaRefForValue(&CreateWebhookSubscriptionRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["CreateWebhookSubscriptionRequest"] = createReqSchema
+
+	updateReqSchema, _ := openapi3gen.NewSchemaRefForValue(&UpdateWebhookSubscriptionRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["UpdateWebhookSubscriptionRequest"] = updateReqSchema
+
+	// Error response schema
+	if _, exists := spec.Components.Schemas["ErrorResponse"]; !exists {
+		errorSchema := openapi3.NewObjectSchema().
+			WithProperty("error", openapi3.NewStringSchema()).
+			WithRequired([]string{"error"})
+		spec.Components.Schemas["ErrorResponse"] = &openapi3.SchemaRef{Value: errorSchema}
+	}
+
+	// DELETE response schema
+	if _, exists := spec.Components.Schemas["DeleteResponse"]; !exists {
+		deleteSchema, _ := openapi3gen.NewSchemaRefForValue(&DeleteResponse{}, spec.Components.Schemas)
+		spec.Components.Schemas["DeleteResponse"] = deleteSchema
+	}
+
+	// List WebhookSubscriptions operation
+	listOp := openapi3.NewOperation()
+	listOp.OperationID = "listWebhookSubscriptions"
+	listOp.Summary = "List all WebhookSubscription resources"
+	listOp.Description = "Returns a list of all WebhookSubscription resources in the inventory"
+	listOp.Tags = []string{"WebhookSubscription"}
+	listOp.Responses = openapi3.NewResponses()
+	arraySchema := openapi3.NewArraySchema()
+	arraySchema.Items = &openapi3.SchemaRef{Ref: "#/components/schemas/WebhookSubscription"}
+	listOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{Value: arraySchema}),
+	})
+	listOp.Responses.Set("500", errorResponse())
+
+	// Create WebhookSubscription operation
+	createOp := openapi3.NewOperation()
+	createOp.OperationID = "createWebhookSubscription"
+	createOp.Summary = "Create a new WebhookSubscription resource"
+	createOp.Description = "Creates a new WebhookSubscription resource with the provided specification"
+	createOp.Tags = []string{"WebhookSubscription"}
+	createOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/CreateWebhookSubscriptionRequest",
+			}),
+	}
+	createOp.Responses = openapi3.NewResponses()
+	createOp.Responses.Set("201", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource created successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/WebhookSubscription",
+			}),
+	})
+	createOp.Responses.Set("400", errorResponse())
+	createOp.Responses.Set("500", errorResponse())
+
+	// Get WebhookSubscription operation
+	getOp := openapi3.NewOperation()
+	getOp.OperationID = "getWebhookSubscription"
+	getOp.Summary = "Get a specific WebhookSubscription resource"
+	getOp.Description = "Returns details of a specific WebhookSubscription resource by UID"
+	getOp.Tags = []string{"WebhookSubscription"}
+	getOp.Responses = openapi3.NewResponses()
+	getOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/WebhookSubscription",
+			}),
+	})
+	getOp.Responses.Set("404", errorResponse())
+	getOp.Responses.Set("500", errorResponse())
+
+	// Update WebhookSubscription operation
+	updateOp := openapi3.NewOperation()
+	updateOp.OperationID = "updateWebhookSubscription"
+	updateOp.Summary = "Update a WebhookSubscription resource"
+	updateOp.Description = "Updates an existing WebhookSubscription resource with new values"
+	updateOp.Tags = []string{"WebhookSubscription"}
+	updateOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/UpdateWebhookSubscriptionRequest",
+			}),
+	}
+	updateOp.Responses = openapi3.NewResponses()
+	updateOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource updated successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/WebhookSubscription",
+			}),
+	})
+	updateOp.Responses.Set("400", errorResponse())
+	updateOp.Responses.Set("404", errorResponse())
+	updateOp.Responses.Set("500", errorResponse())
+
+	// Delete WebhookSubscription operation
+	deleteOp := openapi3.NewOperation()
+	deleteOp.OperationID = "deleteWebhookSubscription"
+	deleteOp.Summary = "Delete a WebhookSubscription resource"
+	deleteOp.Description = "Removes a WebhookSubscription resource from the inventory"
+	deleteOp.Tags = []string{"WebhookSubscription"}
+	deleteOp.Responses = openapi3.NewResponses()
+	deleteOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource deleted successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/DeleteResponse",
+			}),
+	})
+	deleteOp.Responses.Set("400", errorResponse())
+	deleteOp.Responses.Set("404", errorResponse())
+	deleteOp.Responses.Set("500", errorResponse())
+
+	// Create path items
+	collectionPath := &openapi3.PathItem{
+		Get:  listOp,
+		Post: createOp,
+	}
+
+	uidParam := openapi3.NewPathParameter("uid").
+		WithDescription("Unique identifier of the WebhookSubscription resource").
+		WithRequired(true).
+		WithSchema(openapi3.NewStringSchema())
+
+	itemPath := &openapi3.PathItem{
+		Get:    getOp,
+		Put:    updateOp,
+		Delete: deleteOp,
+		Parameters: []*openapi3.ParameterRef{
+			{Value: uidParam},
+		},
+	}
+
+	// Add paths to spec
+	spec.Paths.Set("/webhooksubscriptions", collectionPath)
+	spec.Paths.Set("/webhooksubscriptions/{uid}", itemPath)
+}
+
 // Helper function for error responses
 func errorResponse() *openapi3.ResponseRef {
 	return &openapi3.ResponseRef{
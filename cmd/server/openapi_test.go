@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPISpec(t *testing.T) {
+	r := chi.NewRouter()
+	registerRoutes(r, nil)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL + "/openapi.json")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var raw json.RawMessage
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&raw))
+
+	// The served document is a valid OpenAPI 3 spec
+	spec, err := openapi3.NewLoader().LoadFromData(raw)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+
+	// It documents every route the server answers
+	err = chi.Walk(r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		path := strings.TrimSuffix(route, "/")
+		item := spec.Paths.Find(path)
+		if assert.NotNil(t, item, "%s %s is not documented", method, path) {
+			assert.NotNil(t, item.GetOperation(method), "%s %s is not documented", method, path)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	docs, err := server.Client().Get(server.URL + "/docs")
+	require.NoError(t, err)
+	docs.Body.Close()
+	assert.Equal(t, http.StatusOK, docs.StatusCode)
+	assert.Contains(t, docs.Header.Get("Content-Type"), "text/html")
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// readinessTimeout bounds all checks of one /readyz request together.
+const readinessTimeout = 5 * time.Second
+
+// readiness holds the checks behind GET /readyz. runServer adds them as it
+// brings up each part of the server.
+var readiness = &readinessChecks{}
+
+// readinessCheck is one named condition the server must meet to take traffic.
+type readinessCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// readinessChecks is the list of checks run by GET /readyz, in order.
+type readinessChecks struct {
+	mu     sync.Mutex
+	checks []readinessCheck
+}
+
+// add registers check under name.
+func (c *readinessChecks) add(name string, check func(ctx context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, readinessCheck{name: name, check: check})
+}
+
+// checkResult is the outcome of one check in a /readyz response.
+type checkResult struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// run evaluates every check concurrently and returns the results in
+// registration order.
+func (c *readinessChecks) run(ctx context.Context) []checkResult {
+	c.mu.Lock()
+	checks := append([]readinessCheck(nil), c.checks...)
+	c.mu.Unlock()
+
+	results := make([]checkResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Go(func() {
+			results[i] = checkResult{Name: check.name, OK: true}
+			if err := check.check(ctx); err != nil {
+				results[i] = checkResult{Name: check.name, Error: err.Error()}
+			}
+		})
+	}
+	wg.Wait()
+	return results
+}
+
+// livezHandler reports that the process is up and serving requests. It
+// checks nothing else, so an orchestrator only restarts a server that hangs.
+func livezHandler(w http.ResponseWriter, r *http.Request) {
+	writeProbe(w, http.StatusOK, "ok", nil)
+}
+
+// readyzHandler reports whether the server can do useful work: its database
+// answers, events are relayed, the reconciler runs and is keeping up. A failed
+// check answers 503 and lists the failures; ?verbose lists every check.
+func readyzHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	results := readiness.run(ctx)
+	_, verbose := r.URL.Query()["verbose"]
+	status, code := "ok", http.StatusOK
+	listed := make([]checkResult, 0, len(results))
+	for _, result := range results {
+		if !result.OK {
+			status, code = "unavailable", http.StatusServiceUnavailable
+		}
+		if verbose || !result.OK {
+			listed = append(listed, result)
+		}
+	}
+	writeProbe(w, code, status, listed)
+}
+
+func writeProbe(w http.ResponseWriter, code int, status string, checks []checkResult) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(struct {
+		Status  string        `json:"status"`
+		Service string        `json:"service"`
+		Checks  []checkResult `json:"checks,omitempty"`
+	}{status, "fru-tracker", checks})
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbes(t *testing.T) {
+	client := storagetest.Open(t)
+	storage.SetEntClient(client)
+
+	bus := storage.NewDurableEventBus(events.NewInMemoryEventBus(10, 1), 0)
+	t.Cleanup(func() { _ = bus.Close() })
+
+	previous := readiness
+	readiness = &readinessChecks{}
+	t.Cleanup(func() { readiness = previous })
+	readiness.add("database", storage.Ping)
+	readiness.add("event-bus", func(context.Context) error { return bus.Running() })
+
+	r := chi.NewRouter()
+	registerRoutes(r, nil)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	type probe struct {
+		Status string        `json:"status"`
+		Checks []checkResult `json:"checks"`
+	}
+	get := func(path string) (int, probe) {
+		t.Helper()
+		resp, err := server.Client().Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var body probe
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+
+	// Alive regardless of the checks; not ready until events are relayed
+	code, body := get("/livez")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", body.Status)
+
+	code, body = get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unavailable", body.Status)
+	assert.Equal(t, []checkResult{{Name: "event-bus", Error: "event relay not started"}}, body.Checks)
+
+	bus.Start()
+	code, body = get("/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", body.Status)
+	assert.Empty(t, body.Checks)
+
+	code, body = get("/readyz?verbose")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []checkResult{{Name: "database", OK: true}, {Name: "event-bus", OK: true}}, body.Checks)
+
+	// A database that stops answering makes the server unready
+	require.NoError(t, bus.Close())
+	require.NoError(t, client.Close())
+	code, body = get("/readyz?verbose")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	require.Len(t, body.Checks, 2)
+	assert.False(t, body.Checks[0].OK)
+	assert.Equal(t, checkResult{Name: "event-bus", Error: "event relay stopped"}, body.Checks[1])
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/openchami/fabrica/pkg/versioning"
+
+	"github.com/example/fru-tracker/internal/metrics"
+	mw "github.com/example/fru-tracker/internal/middleware"
+)
+
+// newRouter builds the server's router. chi panics when middleware is added
+// after a route, so every r.Use comes before the debug profiler and routes.
+func newRouter(debug bool, authenticate func(http.Handler) http.Handler) *chi.Mux {
+	r := chi.NewRouter()
+
+	// Add middleware
+	r.Use(middleware.Recoverer)
+	r.Use(middleware.RequestID)
+	r.Use(middleware.RealIP)
+	r.Use(RequestMetrics)
+	r.Use(TraceRequests)
+	r.Use(LogRequests)
+
+	// Version negotiation (Accept header `version=` or URL strategy)
+	// Initialize and use global version registry when versioning is enabled.
+	// Note: VersionNegotiationMiddleware currently uses versioning.VersionRegistry
+	// Versioning support will be fully wired in a future update.
+	r.Use(versioning.VersionNegotiationMiddleware(versioning.GlobalVersionRegistry, nil))
+
+	if debug {
+		r.Mount("/debug", middleware.Profiler())
+	}
+
+	// Register routes - generated by 'fabrica generate'
+	registerRoutes(r, authenticate)
+	return r
+}
+
+// registerRoutes registers every route the server answers: the API, behind
+// authenticate when it is set, and the public health checks, metrics and API
+// documentation beside it.
+func registerRoutes(r chi.Router, authenticate func(http.Handler) http.Handler) {
+	r.Group(func(api chi.Router) {
+		if authenticate != nil {
+			api.Use(authenticate)
+		}
+		// ETags on reads, If-Match on writes
+		api.Use(mw.ConditionalMiddleware)
+		api.Use(ConditionalRequests)
+		// Serve CSV/BOM and watch variants of GET /devices before the generated JSON handler
+		api.Use(DeviceListVariants)
+		// Reject snapshots the reconciler could not process with 422
+		api.Use(SnapshotValidation)
+		// Refuse device writes taking another device's serial number with 409
+		api.Use(DeviceConflicts)
+		// Never answer with webhook signing secrets
+		api.Use(WebhookSecrets)
+
+		RegisterGeneratedRoutes(api)
+		RegisterCustomRoutes(api)
+	})
+
+	r.Get("/health", healthHandler)
+	r.Get("/livez", livezHandler)
+	r.Get("/readyz", readyzHandler)
+	r.Method(http.MethodGet, "/metrics", metrics.Handler())
+	r.Get("/openapi.json", ServeOpenAPISpec)
+	r.Get("/docs", ServeSwaggerUI)
+}
+
+// RegisterCustomRoutes registers the hand-written API routes that sit beside
+// the generated resource routes.
+func RegisterCustomRoutes(r chi.Router) {
+	r.Group(func(protected chi.Router) {
+		protected.Get("/changes", GetChanges)
+		protected.Get("/events", GetEvents)
+	})
+}
@@ -14,6 +14,7 @@
 // This file registers routes for all resource types:
 //   - /devices (Device operations)
 //   - /discoverysnapshots (DiscoverySnapshot operations)
+//   - /webhooksubscriptions (WebhookSubscription operations)
 //
 // Route patterns:
 //   - GET    /resource              -> List all resources
@@ -47,6 +48,8 @@ func registerResourcePrefixes() error {
 
 	resource.RegisterResourcePrefix("DiscoverySnapshot", "discoverysnapshot")
 
+	resource.RegisterResourcePrefix("WebhookSubscription", "webhooksubscription")
+
 	return nil
 }
 
@@ -94,5 +97,23 @@ func RegisterGeneratedRoutes(r chi.Router) {
 			})
 		})
 
+		// WebhookSubscription routes
+		protected.Route("/webhooksubscriptions", func(r chi.Router) {
+			r.Get("/", GetWebhookSubscriptions)
+			r.Post("/", CreateWebhookSubscription)
+			r.Route("/{uid}", func(r chi.Router) {
+				r.Get("/", GetWebhookSubscription)
+				r.Put("/", UpdateWebhookSubscription)
+				r.Patch("/", PatchWebhookSubscription)
+				r.Delete("/", DeleteWebhookSubscription)
+
+				// Status subresource
+				r.Route("/status", func(r chi.Router) {
+					r.Put("/", UpdateWebhookSubscriptionStatus)
+					r.Patch("/", PatchWebhookSubscriptionStatus)
+				})
+			})
+		})
+
 	})
 }
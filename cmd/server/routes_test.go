@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewRouterDebug checks the router builds with --debug, where the
+// profiler is mounted beside the API and its middleware.
+func TestNewRouterDebug(t *testing.T) {
+	var r *chi.Mux
+	require.NotPanics(t, func() { r = newRouter(true, nil) })
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	mw "github.com/example/fru-tracker/internal/middleware"
+)
+
+// ValidationErrorResponse is the body of a 422 response: the summary of an
+// ErrorResponse plus every problem found.
+type ValidationErrorResponse struct {
+	Error   string               `json:"error"`
+	Code    int                  `json:"code"`
+	Details []mw.ValidationError `json:"details"`
+}
+
+// SnapshotValidation checks the rawData of DiscoverySnapshots being created
+// or updated ahead of the generated handlers, which answer every validation
+// failure with a bare 400. A rawData the reconciler could not process is
+// rejected with 422 and the problems found in each entry, instead of being
+// accepted and failing later with Phase=Error.
+func SnapshotValidation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/")
+		create := r.Method == http.MethodPost && path == "/discoverysnapshots"
+		update := (r.Method == http.MethodPut || r.Method == http.MethodPatch) &&
+			strings.HasPrefix(path, "/discoverysnapshots/") && !strings.HasSuffix(path, "/status")
+		if !create && !update {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Malformed requests are left for the generated handler to reject
+		var req struct {
+			Spec v1.DiscoverySnapshotSpec `json:"spec"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil || (update && len(req.Spec.RawData) == 0) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		snapshot := &v1.DiscoverySnapshot{Spec: req.Spec}
+		if err := snapshot.Validate(r.Context()); err != nil {
+			if !errors.As(err, new(v1.ValidationErrors)) {
+				respondError(w, http.StatusBadRequest, fmt.Errorf("validation failed: %w", err))
+				return
+			}
+			respondJSON(w, http.StatusUnprocessableEntity, ValidationErrorResponse{
+				Error:   "Validation failed",
+				Code:    http.StatusUnprocessableEntity,
+				Details: mw.FormatResourceErrors(err),
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
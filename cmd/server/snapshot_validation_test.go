@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotValidation(t *testing.T) {
+	client := storagetest.Open(t)
+	storage.SetEntClient(client)
+	require.NoError(t, registerResourcePrefixes())
+
+	r := chi.NewRouter()
+	r.Use(SnapshotValidation)
+	RegisterGeneratedRoutes(r)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	send := func(method, path, rawData string) (*http.Response, ValidationErrorResponse) {
+		t.Helper()
+		body := `{"metadata": {"name": "snap"}, "spec": {"rawData": ` + rawData + `}}`
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var decoded ValidationErrorResponse
+		if resp.StatusCode == http.StatusUnprocessableEntity {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+		}
+		return resp, decoded
+	}
+	fields := func(details ValidationErrorResponse) []string {
+		var names []string
+		for _, detail := range details.Details {
+			names = append(names, detail.Field)
+		}
+		return names
+	}
+
+	resp, _ := send(http.MethodPost, "/discoverysnapshots", `[
+		{"deviceType": "Node", "serialNumber": "NODE-1"},
+		{"deviceType": "DIMM", "properties": {"redfish_uri": "/Systems/1/Memory/1", "capacity_mib": 32768}}
+	]`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, details := send(http.MethodPost, "/discoverysnapshots", `{"devices": []}`)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, []string{"spec.rawData"}, fields(details))
+	assert.Contains(t, details.Details[0].Message, "must be a JSON array")
+
+	resp, details = send(http.MethodPost, "/discoverysnapshots", `[]`)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, "must list at least one device", details.Details[0].Message)
+
+	resp, details = send(http.MethodPost, "/discoverysnapshots", `[
+		{"deviceType": "Node", "serialNumber": "NODE-1"},
+		{"serialNumber": "CPU-1"},
+		{"deviceType": "PSU"},
+		{"deviceType": "CPU", "serialNumber": "NODE-1"},
+		{"deviceType": "Drive", "serialNumber": "DRIVE-1", "properties": {"capacity_bytes": -1, "redfish_uri": 7}},
+		{"deviceType": "Fan", "serialNumber": "`+strings.Repeat("x", 300)+`"}
+	]`)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, http.StatusUnprocessableEntity, details.Code)
+	assert.Equal(t, []string{
+		"spec.rawData[1].deviceType",
+		"spec.rawData[2]",
+		"spec.rawData[3].serialNumber",
+		"spec.rawData[4].properties.redfish_uri",
+		"spec.rawData[4].properties.capacity_bytes",
+		"spec.rawData[5].serialNumber",
+	}, fields(details))
+	assert.Contains(t, details.Details[2].Message, "spec.rawData[0]")
+
+	// Nothing invalid was stored
+	snapshots, err := storage.LoadAllDiscoverySnapshots(t.Context())
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+
+	resp, details = send(http.MethodPut, "/discoverysnapshots/"+snapshots[0].GetUID(), `[{"deviceType": "Node"}]`)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, []string{"spec.rawData[0]"}, fields(details))
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/example/fru-tracker/internal/tracing"
+)
+
+// Values of --tracing-exporter.
+const (
+	tracingExporterNone   = ""
+	tracingExporterStdout = "stdout"
+	tracingExporterOTLP   = "otlp"
+)
+
+// defaultOTLPEndpoint is where an OpenTelemetry collector listens for
+// OTLP/HTTP by default.
+const defaultOTLPEndpoint = "http://localhost:4318"
+
+// newTracingExporter returns the exporter named by kind, or nil when tracing
+// is off. An unset endpoint falls back to OTEL_EXPORTER_OTLP_ENDPOINT and
+// then to a collector on localhost.
+func newTracingExporter(kind, endpoint string) (sdktrace.SpanExporter, error) {
+	switch kind {
+	case tracingExporterNone:
+		return nil, nil
+	case tracingExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case tracingExporterOTLP:
+		if endpoint == "" {
+			endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		}
+		if endpoint == "" {
+			endpoint = defaultOTLPEndpoint
+		}
+		return tracing.NewOTLPExporter(endpoint, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q (want %q or %q)", kind, tracingExporterStdout, tracingExporterOTLP)
+	}
+}
+
+// TraceRequests runs each request in a server span that continues the trace
+// of an incoming traceparent header. Spans are named by route pattern.
+func TraceRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := tracing.Extract(r.Context(), r.Header.Get("traceparent"))
+		ctx, span := tracing.Start(ctx, r.Method, trace.SpanKindServer)
+		if !span.IsRecording() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		defer span.End()
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		route, status := requestRoute(r), responseStatus(ww)
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(
+			attribute.String("http.request.method", r.Method),
+			attribute.String("http.route", route),
+			attribute.String("url.path", r.URL.Path),
+			attribute.Int("http.response.status_code", status),
+		)
+		if requestID := middleware.GetReqID(r.Context()); requestID != "" {
+			span.SetAttributes(attribute.String("fru_tracker.request_id", requestID))
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	})
+}
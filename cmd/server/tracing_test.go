@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/example/fru-tracker/internal/tracing"
+	"github.com/example/fru-tracker/pkg/reconcilers"
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/reconcile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestTracing follows one snapshot POST through the event bus into the
+// reconciler and checks that every hop lands in the caller's trace.
+func TestTracing(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.Open(t)
+	storage.SetEntClient(client)
+	require.NoError(t, registerResourcePrefixes())
+
+	exported := tracetest.NewInMemoryExporter()
+	shutdown := tracing.Setup(exported, "fru-tracker")
+	t.Cleanup(func() { _ = shutdown(ctx) })
+
+	memoryBus := events.NewInMemoryEventBus(100, 1)
+	memoryBus.Start()
+	bus := storage.NewDurableEventBus(memoryBus, 0)
+	t.Cleanup(func() { _ = bus.Close() })
+	previous := events.GetGlobalEventBus()
+	events.SetGlobalEventBus(bus)
+	t.Cleanup(func() { events.SetGlobalEventBus(previous) })
+
+	controller := reconcile.NewController(bus, storage.Backend)
+	require.NoError(t, reconcilers.RegisterControllerReconcilers(controller, storage.NewStorageClient(), bus))
+	require.NoError(t, controller.Start(ctx))
+	t.Cleanup(controller.Stop)
+	snapshots := reconcilers.NewSnapshotRunner(storage.NewStorageClient(), bus)
+	require.NoError(t, snapshots.Subscribe(bus, events.GetEventConfig().EventTypePrefix))
+	t.Cleanup(snapshots.Close)
+	bus.Start()
+
+	r := chi.NewRouter()
+	r.Use(TraceRequests)
+	registerRoutes(r, nil)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/discoverysnapshots", strings.NewReader(
+		`{"metadata": {"name": "snap"}, "spec": {"rawData": [{"deviceType": "Node", "serialNumber": "NODE-1"}, {"deviceType": "DIMM", "serialNumber": "DIMM-1", "parentSerialNumber": "NODE-1"}]}}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	require.Eventually(t, func() bool {
+		snapshots, err := storage.LoadAllDiscoverySnapshots(ctx)
+		return err == nil && len(snapshots) == 1 && snapshots[0].Status.Phase == "Completed"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Every hop is in the caller's trace, under the span it came from
+	byName := make(map[string]tracetest.SpanStub)
+	require.Eventually(t, func() bool {
+		tracing.Flush()
+		for _, s := range exported.GetSpans() {
+			if s.SpanContext.TraceID().String() == traceID {
+				if _, seen := byName[s.Name]; !seen {
+					byName[s.Name] = s
+				}
+			}
+		}
+		_, done := byName["reconcile pass 2: link parents"]
+		return done
+	}, 5*time.Second, 10*time.Millisecond)
+
+	post := byName["POST /discoverysnapshots"]
+	assert.Equal(t, "00f067aa0ba902b7", post.Parent.SpanID().String())
+	// Event spans are named after the event type, whatever its prefix
+	eventSpan := func(operation string) tracetest.SpanStub {
+		for name, s := range byName {
+			if strings.HasPrefix(name, operation+" ") && strings.HasSuffix(name, ".discoverysnapshot.created") {
+				return s
+			}
+		}
+		t.Fatalf("no %s span for the snapshot's created event", operation)
+		return tracetest.SpanStub{}
+	}
+	publish := eventSpan("publish")
+	assert.Equal(t, post.SpanContext.SpanID(), publish.Parent.SpanID())
+	consume := eventSpan("consume")
+	assert.Equal(t, publish.SpanContext.SpanID(), consume.Parent.SpanID())
+	reconciled := byName["reconcile DiscoverySnapshot"]
+	assert.True(t, reconciled.Parent.HasSpanID())
+	merge := byName["reconcile pass 1: merge devices"]
+	assert.Equal(t, reconciled.SpanContext.SpanID(), merge.Parent.SpanID())
+	assert.Equal(t, reconciled.SpanContext.SpanID(), byName["reconcile pass 2: link parents"].Parent.SpanID())
+	assert.Contains(t, byName, "storage.SaveDevicesBulk")
+	assert.Contains(t, byName, "storage.LoadDevicesByIdentifiers")
+}
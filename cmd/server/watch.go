@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/events"
+
+	"github.com/example/fru-tracker/internal/storage"
+)
+
+// Watch stream tuning.
+const (
+	watchHistorySize       = 1024
+	watchSubscriberBuffer  = 64
+	watchHeartbeatInterval = 30 * time.Second
+)
+
+// watchActions are the lifecycle actions the hub relays from the event bus.
+var watchActions = []string{"created", "updated", "patched", "deleted"}
+
+// watchBookmark is the event name of the first message on every stream. It
+// carries the revision the stream starts from and no object.
+const watchBookmark = "bookmark"
+
+// errRevisionGone is returned when a client asks to resume from a revision the
+// hub no longer holds. The client must re-list and watch from the new revision.
+var errRevisionGone = errors.New("requested revision is no longer available; re-list and watch again")
+
+// watcher is the hub serving GET /events; nil when the server runs without an event bus.
+var watcher *watchHub
+
+// watchEvent is one message in the watch stream.
+type watchEvent struct {
+	Revision uint64      `json:"revision"`
+	Action   string      `json:"action"`
+	Kind     string      `json:"kind,omitempty"`
+	UID      string      `json:"uid,omitempty"`
+	Time     time.Time   `json:"time"`
+	Object   interface{} `json:"object,omitempty"`
+}
+
+// watchHub relays resource lifecycle events from the event bus to streaming
+// HTTP clients. Each event gets a monotonically increasing revision and the
+// most recent events are kept so that reconnecting clients can resume.
+type watchHub struct {
+	mu          sync.Mutex
+	revision    uint64
+	history     []watchEvent
+	subscribers map[chan watchEvent]struct{}
+}
+
+func newWatchHub() *watchHub {
+	return &watchHub{subscribers: make(map[chan watchEvent]struct{})}
+}
+
+// Subscribe registers the hub for the lifecycle events of every exportable kind.
+func (h *watchHub) Subscribe(bus events.EventBus, prefix string) error {
+	for _, kind := range exportableKinds {
+		for _, action := range watchActions {
+			eventType := fmt.Sprintf("%s.%s.%s", prefix, strings.ToLower(kind), action)
+			handler := func(ctx context.Context, event events.Event) error {
+				h.handleEvent(ctx, kind, action, event.ResourceUID())
+				return nil
+			}
+			if _, err := bus.Subscribe(eventType, handler); err != nil {
+				return fmt.Errorf("failed to subscribe to %s: %w", eventType, err)
+			}
+		}
+	}
+	return nil
+}
+
+// handleEvent attaches the current state of the resource and broadcasts it.
+func (h *watchHub) handleEvent(ctx context.Context, kind, action, uid string) {
+	var object interface{}
+	if action != "deleted" {
+		var err error
+		switch kind {
+		case "Device":
+			object, err = storage.GetDeviceByUID(ctx, uid)
+		case "DiscoverySnapshot":
+			object, err = storage.GetDiscoverySnapshotByUID(ctx, uid)
+		}
+		if err != nil {
+			// Deleted again before we got here; a deleted event will follow
+			object = nil
+		}
+	}
+	h.publish(watchEvent{Action: action, Kind: kind, UID: uid, Time: time.Now().UTC(), Object: object})
+}
+
+// publish assigns the next revision to event and fans it out. Subscribers that
+// cannot keep up are disconnected; they resume from their last revision.
+func (h *watchHub) publish(event watchEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.revision++
+	event.Revision = h.revision
+
+	h.history = append(h.history, event)
+	if len(h.history) > watchHistorySize {
+		h.history = h.history[len(h.history)-watchHistorySize:]
+	}
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(h.subscribers, ch)
+			close(ch)
+			watchStreamsDropped.Inc()
+		}
+	}
+}
+
+// watch registers a subscriber. When resume is set, events after revision are
+// replayed first. It returns the replayed events, the live channel and the
+// revision the stream starts from.
+func (h *watchHub) watch(revision uint64, resume bool) ([]watchEvent, chan watchEvent, uint64, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var backlog []watchEvent
+	if resume {
+		oldest := h.revision + 1
+		if len(h.history) > 0 {
+			oldest = h.history[0].Revision
+		}
+		if revision > h.revision || revision+1 < oldest {
+			return nil, nil, 0, errRevisionGone
+		}
+		for _, event := range h.history {
+			if event.Revision > revision {
+				backlog = append(backlog, event)
+			}
+		}
+	}
+
+	ch := make(chan watchEvent, watchSubscriberBuffer)
+	h.subscribers[ch] = struct{}{}
+	return backlog, ch, h.revision, nil
+}
+
+func (h *watchHub) unwatch(ch chan watchEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[ch]; ok {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
+// GetEvents streams resource lifecycle events as Server-Sent Events. The
+// optional `kinds` parameter filters by resource kind; `revision` (or the
+// Last-Event-ID header) resumes after a previously seen revision.
+func GetEvents(w http.ResponseWriter, r *http.Request) {
+	kinds, err := parseKinds(r.URL.Query().Get("kinds"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err)
+		return
+	}
+	serveWatch(w, r, kinds)
+}
+
+// serveWatch streams events for kinds until the client disconnects.
+func serveWatch(w http.ResponseWriter, r *http.Request, kinds []string) {
+	if watcher == nil {
+		respondError(w, http.StatusServiceUnavailable, fmt.Errorf("event streaming is not enabled"))
+		return
+	}
+
+	from := r.URL.Query().Get("revision")
+	if from == "" {
+		from = r.Header.Get("Last-Event-ID")
+	}
+	var revision uint64
+	if from != "" {
+		var err error
+		if revision, err = strconv.ParseUint(from, 10, 64); err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Errorf("invalid revision %q", from))
+			return
+		}
+	}
+
+	backlog, ch, head, err := watcher.watch(revision, from != "")
+	if errors.Is(err, errRevisionGone) {
+		respondError(w, http.StatusGone, err)
+		return
+	}
+	defer watcher.unwatch(ch)
+
+	// Streams outlive the server's write timeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	wanted := make(map[string]bool, len(kinds))
+	for _, kind := range kinds {
+		wanted[kind] = true
+	}
+
+	start := revision
+	if from == "" {
+		start = head
+	}
+	if err := writeSSE(w, watchEvent{Revision: start, Action: watchBookmark, Time: time.Now().UTC()}); err != nil {
+		return
+	}
+	for _, event := range backlog {
+		if !wanted[event.Kind] {
+			continue
+		}
+		if err := writeSSE(w, event); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(watchHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-ch:
+			if !ok {
+				// Dropped for falling behind; the client resumes from its last revision
+				return
+			}
+			if !wanted[event.Kind] {
+				continue
+			}
+			if err := writeSSE(w, event); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, event watchEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Revision, event.Action, data)
+	return err
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/example/fru-tracker/pkg/client"
+)
+
+func TestWatchStreamResumesFromRevision(t *testing.T) {
+	hub := newWatchHub()
+	watcher = hub
+	t.Cleanup(func() { watcher = nil })
+
+	r := chi.NewRouter()
+	r.Use(DeviceListVariants)
+	RegisterCustomRoutes(r)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient(server.URL, nil)
+	require.NoError(t, err)
+
+	// Two events before anyone watches: one device, one snapshot
+	hub.publish(watchEvent{Action: "created", Kind: "Device", UID: "device-1"})
+	hub.publish(watchEvent{Action: "created", Kind: "DiscoverySnapshot", UID: "discoverysnapshot-1"})
+
+	collect := func(watch func(context.Context, func(client.WatchEvent) error) error, want int) []client.WatchEvent {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var got []client.WatchEvent
+		done := errors.New("done")
+		err := watch(ctx, func(event client.WatchEvent) error {
+			got = append(got, event)
+			if event.Action == client.WatchActionBookmark {
+				// Changes made after the stream opened must arrive live
+				hub.publish(watchEvent{Action: "deleted", Kind: "Device", UID: "device-1"})
+			}
+			if len(got) == want {
+				return done
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, done)
+		return got
+	}
+
+	t.Run("streams live events and replays missed ones on resume", func(t *testing.T) {
+		got := collect(func(ctx context.Context, h func(client.WatchEvent) error) error {
+			return c.WatchDevices(ctx, 0, h)
+		}, 2)
+		assert.Equal(t, client.WatchActionBookmark, got[0].Action)
+		assert.Equal(t, uint64(2), got[0].Revision)
+		assert.Equal(t, "deleted", got[1].Action)
+		assert.Equal(t, uint64(3), got[1].Revision)
+
+		got = collect(func(ctx context.Context, h func(client.WatchEvent) error) error {
+			return c.Watch(ctx, client.WatchOptions{Revision: 1}, h)
+		}, 4)
+		assert.Equal(t, uint64(1), got[0].Revision)
+		assert.Equal(t, "DiscoverySnapshot", got[1].Kind)
+		assert.Equal(t, "device-1", got[2].UID)
+		assert.Equal(t, "deleted", got[3].Action)
+	})
+
+	t.Run("revision from the future has expired", func(t *testing.T) {
+		err := c.Watch(context.Background(), client.WatchOptions{Revision: 99}, func(client.WatchEvent) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, client.ErrWatchExpired)
+	})
+}
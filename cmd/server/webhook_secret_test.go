@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	mw "github.com/example/fru-tracker/internal/middleware"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/example/fru-tracker/pkg/client"
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/fabrica"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSecretIsWriteOnly(t *testing.T) {
+	storage.SetEntClient(storagetest.Open(t))
+	require.NoError(t, registerResourcePrefixes())
+
+	r := chi.NewRouter()
+	r.Use(ConditionalRequests)
+	r.Use(WebhookSecrets)
+	RegisterGeneratedRoutes(r)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	ctx := context.Background()
+	c, err := client.NewClient(server.URL, client.WithConditionalRequests(server.Client()))
+	require.NoError(t, err)
+
+	stored := func(uid string) string {
+		t.Helper()
+		subscription, err := storage.LoadWebhookSubscription(ctx, uid)
+		require.NoError(t, err)
+		return subscription.Spec.Secret
+	}
+	body := func(path string) string {
+		t.Helper()
+		resp, err := server.Client().Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	created, err := c.CreateWebhookSubscription(ctx, client.CreateWebhookSubscriptionRequest{
+		Metadata: fabrica.Metadata{Name: "fru-swaps"},
+		Spec:     v1.WebhookSubscriptionSpec{URL: "https://tickets.example.com/hooks/fru", Secret: "s3cret"},
+	})
+	require.NoError(t, err)
+	uid := created.Metadata.UID
+	assert.Empty(t, created.Spec.Secret)
+	assert.True(t, created.Spec.SecretSet)
+	assert.Equal(t, "s3cret", stored(uid), "the dispatcher signs with the stored secret")
+
+	assert.NotContains(t, body("/webhooksubscriptions"), "s3cret")
+	assert.NotContains(t, body("/webhooksubscriptions/"+uid), "s3cret")
+
+	// ETags are those of what clients see, so read-modify-write works
+	var etag string
+	subscription, err := c.GetWebhookSubscription(client.CaptureETag(ctx, &etag), uid)
+	require.NoError(t, err)
+	assert.True(t, subscription.Spec.SecretSet)
+	want, err := mw.GenerateETag(subscription)
+	require.NoError(t, err)
+	assert.Equal(t, want, etag)
+
+	// Writing back what was read keeps the secret
+	subscription.Spec.Paused = true
+	updated, err := c.UpdateWebhookSubscription(client.IfMatch(ctx, etag), uid, client.UpdateWebhookSubscriptionRequest{
+		Metadata: subscription.Metadata,
+		Spec:     subscription.Spec,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, updated.Spec.Secret)
+	assert.True(t, updated.Spec.SecretSet)
+	assert.Equal(t, "s3cret", stored(uid))
+
+	patched, err := c.PatchWebhookSubscription(ctx, uid, []byte(`{"secret": "n3w"}`), "application/merge-patch+json")
+	require.NoError(t, err)
+	assert.Empty(t, patched.Spec.Secret)
+	assert.Equal(t, "n3w", stored(uid))
+
+	patched, err = c.PatchWebhookSubscription(ctx, uid, []byte(`{"secret": null}`), "application/merge-patch+json")
+	require.NoError(t, err)
+	assert.False(t, patched.Spec.SecretSet)
+	assert.Empty(t, stored(uid))
+
+	// Webhook subscriptions are not part of the change feed or export
+	changes := httptest.NewRecorder()
+	changesRouter := chi.NewRouter()
+	RegisterCustomRoutes(changesRouter)
+	changesRouter.ServeHTTP(changes, httptest.NewRequest(http.MethodGet, "/changes?kinds=WebhookSubscription", nil))
+	assert.Equal(t, http.StatusBadRequest, changes.Code)
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage"
+)
+
+// WebhookSecrets keeps the signing secret of webhook subscriptions write-only
+// around the generated handlers. Every subscription they answer with is
+// redacted, and a PUT without a secret keeps the stored one, so writing back
+// what was read does not remove it.
+func WebhookSecrets(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/")
+		uid, item := strings.CutPrefix(path, "/webhooksubscriptions/")
+		if path != "/webhooksubscriptions" && !item {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if r.Method == http.MethodPut && item && !strings.Contains(uid, "/") {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+				return
+			}
+			body = keepWebhookSecret(r, uid, body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+		}
+
+		buffered := &bufferedResponse{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(buffered, r)
+
+		body := buffered.body.Bytes()
+		if buffered.status < http.StatusMultipleChoices {
+			if redacted, err := redactWebhookSubscriptions(body, !item && r.Method == http.MethodGet); err == nil {
+				body = redacted
+			}
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(buffered.status)
+		_, _ = w.Write(body)
+	})
+}
+
+// keepWebhookSecret returns the body of a PUT of subscription uid with the
+// stored secret added when the body has none. Bodies it cannot parse and
+// subscriptions it cannot load are left for the handler.
+func keepWebhookSecret(r *http.Request, uid string, body []byte) []byte {
+	var req map[string]json.RawMessage
+	var spec map[string]json.RawMessage
+	if json.Unmarshal(body, &req) != nil || json.Unmarshal(req["spec"], &spec) != nil || spec == nil {
+		return body
+	}
+	var secret string
+	if raw, ok := spec["secret"]; ok && json.Unmarshal(raw, &secret) == nil && secret != "" {
+		return body
+	}
+
+	stored, err := storage.LoadWebhookSubscription(r.Context(), uid)
+	if err != nil || stored.Spec.Secret == "" {
+		return body
+	}
+	spec["secret"], _ = json.Marshal(stored.Spec.Secret)
+	if req["spec"], err = json.Marshal(spec); err != nil {
+		return body
+	}
+	if kept, err := json.Marshal(req); err == nil {
+		return kept
+	}
+	return body
+}
+
+// redactWebhookSubscriptions re-encodes a response holding one subscription,
+// or a list of them, with their secrets removed.
+func redactWebhookSubscriptions(body []byte, list bool) ([]byte, error) {
+	if list {
+		var subscriptions []*v1.WebhookSubscription
+		if err := json.Unmarshal(body, &subscriptions); err != nil {
+			return nil, err
+		}
+		for i, subscription := range subscriptions {
+			subscriptions[i] = subscription.Redacted()
+		}
+		return encodeResponse(subscriptions)
+	}
+
+	var subscription v1.WebhookSubscription
+	if err := json.Unmarshal(body, &subscription); err != nil {
+		return nil, err
+	}
+	return encodeResponse(subscription.Redacted())
+}
+
+// encodeResponse encodes v the way respondJSON does.
+func encodeResponse(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	err := json.NewEncoder(&buf).Encode(v)
+	return buf.Bytes(), err
+}
@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package auth verifies the JSON Web Tokens that callers of the API present
+// as bearer tokens, against the public keys of a JSON Web Key Set published
+// by the identity provider or kept in a local file.
+//
+// Tokens signed with RS256, RS384, RS512, ES256, ES384 and ES512 are
+// accepted. Symmetric algorithms and unsigned tokens are not, so a leaked key
+// set never lets anyone mint tokens.
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JWK is one key of a JSON Web Key Set (RFC 7517). Only public RSA and EC
+// keys are used.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	// RSA modulus and exponent
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// EC curve and point
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// curves maps the JWK names of the supported curves to their implementations.
+var curves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+// NewJWK describes a public key as a JWK, for publishing the keys tokens are
+// signed with.
+func NewJWK(pub crypto.PublicKey, kid string) (JWK, error) {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		point, err := key.Bytes()
+		if err != nil {
+			return JWK{}, fmt.Errorf("invalid EC key: %w", err)
+		}
+		// The uncompressed point is 0x04 followed by X and Y of equal length
+		size := (len(point) - 1) / 2
+		return JWK{
+			Kty: "EC",
+			Kid: kid,
+			Use: "sig",
+			Crv: key.Curve.Params().Name,
+			X:   base64.RawURLEncoding.EncodeToString(point[1 : 1+size]),
+			Y:   base64.RawURLEncoding.EncodeToString(point[1+size:]),
+		}, nil
+	}
+	return JWK{}, fmt.Errorf("unsupported key type %T", pub)
+}
+
+// Thumbprint returns the RFC 7638 thumbprint of the key, a key ID derived
+// from the key itself.
+func (k JWK) Thumbprint() string {
+	// The required members in lexicographic order, without whitespace
+	var canonical string
+	if k.Kty == "EC" {
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":"EC","x":%q,"y":%q}`, k.Crv, k.X, k.Y)
+	} else {
+		canonical = fmt.Sprintf(`{"e":%q,"kty":%q,"n":%q}`, k.E, k.Kty, k.N)
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// PublicKey decodes the key.
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil || len(n) == 0 {
+			return nil, fmt.Errorf("invalid RSA modulus")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		curve, ok := curves[k.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("invalid EC point")
+		}
+		point := append([]byte{4}, x...)
+		point = append(point, y...)
+		key, err := ecdsa.ParseUncompressedPublicKey(curve, point)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC point: %w", err)
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+const (
+	// refreshInterval is how long keys are used before the set is loaded
+	// again, to pick up rotated keys.
+	refreshInterval = time.Hour
+	// minRefreshInterval limits reloads triggered by tokens naming keys the
+	// set lacks, so forged key ids cannot flood the identity provider.
+	minRefreshInterval = time.Minute
+)
+
+// key is a usable key of a set.
+type key struct {
+	kid string
+	alg string
+	pub crypto.PublicKey
+}
+
+// KeySet is a JWKS read from a file or an http(s) URL. It is loaded again
+// every hour and whenever a token names a key it lacks, at most once a
+// minute, so keys can be rotated without restarting the server.
+type KeySet struct {
+	source string
+	client *http.Client
+
+	mu     sync.Mutex
+	keys   []key
+	loaded time.Time
+}
+
+// NewKeySet loads the key set at source, a file path or an http(s) URL.
+func NewKeySet(ctx context.Context, source string) (*KeySet, error) {
+	s := &KeySet{
+		source: source,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+	keys, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.keys = keys
+	s.loaded = time.Now()
+	return s, nil
+}
+
+// lookup returns the keys a token with kid may be verified with: the key
+// with that id, or every key when the token names none.
+func (s *KeySet) lookup(ctx context.Context, kid string) []key {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matches := s.match(kid)
+	age := time.Since(s.loaded)
+	if age > refreshInterval || (len(matches) == 0 && age > minRefreshInterval) {
+		keys, err := s.load(ctx)
+		// A failed reload keeps the keys that worked until now
+		s.loaded = time.Now()
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to reload JWKS", "source", s.source, "error", err)
+		} else {
+			s.keys = keys
+			matches = s.match(kid)
+		}
+	}
+	return matches
+}
+
+func (s *KeySet) match(kid string) []key {
+	if kid == "" {
+		return s.keys
+	}
+	for _, k := range s.keys {
+		if k.kid == kid {
+			return []key{k}
+		}
+	}
+	return nil
+}
+
+// load reads and decodes the set. Keys that are not for signatures or cannot
+// be decoded are skipped with a warning.
+func (s *KeySet) load(ctx context.Context) ([]key, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(s.source, "http://") || strings.HasPrefix(s.source, "https://") {
+		data, err = s.fetch(ctx)
+	} else {
+		data, err = os.ReadFile(s.source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+
+	var set JWKS
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS from %s: %w", s.source, err)
+	}
+	var keys []key
+	for i, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		pub, err := jwk.PublicKey()
+		if err != nil {
+			slog.WarnContext(ctx, "Skipping unusable JWKS key", "source", s.source, "index", i, "kid", jwk.Kid, "error", err)
+			continue
+		}
+		keys = append(keys, key{kid: jwk.Kid, alg: jwk.Alg, pub: pub})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS " + s.source + " has no usable signing keys")
+	}
+	return keys, nil
+}
+
+func (s *KeySet) fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", s.source, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
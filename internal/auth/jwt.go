@@ -0,0 +1,312 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Roles understood by the API.
+const (
+	// RoleReader may read every resource.
+	RoleReader = "reader"
+	// RoleCollector may only post DiscoverySnapshots.
+	RoleCollector = "collector"
+	// RoleAdmin may do anything, including editing and deleting devices
+	// directly and exporting the inventory.
+	RoleAdmin = "admin"
+)
+
+// DefaultRolesClaim is the claim roles are read from when none is configured.
+const DefaultRolesClaim = "roles"
+
+// Options control which tokens a Verifier accepts.
+type Options struct {
+	// Issuer, when set, must equal the iss claim.
+	Issuer string
+	// Audience, when set, must be one of the aud claim's values.
+	Audience string
+	// RolesClaim names the claim holding the caller's roles, either a list or
+	// a space-separated string. Dots reach into nested objects, as in
+	// realm_access.roles.
+	RolesClaim string
+	// Leeway is the clock skew allowed when checking exp and nbf.
+	Leeway time.Duration
+}
+
+// Claims are what the API uses of a verified token.
+type Claims struct {
+	Subject   string
+	Roles     []string
+	ExpiresAt time.Time
+}
+
+// HasRole reports whether the token grants role.
+func (c *Claims) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role)
+}
+
+// Verifier checks bearer tokens against a key set.
+type Verifier struct {
+	keys    *KeySet
+	options Options
+	now     func() time.Time
+}
+
+// NewVerifier creates a Verifier using keys. An unset roles claim and leeway
+// take their defaults.
+func NewVerifier(keys *KeySet, options Options) *Verifier {
+	if options.RolesClaim == "" {
+		options.RolesClaim = DefaultRolesClaim
+	}
+	if options.Leeway <= 0 {
+		options.Leeway = time.Minute
+	}
+	return &Verifier{keys: keys, options: options, now: time.Now}
+}
+
+// algorithm is a supported JWS signature algorithm.
+type algorithm struct {
+	hash crypto.Hash
+	// curve is set for ECDSA algorithms
+	curve elliptic.Curve
+}
+
+var algorithms = map[string]algorithm{
+	"RS256": {hash: crypto.SHA256},
+	"RS384": {hash: crypto.SHA384},
+	"RS512": {hash: crypto.SHA512},
+	"ES256": {hash: crypto.SHA256, curve: elliptic.P256()},
+	"ES384": {hash: crypto.SHA384, curve: elliptic.P384()},
+	"ES512": {hash: crypto.SHA512, curve: elliptic.P521()},
+}
+
+// header is the JOSE header of a token.
+type header struct {
+	Alg  string   `json:"alg"`
+	Kid  string   `json:"kid,omitempty"`
+	Typ  string   `json:"typ,omitempty"`
+	Crit []string `json:"crit,omitempty"`
+}
+
+// Verify checks the signature, lifetime, issuer and audience of a compact
+// serialized token and returns its claims. Tokens must carry exp.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("token is not a signed JWT")
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("invalid token header: %w", err)
+	}
+	alg, ok := algorithms[h.Alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signing algorithm %q", h.Alg)
+	}
+	if len(h.Crit) > 0 {
+		return nil, fmt.Errorf("unsupported critical header parameters %v", h.Crit)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("invalid token signature encoding")
+	}
+
+	digest := hashOf(alg.hash, parts[0]+"."+parts[1])
+	verified := false
+	for _, k := range v.keys.lookup(ctx, h.Kid) {
+		if (k.alg == "" || k.alg == h.Alg) && verify(alg, k.pub, digest, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("token signature does not match any trusted key")
+	}
+
+	var payload map[string]any
+	if err := decodeSegment(parts[1], &payload); err != nil {
+		return nil, fmt.Errorf("invalid token claims: %w", err)
+	}
+	return v.claims(payload)
+}
+
+// claims checks the registered claims of a token with a valid signature.
+func (v *Verifier) claims(payload map[string]any) (*Claims, error) {
+	now := v.now()
+	exp, ok := payload["exp"].(float64)
+	if !ok {
+		return nil, errors.New("token has no expiry")
+	}
+	expiresAt := time.Unix(int64(exp), 0)
+	if now.After(expiresAt.Add(v.options.Leeway)) {
+		return nil, errors.New("token has expired")
+	}
+	if nbf, ok := payload["nbf"].(float64); ok && now.Add(v.options.Leeway).Before(time.Unix(int64(nbf), 0)) {
+		return nil, errors.New("token is not valid yet")
+	}
+	if v.options.Issuer != "" {
+		if iss, _ := payload["iss"].(string); iss != v.options.Issuer {
+			return nil, fmt.Errorf("token issuer %q is not trusted", iss)
+		}
+	}
+	if v.options.Audience != "" && !slices.Contains(stringList(payload["aud"]), v.options.Audience) {
+		return nil, errors.New("token is not intended for this service")
+	}
+
+	subject, _ := payload["sub"].(string)
+	return &Claims{
+		Subject:   subject,
+		Roles:     stringList(claim(payload, v.options.RolesClaim)),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// claim returns the value at a dotted path into the claims.
+func claim(payload map[string]any, path string) any {
+	var value any = payload
+	for name := range strings.SplitSeq(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[name]
+	}
+	return value
+}
+
+// stringList reads a claim holding a list of strings or one space-separated
+// string.
+func stringList(value any) []string {
+	switch value := value.(type) {
+	case string:
+		return strings.Fields(value)
+	case []any:
+		list := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func verify(alg algorithm, pub crypto.PublicKey, digest, signature []byte) bool {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return alg.curve == nil && rsa.VerifyPKCS1v15(key, alg.hash, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		// JWS signatures are R and S side by side, each the size of the curve
+		if alg.curve == nil || key.Curve != alg.curve {
+			return false
+		}
+		size := (alg.curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(key, digest, r, s)
+	}
+	return false
+}
+
+// Sign issues a token for claims signed with an RSA or EC private key, for
+// local testing and for deployments without an identity provider.
+func Sign(signer crypto.Signer, kid string, claims map[string]any) (string, error) {
+	var name string
+	switch key := signer.Public().(type) {
+	case *rsa.PublicKey:
+		name = "RS256"
+	case *ecdsa.PublicKey:
+		for alg, def := range algorithms {
+			if def.curve == key.Curve {
+				name = alg
+			}
+		}
+	}
+	alg, ok := algorithms[name]
+	if !ok {
+		return "", fmt.Errorf("unsupported signing key %T", signer.Public())
+	}
+
+	h, err := json.Marshal(header{Alg: name, Kid: kid, Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode claims: %w", err)
+	}
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := hashOf(alg.hash, signed)
+
+	var signature []byte
+	if key, ok := signer.(*ecdsa.PrivateKey); ok {
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+		if err != nil {
+			return "", fmt.Errorf("failed to sign token: %w", err)
+		}
+		size := (alg.curve.Params().BitSize + 7) / 8
+		signature = make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+	} else if signature, err = signer.Sign(rand.Reader, digest, alg.hash); err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func hashOf(hash crypto.Hash, data string) []byte {
+	switch hash {
+	case crypto.SHA384:
+		sum := sha512.Sum384([]byte(data))
+		return sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512([]byte(data))
+		return sum[:]
+	}
+	sum := sha256.Sum256([]byte(data))
+	return sum[:]
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the claims of the caller.
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, contextKey{}, claims)
+}
+
+// FromContext returns the claims of the caller, if the request was
+// authenticated.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(contextKey{}).(*Claims)
+	return claims, ok
+}
@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeJWKS publishes the public keys of signers in a JWKS file, each under
+// its index as key id.
+func writeJWKS(t *testing.T, signers ...crypto.Signer) string {
+	t.Helper()
+	var set JWKS
+	for i, signer := range signers {
+		jwk, err := NewJWK(signer.Public(), string(rune('a'+i)))
+		require.NoError(t, err)
+		set.Keys = append(set.Keys, jwk)
+	}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	return path
+}
+
+func claimsFor(roles any, ttl time.Duration) map[string]any {
+	return map[string]any{
+		"sub":   "tester",
+		"roles": roles,
+		"exp":   time.Now().Add(ttl).Unix(),
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	untrusted, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keys, err := NewKeySet(ctx, writeJWKS(t, rsaKey, ecKey))
+	require.NoError(t, err)
+	verifier := NewVerifier(keys, Options{Issuer: "https://idp.example.com", Audience: "fru-tracker"})
+
+	sign := func(signer crypto.Signer, kid string, claims map[string]any) string {
+		t.Helper()
+		claims["iss"] = "https://idp.example.com"
+		claims["aud"] = []string{"other", "fru-tracker"}
+		token, err := Sign(signer, kid, claims)
+		require.NoError(t, err)
+		return token
+	}
+
+	claims, err := verifier.Verify(ctx, sign(rsaKey, "a", claimsFor([]string{"reader", "admin"}, time.Hour)))
+	require.NoError(t, err)
+	assert.Equal(t, "tester", claims.Subject)
+	assert.True(t, claims.HasRole(RoleAdmin))
+	assert.False(t, claims.HasRole(RoleCollector))
+
+	// Roles may be one space-separated string, and tokens need not name their key
+	claims, err = verifier.Verify(ctx, sign(ecKey, "", claimsFor("collector reader", time.Hour)))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"collector", "reader"}, claims.Roles)
+
+	for name, token := range map[string]string{
+		"expired":          sign(rsaKey, "a", claimsFor("admin", -time.Hour)),
+		"untrusted key":    sign(untrusted, "b", claimsFor("admin", time.Hour)),
+		"key of other kid": sign(ecKey, "a", claimsFor("admin", time.Hour)),
+		"not a jwt":        "not-a-token",
+		"tampered":         tamper(t, sign(rsaKey, "a", claimsFor("reader", time.Hour))),
+		"unsigned":         unsigned(t, claimsFor("admin", time.Hour)),
+		"wrong issuer":     withClaim(t, rsaKey, "iss", "https://evil.example.com"),
+		"wrong audience":   withClaim(t, rsaKey, "aud", "someone-else"),
+		"no expiry":        withClaim(t, rsaKey, "exp", nil),
+		"not yet valid":    withClaim(t, rsaKey, "nbf", time.Now().Add(time.Hour).Unix()),
+	} {
+		_, err := verifier.Verify(ctx, token)
+		assert.Error(t, err, name)
+	}
+}
+
+// withClaim signs a token that is valid for the verifier in TestVerify except
+// for one claim. A nil value removes the claim.
+func withClaim(t *testing.T, key crypto.Signer, name string, value any) string {
+	t.Helper()
+	claims := claimsFor("admin", time.Hour)
+	claims["iss"] = "https://idp.example.com"
+	claims["aud"] = "fru-tracker"
+	if value == nil {
+		delete(claims, name)
+	} else {
+		claims[name] = value
+	}
+	token, err := Sign(key, "a", claims)
+	require.NoError(t, err)
+	return token
+}
+
+// tamper grants the admin role in a signed token without signing it again.
+func tamper(t *testing.T, token string) string {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	payload, err := json.Marshal(claimsFor("admin", time.Hour))
+	require.NoError(t, err)
+	parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+	return strings.Join(parts, ".")
+}
+
+func unsigned(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(payload) + "."
+}
+
+func TestVerifyNestedRolesClaim(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	keys, err := NewKeySet(ctx, writeJWKS(t, key))
+	require.NoError(t, err)
+	verifier := NewVerifier(keys, Options{RolesClaim: "realm_access.roles"})
+
+	token, err := Sign(key, "a", map[string]any{
+		"exp":          time.Now().Add(time.Hour).Unix(),
+		"realm_access": map[string]any{"roles": []string{"reader"}},
+	})
+	require.NoError(t, err)
+	claims, err := verifier.Verify(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reader"}, claims.Roles)
+}
+
+func TestKeySetFromURLPicksUpRotatedKeys(t *testing.T) {
+	ctx := context.Background()
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var published atomic.Value
+	published.Store(writeJWKS(t, oldKey))
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		http.ServeFile(w, r, published.Load().(string))
+	}))
+	defer server.Close()
+
+	keys, err := NewKeySet(ctx, server.URL)
+	require.NoError(t, err)
+	verifier := NewVerifier(keys, Options{})
+
+	// The provider starts signing with a new key, published beside the old one
+	published.Store(writeJWKS(t, oldKey, newKey))
+	token, err := Sign(newKey, "b", map[string]any{"exp": time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+
+	// Reloads for unknown keys are rate limited
+	_, err = verifier.Verify(ctx, token)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), fetches.Load())
+
+	keys.mu.Lock()
+	keys.loaded = time.Now().Add(-2 * minRefreshInterval)
+	keys.mu.Unlock()
+	_, err = verifier.Verify(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestNewKeySetRejectsEmptySet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`), 0o644))
+	_, err := NewKeySet(context.Background(), path)
+	assert.ErrorContains(t, err, "no usable signing keys")
+}
+
+func TestThumbprint(t *testing.T) {
+	// The example key of RFC 7638 section 3.1
+	jwk := JWK{
+		Kty: "RSA",
+		E:   "AQAB",
+		N: "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECP" +
+			"ebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2Q" +
+			"vzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh" +
+			"6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+	}
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", jwk.Thumbprint())
+}
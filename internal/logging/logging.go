@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package logging configures the service's structured logger.
+//
+// Setup installs one log/slog logger as the default for both slog and the
+// standard log package. The service logs with slog directly; the standard
+// logger is only bridged for Fabrica-generated code, which still calls
+// log.Printf, so its lines come out in the same format. Fields added to
+// a context with With are attached to every record logged with that context,
+// which is how a request ID or snapshot UID follows the work from the handler
+// through the reconciler into storage.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/example/fru-tracker/internal/tracing"
+)
+
+// Output formats accepted by New and Setup.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// ParseLevel parses debug, info, warn or error, in any case.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q (want debug, info, warn or error)", s)
+	}
+	return level, nil
+}
+
+// New returns a logger writing records at level and above to w in format.
+func New(w io.Writer, format string, level slog.Leveler) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch format {
+	case FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q (want %q or %q)", format, FormatText, FormatJSON)
+	}
+	return slog.New(contextHandler{handler}), nil
+}
+
+// Setup makes a logger built by New the default for slog and for the
+// standard log package.
+func Setup(w io.Writer, format, level string) error {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	logger, err := New(w, format, lvl)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	log.SetFlags(0)
+	log.SetOutput(logWriter{logger})
+	return nil
+}
+
+type fieldsKey struct{}
+
+// With returns a copy of ctx whose log records carry the given key-value
+// pairs, after any added by an enclosing With.
+func With(ctx context.Context, args ...any) context.Context {
+	fields, _ := ctx.Value(fieldsKey{}).([]any)
+	return context.WithValue(ctx, fieldsKey{}, append(slices.Clip(fields), args...))
+}
+
+// contextHandler adds the fields of With, and the trace ID of the current
+// span, to each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if fields, ok := ctx.Value(fieldsKey{}).([]any); ok {
+		r.Add(fields...)
+	}
+	if traceID := tracing.TraceIDFromContext(ctx); traceID != "" {
+		r.AddAttrs(slog.String("trace_id", traceID))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// levelPrefixes are the message prefixes that Fabrica-generated code, such
+// as the reconcile package's default logger and the generated middleware,
+// uses to mark a level when it calls log.Printf.
+var levelPrefixes = []struct {
+	prefix string
+	level  slog.Level
+}{
+	{"WARN: ", slog.LevelWarn},
+	{"ERROR: ", slog.LevelError},
+	{"INFO: ", slog.LevelInfo},
+	{"DEBUG: ", slog.LevelDebug},
+}
+
+// logWriter turns lines of the standard log package, which only generated
+// code writes, into records, taking the level from the line's prefix.
+// Unprefixed lines are logged at info.
+type logWriter struct {
+	logger *slog.Logger
+}
+
+func (w logWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	level := slog.LevelInfo
+	for _, lp := range levelPrefixes {
+		if rest, ok := strings.CutPrefix(msg, lp.prefix); ok {
+			msg, level = rest, lp.level
+			break
+		}
+	}
+	w.logger.Log(context.Background(), level, msg)
+	return len(p), nil
+}
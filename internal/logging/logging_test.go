@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/example/fru-tracker/internal/tracing"
+)
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("WARN")
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelWarn, level)
+
+	_, err = ParseLevel("loud")
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(os.Stderr, "xml", slog.LevelInfo)
+	assert.Error(t, err)
+
+	var buf bytes.Buffer
+	logger, err := New(&buf, FormatText, slog.LevelInfo)
+	require.NoError(t, err)
+	logger.DebugContext(With(context.Background(), "request_id", "r1"), "hidden")
+	logger.InfoContext(With(context.Background(), "request_id", "r1"), "shown")
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "level=INFO msg=shown request_id=r1")
+}
+
+func TestSetup(t *testing.T) {
+	previous, flags, output := slog.Default(), log.Flags(), log.Writer()
+	t.Cleanup(func() {
+		slog.SetDefault(previous)
+		log.SetFlags(flags)
+		log.SetOutput(output)
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, Setup(&buf, FormatJSON, "debug"))
+
+	// Fields accumulate through nested contexts, next to the trace ID
+	ctx := tracing.Extract(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx = With(ctx, "request_id", "r1")
+	slog.InfoContext(With(ctx, "snapshot_uid", "dis-1"), "Reconciling", "devices", 2)
+	// Generated code's standard logger goes through slog, at the level its
+	// prefix names
+	log.Printf("WARN: Validation failed for %T: %v", &struct{}{}, "missing name")
+	log.Printf("ERROR: Reconciliation failed")
+	log.Printf("Successfully initialized memory event bus")
+
+	var records []map[string]any
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		delete(record, "time")
+		records = append(records, record)
+	}
+	assert.Equal(t, []map[string]any{
+		{"level": "INFO", "msg": "Reconciling", "devices": float64(2), "request_id": "r1", "snapshot_uid": "dis-1",
+			"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"level": "WARN", "msg": "Validation failed for *struct {}: missing name"},
+		{"level": "ERROR", "msg": "Reconciliation failed"},
+		{"level": "INFO", "msg": "Successfully initialized memory event bus"},
+	}, records)
+}
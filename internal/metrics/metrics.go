@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package metrics holds what the server's Prometheus metrics share: their
+// histogram buckets, gauges computed at scrape time and the /metrics handler.
+//
+// Metrics are client_golang vectors created with promauto, usually as package
+// variables of the package that updates them, so they register with the
+// default registry next to the Go runtime and process collectors. Gauges that
+// are cheaper to compute on demand than to keep up to date are registered
+// with NewGaugeFunc and evaluated on every scrape.
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultBuckets are histogram upper bounds in seconds, from a fast API call
+// to a slow reconcile of a large snapshot.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// scrapeTimeout bounds each gauge function evaluated by a scrape.
+const scrapeTimeout = 10 * time.Second
+
+// Handler serves the default registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// gaugeFunc is a gauge evaluated at scrape time.
+type gaugeFunc struct {
+	name    string
+	desc    *prometheus.Desc
+	labeled bool
+	collect func(ctx context.Context) (map[string]float64, error)
+}
+
+// NewGaugeFunc returns a collector for a gauge computed by collect on every
+// scrape. collect returns one value per value of label; with no label, the
+// value under "" is the gauge's only value. A scrape on which collect fails
+// leaves the gauge out and logs why; the other metrics are still served.
+func NewGaugeFunc(opts prometheus.GaugeOpts, label string, collect func(ctx context.Context) (map[string]float64, error)) prometheus.Collector {
+	var labels []string
+	if label != "" {
+		labels = []string{label}
+	}
+	name := prometheus.BuildFQName(opts.Namespace, opts.Subsystem, opts.Name)
+	return &gaugeFunc{
+		name:    name,
+		desc:    prometheus.NewDesc(name, opts.Help, labels, opts.ConstLabels),
+		labeled: label != "",
+		collect: collect,
+	}
+}
+
+func (g *gaugeFunc) Describe(ch chan<- *prometheus.Desc) {
+	ch <- g.desc
+}
+
+func (g *gaugeFunc) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+
+	values, err := g.collect(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to collect metric", "metric", g.name, "error", err)
+		return
+	}
+	for key, value := range values {
+		if !g.labeled {
+			ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, value)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, value, key)
+	}
+}
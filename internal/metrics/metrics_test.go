@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaugeFunc(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(
+		NewGaugeFunc(prometheus.GaugeOpts{Name: "devices", Help: "Devices by type."}, "type",
+			func(context.Context) (map[string]float64, error) {
+				return map[string]float64{"Node": 2, `Odd "one"`: 1}, nil
+			}),
+		NewGaugeFunc(prometheus.GaugeOpts{Name: "pending", Help: "Pending events."}, "",
+			func(context.Context) (map[string]float64, error) {
+				return map[string]float64{"": 4}, nil
+			}),
+		NewGaugeFunc(prometheus.GaugeOpts{Name: "broken", Help: "Always fails."}, "",
+			func(context.Context) (map[string]float64, error) {
+				return nil, errors.New("database is down")
+			}),
+	)
+
+	// A failing gauge function is left out, the rest are gathered
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`# HELP devices Devices by type.
+# TYPE devices gauge
+devices{type="Node"} 2
+devices{type="Odd \"one\""} 1
+# HELP pending Pending events.
+# TYPE pending gauge
+pending 4
+`)))
+
+	assert.Panics(t, func() {
+		registry.MustRegister(NewGaugeFunc(prometheus.GaugeOpts{Name: "pending", Help: "Again."}, "",
+			func(context.Context) (map[string]float64, error) { return nil, nil }))
+	}, "duplicate name")
+}
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package server
+
+import (
+	"errors"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+)
+
+// FormatResourceErrors converts the per-field errors a resource's Validate
+// method returns, leaving any other error to FormatValidationErrors.
+func FormatResourceErrors(err error) []ValidationError {
+	var fieldErrs v1.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return FormatValidationErrors(err)
+	}
+	formatted := make([]ValidationError, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		formatted = append(formatted, ValidationError{Field: fieldErr.Field, Message: fieldErr.Message})
+	}
+	return formatted
+}
@@ -0,0 +1,342 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package redfishmock serves a canned Redfish tree over HTTP so the collector
+// can be tested without a BMC.
+//
+// A fixture is a JSON object mapping each @odata.id under /redfish/v1 to the
+// resource served there. The fixtures shipped in fixtures/ cover a
+// well-behaved rack server and one with the quirks real BMCs show: missing
+// serial numbers and @odata.ids that lack the service root or carry trailing
+// slashes. Paged collections are produced by setting PageSize, $expand is
+// honoured when Expand is set, and Fail makes resources answer with errors.
+package redfishmock
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Fixture returns one of the fixtures shipped with the package by name,
+// without the .json extension.
+func Fixture(name string) (map[string]json.RawMessage, error) {
+	data, err := fixtures.ReadFile("fixtures/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown fixture %s: %w", name, err)
+	}
+	return parseFixture(name, data)
+}
+
+// LoadFixture reads a fixture from a file.
+func LoadFixture(path string) (map[string]json.RawMessage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	return parseFixture(path, data)
+}
+
+func parseFixture(name string, data []byte) (map[string]json.RawMessage, error) {
+	var resources map[string]json.RawMessage
+	if err := json.Unmarshal(data, &resources); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture %s: %w", name, err)
+	}
+	normalized := make(map[string]json.RawMessage, len(resources))
+	for path, body := range resources {
+		normalized[cleanPath(path)] = body
+	}
+	return normalized, nil
+}
+
+// sessionsPath is where the mock accepts Redfish session logins.
+const sessionsPath = "/redfish/v1/SessionService/Sessions"
+
+// Server is a Redfish service answering from a fixture.
+type Server struct {
+	*httptest.Server
+
+	// Username and Password, when set, are required as basic auth or a
+	// session login.
+	Username string
+	Password string
+	// Sessions enables the SessionService; without it logins get a 404 and
+	// clients must fall back to basic auth.
+	Sessions bool
+	// PageSize splits collections into pages of this many members linked by
+	// Members@odata.nextLink. Zero serves every member at once.
+	PageSize int
+	// Expand advertises $expand in the service root and returns collection
+	// members inline when a request asks for it.
+	Expand bool
+
+	mu        sync.Mutex
+	resources map[string]json.RawMessage
+	tokens    map[string]bool
+	requests  map[string]int
+	failures  map[string]*failure
+	inFlight  int
+	peak      int
+}
+
+// failure is an error a resource answers with for a number of requests.
+type failure struct {
+	status     int
+	retryAfter string
+	remaining  int
+}
+
+// New starts a mock serving resources over HTTP. Close it when done.
+func New(resources map[string]json.RawMessage) *Server {
+	s := &Server{
+		resources: resources,
+		tokens:    make(map[string]bool),
+		requests:  make(map[string]int),
+		failures:  make(map[string]*failure),
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Set replaces the resource at path, for tests where hardware changes
+// between collections.
+func (s *Server) Set(path string, body json.RawMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resources[cleanPath(path)] = body
+}
+
+// Fail makes the next times GETs of path answer with status, as a busy or
+// failing BMC does. retryAfter, when set, is sent as the Retry-After header.
+func (s *Server) Fail(path string, status, times int, retryAfter string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[cleanPath(path)] = &failure{status: status, retryAfter: retryAfter, remaining: times}
+}
+
+// Requests returns how many GETs path has received, counting each page and
+// failed attempt.
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[cleanPath(path)]
+}
+
+// PeakConcurrency returns the most GETs that were being served at once.
+func (s *Server) PeakConcurrency() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.peak
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := cleanPath(r.URL.Path)
+	if r.Method == http.MethodPost && path == sessionsPath {
+		s.login(w, r)
+		return
+	}
+	if r.Method == http.MethodDelete && strings.HasPrefix(path, sessionsPath+"/") {
+		s.mu.Lock()
+		delete(s.tokens, strings.TrimPrefix(path, sessionsPath+"/"))
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests[path]++
+	s.inFlight++
+	s.peak = max(s.peak, s.inFlight)
+	body, ok := s.resources[path]
+	fail := s.failures[path]
+	if fail != nil && fail.remaining > 0 {
+		fail.remaining--
+	} else {
+		fail = nil
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+
+	if fail != nil {
+		if fail.retryAfter != "" {
+			w.Header().Set("Retry-After", fail.retryAfter)
+		}
+		w.WriteHeader(fail.status)
+		return
+	}
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	if s.Expand && path == "/redfish/v1" {
+		body = s.advertiseExpand(body)
+	}
+	if s.PageSize > 0 {
+		paged, err := s.page(path, body, query.Get("$skip"), query.Get("$expand"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = paged
+	}
+	if s.Expand && query.Get("$expand") != "" {
+		body = s.expand(body)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// login creates a session whose token is also its id.
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	if !s.Sessions {
+		http.NotFound(w, r)
+		return
+	}
+	var credentials struct{ UserName, Password string }
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil ||
+		credentials.UserName != s.Username || credentials.Password != s.Password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	token := "session-" + strconv.Itoa(len(s.tokens)+1)
+	s.tokens[token] = true
+	s.mu.Unlock()
+	w.Header().Set("X-Auth-Token", token)
+	w.Header().Set("Location", sessionsPath+"/"+token)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.Username == "" {
+		return true
+	}
+	if user, pass, ok := r.BasicAuth(); ok {
+		return user == s.Username && pass == s.Password
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens[r.Header.Get("X-Auth-Token")]
+}
+
+// page cuts the members of a collection down to the page starting at skip.
+// Resources without a Members array are returned whole.
+func (s *Server) page(path string, body json.RawMessage, skip, expand string) (json.RawMessage, error) {
+	var resource map[string]json.RawMessage
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return body, nil
+	}
+	var members []json.RawMessage
+	if err := json.Unmarshal(resource["Members"], &members); err != nil {
+		return body, nil
+	}
+
+	start := 0
+	if skip != "" {
+		var err error
+		if start, err = strconv.Atoi(skip); err != nil || start < 0 {
+			return nil, fmt.Errorf("invalid $skip %q", skip)
+		}
+	}
+	start = min(start, len(members))
+	end := min(start+s.PageSize, len(members))
+
+	resource["Members"], _ = json.Marshal(members[start:end])
+	resource["Members@odata.count"], _ = json.Marshal(len(members))
+	if end < len(members) {
+		next := fmt.Sprintf("%s?$skip=%d", path, end)
+		if expand != "" {
+			next += "&$expand=" + expand
+		}
+		resource["Members@odata.nextLink"], _ = json.Marshal(next)
+	}
+	return json.Marshal(resource)
+}
+
+// advertiseExpand adds the $expand support of the mock to the service root.
+func (s *Server) advertiseExpand(body json.RawMessage) json.RawMessage {
+	var root map[string]any
+	if err := json.Unmarshal(body, &root); err != nil {
+		return body
+	}
+	root["ProtocolFeaturesSupported"] = map[string]any{
+		"ExpandQuery": map[string]any{"ExpandAll": true, "NoLinks": true, "Levels": true, "MaxLevels": 1},
+	}
+	expanded, _ := json.Marshal(root)
+	return expanded
+}
+
+// expand replaces the member links of a collection with the members.
+// Members the fixture lacks stay links.
+func (s *Server) expand(body json.RawMessage) json.RawMessage {
+	var resource map[string]json.RawMessage
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return body
+	}
+	var members []struct {
+		ODataID string `json:"@odata.id"`
+	}
+	if err := json.Unmarshal(resource["Members"], &members); err != nil {
+		return body
+	}
+
+	s.mu.Lock()
+	inline := make([]json.RawMessage, 0, len(members))
+	for _, member := range members {
+		if member.ODataID == "" {
+			continue
+		}
+		var full map[string]json.RawMessage
+		if err := json.Unmarshal(s.resources[cleanPath(member.ODataID)], &full); err == nil {
+			// Fixtures are keyed by @odata.id, so their resources may lack it
+			if _, ok := full["@odata.id"]; !ok {
+				full["@odata.id"], _ = json.Marshal(member.ODataID)
+			}
+			body, _ := json.Marshal(full)
+			inline = append(inline, body)
+		} else {
+			link, _ := json.Marshal(member)
+			inline = append(inline, link)
+		}
+	}
+	s.mu.Unlock()
+	resource["Members"], _ = json.Marshal(inline)
+	expanded, _ := json.Marshal(resource)
+	return expanded
+}
+
+// cleanPath maps the forms an @odata.id takes in a fixture or request to one
+// key: /redfish/v1/... without a trailing slash.
+func cleanPath(path string) string {
+	path = "/" + strings.Trim(path, "/")
+	if !strings.HasPrefix(path, "/redfish/v1") {
+		path = "/redfish/v1" + strings.TrimSuffix(path, "/")
+	}
+	return path
+}
@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage/ent"
+	"github.com/example/fru-tracker/internal/storage/ent/hook"
+	entresource "github.com/example/fru-tracker/internal/storage/ent/resource"
+	enttombstone "github.com/example/fru-tracker/internal/storage/ent/tombstone"
+)
+
+const (
+	// TombstoneKind is the kind reported for deleted resources in change sets and exports.
+	TombstoneKind = "Tombstone"
+
+	// DefaultTombstoneRetention is how long tombstones of deleted resources are kept.
+	DefaultTombstoneRetention = 30 * 24 * time.Hour
+
+	// ChangesOverlap is how far the until of a change set trails the time it
+	// was read. Writers stamp updated_at before they commit, so a write
+	// committing just after a read can carry an earlier time than it; starting
+	// the next read this much earlier still picks it up.
+	ChangesOverlap = time.Minute
+)
+
+// ErrChangesExpired is returned for a since older than the tombstone retention,
+// whose deletions may already have been pruned.
+var ErrChangesExpired = errors.New("changes that far back are no longer available; export everything again")
+
+// tombstoneRetention is set by RecordTombstones.
+var tombstoneRetention = DefaultTombstoneRetention
+
+// Tombstone describes a resource that was deleted from storage.
+type Tombstone struct {
+	Kind         string      `json:"kind" yaml:"kind"`
+	ResourceKind string      `json:"resourceKind" yaml:"resourceKind"`
+	UID          string      `json:"uid" yaml:"uid"`
+	Name         string      `json:"name,omitempty" yaml:"name,omitempty"`
+	DeletedAt    time.Time   `json:"deletedAt" yaml:"deletedAt"`
+	Spec         interface{} `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+// ChangeSet holds every resource created, updated or deleted after Since.
+// Until trails the read by ChangesOverlap, so passing it as the next since
+// returns some changes again: keep the copy of a resource with the latest
+// updatedAt, and of a tombstone with the latest deletedAt.
+type ChangeSet struct {
+	Since      time.Time     `json:"since" yaml:"since"`
+	Until      time.Time     `json:"until" yaml:"until"`
+	Resources  []interface{} `json:"resources" yaml:"resources"`
+	Tombstones []*Tombstone  `json:"tombstones" yaml:"tombstones"`
+}
+
+// RecordTombstones installs a hook on client that writes a Tombstone row for
+// every Resource deleted through it, whichever code path performs the delete.
+// Tombstones older than retention are pruned as new ones are written; a zero
+// retention keeps them forever.
+func RecordTombstones(client *ent.Client, retention time.Duration) {
+	tombstoneRetention = retention
+	client.Resource.Use(tombstoneHook)
+}
+
+// CheckChangesSince returns ErrChangesExpired if tombstones of resources
+// deleted after since may already have been pruned. A zero since, asking
+// for everything, is always allowed.
+func CheckChangesSince(since time.Time) error {
+	if since.IsZero() || tombstoneRetention <= 0 {
+		return nil
+	}
+	if since.Before(time.Now().Add(-tombstoneRetention)) {
+		return ErrChangesExpired
+	}
+	return nil
+}
+
+func tombstoneHook(next ent.Mutator) ent.Mutator {
+	return hook.ResourceFunc(func(ctx context.Context, m *ent.ResourceMutation) (ent.Value, error) {
+		if !m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+			return next.Mutate(ctx, m)
+		}
+
+		ids, err := m.IDs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve resources to delete: %w", err)
+		}
+		var doomed []*ent.Resource
+		if len(ids) > 0 {
+			doomed, err = m.Client().Resource.Query().Where(entresource.IDIn(ids...)).All(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load resources to delete: %w", err)
+			}
+		}
+
+		value, err := next.Mutate(ctx, m)
+		if err != nil {
+			return value, err
+		}
+
+		now := time.Now()
+		for _, r := range doomed {
+			if err := m.Client().Tombstone.Create().
+				SetUID(r.UID).
+				SetName(r.Name).
+				SetKind(r.Kind).
+				SetSpec(r.Spec).
+				SetDeletedAt(now).
+				Exec(ctx); err != nil {
+				return value, fmt.Errorf("failed to record tombstone for %s %s: %w", r.Kind, r.UID, err)
+			}
+		}
+
+		if tombstoneRetention > 0 && len(doomed) > 0 {
+			if _, err := m.Client().Tombstone.Delete().
+				Where(enttombstone.DeletedAtLT(now.Add(-tombstoneRetention))).
+				Exec(ctx); err != nil {
+				return value, fmt.Errorf("failed to prune tombstones: %w", err)
+			}
+		}
+		return value, nil
+	})
+}
+
+// LoadResourcesChangedSince loads resources of kind created or updated after since.
+// A zero since returns every resource of that kind.
+func LoadResourcesChangedSince(ctx context.Context, kind string, since time.Time) ([]interface{}, error) {
+	if err := ensureBackendReady(); err != nil {
+		return nil, err
+	}
+
+	entResources, err := entClient.Resource.Query().
+		Where(
+			entresource.KindEQ(kind),
+			entresource.UpdatedAtGT(since),
+		).
+		Order(ent.Asc(entresource.FieldUpdatedAt)).
+		WithLabels().
+		WithAnnotations().
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query changed %s resources: %w", kind, err)
+	}
+
+	resources := make([]interface{}, 0, len(entResources))
+	for _, entResource := range entResources {
+		resource, err := FromEntResource(ctx, entResource)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// LoadTombstonesSince loads tombstones for resources of the given kinds deleted after since.
+func LoadTombstonesSince(ctx context.Context, kinds []string, since time.Time) ([]*Tombstone, error) {
+	if err := ensureBackendReady(); err != nil {
+		return nil, err
+	}
+
+	rows, err := entClient.Tombstone.Query().
+		Where(
+			enttombstone.KindIn(kinds...),
+			enttombstone.DeletedAtGT(since),
+		).
+		Order(ent.Asc(enttombstone.FieldDeletedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tombstones: %w", err)
+	}
+
+	tombstones := make([]*Tombstone, 0, len(rows))
+	for _, row := range rows {
+		tombstone := &Tombstone{
+			Kind:         TombstoneKind,
+			ResourceKind: row.Kind,
+			UID:          row.UID,
+			Name:         row.Name,
+			DeletedAt:    row.DeletedAt,
+		}
+		if len(row.Spec) > 0 && string(row.Spec) != "null" {
+			if err := json.Unmarshal(row.Spec, &tombstone.Spec); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal tombstone spec for %s: %w", row.UID, err)
+			}
+		}
+		tombstones = append(tombstones, tombstone)
+	}
+	return tombstones, nil
+}
+
+// LoadDeletedDevice rebuilds the last stored state of a deleted Device from
+// its most recent tombstone. It returns ErrNotFound if none was recorded.
+func LoadDeletedDevice(ctx context.Context, uid string) (*v1.Device, error) {
+	if err := ensureBackendReady(); err != nil {
+		return nil, err
+	}
+
+	row, err := entClient.Tombstone.Query().
+		Where(
+			enttombstone.KindEQ("Device"),
+			enttombstone.UIDEQ(uid),
+		).
+		Order(ent.Desc(enttombstone.FieldDeletedAt)).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to load tombstone for Device %s: %w", uid, err)
+	}
+
+	device := &v1.Device{
+		APIVersion: "example.fabrica.dev/v1",
+		Kind:       "Device",
+	}
+	device.Metadata.UID = row.UID
+	device.Metadata.Name = row.Name
+	if len(row.Spec) > 0 && string(row.Spec) != "null" {
+		if err := json.Unmarshal(row.Spec, &device.Spec); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tombstone spec for %s: %w", uid, err)
+		}
+	}
+	return device, nil
+}
+
+// LoadChangesSince collects the resources of the given kinds created, updated
+// or deleted after since. Until is captured before querying and set back by
+// ChangesOverlap, so callers can use it as the since value of their next
+// request without missing changes. It returns ErrChangesExpired if since is
+// older than the tombstone retention.
+func LoadChangesSince(ctx context.Context, kinds []string, since time.Time) (*ChangeSet, error) {
+	if err := CheckChangesSince(since); err != nil {
+		return nil, err
+	}
+
+	until := time.Now().Add(-ChangesOverlap)
+	if until.Before(since) {
+		until = since
+	}
+	changes := &ChangeSet{
+		Since:      since,
+		Until:      until.UTC(),
+		Resources:  []interface{}{},
+		Tombstones: []*Tombstone{},
+	}
+
+	for _, kind := range kinds {
+		resources, err := LoadResourcesChangedSince(ctx, kind, since)
+		if err != nil {
+			return nil, err
+		}
+		changes.Resources = append(changes.Resources, resources...)
+	}
+
+	tombstones, err := LoadTombstonesSince(ctx, kinds, since)
+	if err != nil {
+		return nil, err
+	}
+	changes.Tombstones = append(changes.Tombstones, tombstones...)
+
+	return changes, nil
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+
+	"github.com/example/fru-tracker/internal/storage/ent"
+)
+
+// Dialect returns the database dialect of databaseURL, chosen by its scheme:
+// postgres:// and postgresql:// URLs are PostgreSQL (dialect.Postgres) and
+// anything else, a file: URL or a plain path, is SQLite (dialect.SQLite).
+func Dialect(databaseURL string) string {
+	scheme, _, _ := strings.Cut(databaseURL, "://")
+	switch strings.ToLower(scheme) {
+	case "postgres", "postgresql":
+		return dialect.Postgres
+	}
+	return dialect.SQLite
+}
+
+// OpenClient opens an ent client on databaseURL without migrating it. The
+// driver must be linked in by the caller: github.com/mattn/go-sqlite3 for
+// SQLite and github.com/jackc/pgx/v5/stdlib for PostgreSQL.
+func OpenClient(databaseURL string) (*ent.Client, error) {
+	switch Dialect(databaseURL) {
+	case dialect.Postgres:
+		db, err := sql.Open("pgx", databaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed opening connection to postgres: %w", err)
+		}
+		return ent.NewClient(ent.Driver(entsql.OpenDB(dialect.Postgres, db))), nil
+	default:
+		client, err := ent.Open(dialect.SQLite, databaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed opening connection to sqlite3: %w", err)
+		}
+		return client, nil
+	}
+}
@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage/ent"
+	entidentity "github.com/example/fru-tracker/internal/storage/ent/deviceidentity"
+	"github.com/example/fru-tracker/internal/storage/ent/hook"
+	entresource "github.com/example/fru-tracker/internal/storage/ent/resource"
+)
+
+// IdentitySerial is the scheme of a DeviceIdentity holding a serial number.
+// Serial numbers are the only identities: Redfish URIs are relative to their
+// BMC, so every node of the same model reports the same ones.
+const IdentitySerial = "serial"
+
+// ErrDeviceConflict reports a write that would give a serial number already
+// held by one Device to another.
+var ErrDeviceConflict = errors.New("device identity is held by another device")
+
+type conflictKey struct{}
+
+// NoteConflicts returns a context under which a write refused with
+// ErrDeviceConflict also stores that error in conflict, for callers that only
+// see the error after others have reported it.
+func NoteConflicts(ctx context.Context, conflict *error) context.Context {
+	return context.WithValue(ctx, conflictKey{}, conflict)
+}
+
+// noteConflict stores err in the conflict noted by ctx if it is an
+// ErrDeviceConflict, and returns it.
+func noteConflict(ctx context.Context, err error) error {
+	if conflict, ok := ctx.Value(conflictKey{}).(*error); ok && errors.Is(err, ErrDeviceConflict) {
+		*conflict = err
+	}
+	return err
+}
+
+// EnforceDeviceIdentities installs a hook on client that keeps a
+// DeviceIdentity row for the serial number of every Device written through
+// it, and removes it when the Device is deleted. The rows'
+// unique index makes a write that would duplicate an identity fail with
+// ErrDeviceConflict, whichever code path or process performs it.
+func EnforceDeviceIdentities(client *ent.Client) {
+	client.Resource.Use(deviceIdentityHook)
+}
+
+func deviceIdentityHook(next ent.Mutator) ent.Mutator {
+	return hook.ResourceFunc(func(ctx context.Context, m *ent.ResourceMutation) (ent.Value, error) {
+		switch {
+		case m.Op().Is(ent.OpDelete | ent.OpDeleteOne):
+			ids, err := m.IDs(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve resources to delete: %w", err)
+			}
+			var uids []string
+			if len(ids) > 0 {
+				uids, err = m.Client().Resource.Query().
+					Where(entresource.IDIn(ids...), entresource.KindEQ("Device")).
+					Select(entresource.FieldUID).
+					Strings(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to load devices to delete: %w", err)
+				}
+			}
+
+			value, err := next.Mutate(ctx, m)
+			if err != nil || len(uids) == 0 {
+				return value, err
+			}
+			if _, err := m.Client().DeviceIdentity.Delete().Where(entidentity.DeviceUIDIn(uids...)).Exec(ctx); err != nil {
+				return value, fmt.Errorf("failed to release device identities: %w", err)
+			}
+			return value, nil
+
+		case m.Op().Is(ent.OpCreate | ent.OpUpdateOne):
+			spec, ok := m.Spec()
+			if !ok {
+				return next.Mutate(ctx, m)
+			}
+			kind, uid, oldSpec, err := mutatedResource(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			if kind != "Device" {
+				return next.Mutate(ctx, m)
+			}
+
+			// Claim first, so that outside a transaction a conflicting
+			// device is never written at all
+			if err := claimDeviceIdentities(ctx, m.Client(), uid, spec); err != nil {
+				return nil, noteConflict(ctx, err)
+			}
+			value, err := next.Mutate(ctx, m)
+			// Inside a transaction the rollback releases the claim, and
+			// PostgreSQL refuses further queries in a failed one anyway
+			if _, txErr := m.Tx(); err != nil && txErr != nil {
+				if restoreErr := claimDeviceIdentities(ctx, m.Client(), uid, oldSpec); restoreErr != nil {
+					slog.WarnContext(ctx, "Failed to restore device identities", "device_uid", uid, "error", restoreErr)
+				}
+			}
+			return value, err
+
+		case m.Op().Is(ent.OpUpdate):
+			if _, ok := m.Spec(); ok {
+				return nil, errors.New("updating the spec of several resources at once is not supported")
+			}
+		}
+		return next.Mutate(ctx, m)
+	})
+}
+
+// mutatedResource returns the kind and UID of the resource m creates or
+// updates, and the spec it had before.
+func mutatedResource(ctx context.Context, m *ent.ResourceMutation) (kind, uid string, oldSpec json.RawMessage, err error) {
+	if m.Op().Is(ent.OpCreate) {
+		kind, _ = m.Kind()
+		uid, _ = m.UID()
+		return kind, uid, nil, nil
+	}
+	if kind, err = m.OldKind(ctx); err != nil {
+		return "", "", nil, fmt.Errorf("failed to load resource to update: %w", err)
+	}
+	if uid, err = m.OldUID(ctx); err != nil {
+		return "", "", nil, fmt.Errorf("failed to load resource to update: %w", err)
+	}
+	if oldSpec, err = m.OldSpec(ctx); err != nil {
+		return "", "", nil, fmt.Errorf("failed to load resource to update: %w", err)
+	}
+	return kind, uid, oldSpec, nil
+}
+
+// deviceIdentities returns the identities named by a Device spec, by scheme.
+func deviceIdentities(spec json.RawMessage) map[string]string {
+	identities := make(map[string]string)
+	var device v1.DeviceSpec
+	if len(spec) == 0 || json.Unmarshal(spec, &device) != nil {
+		return identities
+	}
+	if device.SerialNumber != "" {
+		identities[IdentitySerial] = device.SerialNumber
+	}
+	return identities
+}
+
+// claimDeviceIdentities makes the identities of spec the only ones held by
+// the Device uid.
+func claimDeviceIdentities(ctx context.Context, client *ent.Client, uid string, spec json.RawMessage) error {
+	want := deviceIdentities(spec)
+	held, err := client.DeviceIdentity.Query().Where(entidentity.DeviceUIDEQ(uid)).All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load identities of Device %s: %w", uid, err)
+	}
+	for _, identity := range held {
+		if want[identity.Scheme] == identity.Value {
+			delete(want, identity.Scheme)
+			continue
+		}
+		if err := client.DeviceIdentity.DeleteOne(identity).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to release %s of Device %s: %w", identity.Scheme, uid, err)
+		}
+	}
+
+	for scheme, value := range want {
+		owner, err := client.DeviceIdentity.Query().
+			Where(entidentity.SchemeEQ(scheme), entidentity.ValueEQ(value)).
+			Only(ctx)
+		switch {
+		case err == nil:
+			return fmt.Errorf("%w: %s %q of Device %s belongs to Device %s", ErrDeviceConflict, scheme, value, uid, owner.DeviceUID)
+		case !ent.IsNotFound(err):
+			return fmt.Errorf("failed to look up %s %q: %w", scheme, value, err)
+		}
+
+		err = client.DeviceIdentity.Create().
+			SetScheme(scheme).
+			SetValue(value).
+			SetDeviceUID(uid).
+			Exec(ctx)
+		if ent.IsConstraintError(err) {
+			// Claimed by a concurrent writer since the look-up
+			return fmt.Errorf("%w: %s %q of Device %s was claimed concurrently", ErrDeviceConflict, scheme, value, uid)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to claim %s %q for Device %s: %w", scheme, value, uid, err)
+		}
+	}
+	return nil
+}
+
+// releaseStaleIdentities releases the identities held by the Devices in
+// specs, by UID, that their new specs no longer name. Writing a batch after
+// this lets its devices hand identities to each other, as when two swap
+// serial numbers, without either write conflicting with the other's old state.
+func releaseStaleIdentities(ctx context.Context, client *ent.Client, specs map[string]json.RawMessage) error {
+	if len(specs) == 0 {
+		return nil
+	}
+	uids := make([]string, 0, len(specs))
+	for uid := range specs {
+		uids = append(uids, uid)
+	}
+	held, err := client.DeviceIdentity.Query().Where(entidentity.DeviceUIDIn(uids...)).All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load device identities: %w", err)
+	}
+	for _, identity := range held {
+		if deviceIdentities(specs[identity.DeviceUID])[identity.Scheme] == identity.Value {
+			continue
+		}
+		if err := client.DeviceIdentity.DeleteOne(identity).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to release %s of Device %s: %w", identity.Scheme, identity.DeviceUID, err)
+		}
+	}
+	return nil
+}
+
+// ClaimExistingDeviceIdentities claims the identities of devices stored
+// before identities were enforced, and releases any of a scheme no longer
+// enforced. It claims nothing once any identity is recorded. Devices that
+// duplicate an identity already claimed are logged and left without it, as
+// there is no telling which one is right.
+func ClaimExistingDeviceIdentities(ctx context.Context) (int, error) {
+	if err := ensureBackendReady(); err != nil {
+		return 0, err
+	}
+	if _, err := entClient.DeviceIdentity.Delete().Where(entidentity.SchemeNEQ(IdentitySerial)).Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to release retired device identities: %w", err)
+	}
+	if exists, err := entClient.DeviceIdentity.Query().Exist(ctx); err != nil || exists {
+		return 0, err
+	}
+
+	devices, err := entClient.Resource.Query().
+		Where(entresource.KindEQ("Device")).
+		Order(ent.Asc(entresource.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load devices: %w", err)
+	}
+	claimed := 0
+	for _, device := range devices {
+		for scheme, value := range deviceIdentities(device.Spec) {
+			err := entClient.DeviceIdentity.Create().
+				SetScheme(scheme).
+				SetValue(value).
+				SetDeviceUID(device.UID).
+				Exec(ctx)
+			if ent.IsConstraintError(err) {
+				slog.WarnContext(ctx, "Device duplicates an identity of an older device; merge or delete it",
+					"device_uid", device.UID, "scheme", scheme, "value", value)
+				continue
+			}
+			if err != nil {
+				return claimed, fmt.Errorf("failed to claim %s %q for Device %s: %w", scheme, value, device.UID, err)
+			}
+			claimed++
+		}
+	}
+	return claimed, nil
+}
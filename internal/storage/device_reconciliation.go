@@ -8,31 +8,52 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"sort"
 	"time"
 
 	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
 	"github.com/example/fru-tracker/internal/storage/ent"
+	entidentity "github.com/example/fru-tracker/internal/storage/ent/deviceidentity"
 	entresource "github.com/example/fru-tracker/internal/storage/ent/resource"
+	"github.com/example/fru-tracker/internal/tracing"
 	"github.com/openchami/fabrica/pkg/resource"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// LoadDevicesByIdentifiers loads Device resources whose names match any of the provided identifiers.
-// The reconciler uses this to prefetch only the records that matter for a snapshot.
-func LoadDevicesByIdentifiers(ctx context.Context, identifiers []string) ([]*v1.Device, error) {
+// LoadDevicesByIdentifiers loads Device resources whose names or serial numbers match any of the
+// provided identifiers. The reconciler uses this to prefetch only the records that matter
+// for a snapshot.
+func LoadDevicesByIdentifiers(ctx context.Context, identifiers []string) (_ []*v1.Device, err error) {
+	ctx, span := tracing.Start(ctx, "storage.LoadDevicesByIdentifiers", trace.SpanKindInternal)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
 	if err := ensureBackendReady(); err != nil {
 		return nil, err
 	}
 
 	lookup := uniqueStrings(identifiers)
+	span.SetAttributes(attribute.Int("fru_tracker.identifiers", len(lookup)))
 	if len(lookup) == 0 {
 		return nil, nil
 	}
 
+	// Devices are named after an identity, but a rename must not hide one
+	claimed, err := entClient.DeviceIdentity.Query().
+		Where(entidentity.SchemeEQ(IdentitySerial), entidentity.ValueIn(lookup...)).
+		Select(entidentity.FieldDeviceUID).
+		Strings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up device identities: %w", err)
+	}
+
 	entResources, err := entClient.Resource.Query().
 		Where(
 			entresource.KindEQ("Device"),
-			entresource.NameIn(lookup...),
+			entresource.Or(entresource.NameIn(lookup...), entresource.UIDIn(claimed...)),
 		).
 		WithLabels().
 		WithAnnotations().
@@ -45,21 +66,44 @@ func LoadDevicesByIdentifiers(ctx context.Context, identifiers []string) ([]*v1.
 	for _, entResource := range entResources {
 		fabricaResource, err := FromEntResource(ctx, entResource)
 		if err != nil {
+			slog.WarnContext(ctx, "Skipping undecodable device", "device_uid", entResource.UID, "error", err)
 			continue
 		}
 		devices = append(devices, fabricaResource.(*v1.Device))
 	}
 
+	slog.DebugContext(ctx, "Loaded devices by identifier", "identifiers", len(lookup), "devices", len(devices))
 	return devices, nil
 }
 
 // SaveDevicesBulk upserts a set of Device resources in a single transaction.
-func SaveDevicesBulk(ctx context.Context, devices []*v1.Device) error {
+func SaveDevicesBulk(ctx context.Context, devices []*v1.Device) (err error) {
+	ctx, span := tracing.Start(ctx, "storage.SaveDevicesBulk", trace.SpanKindInternal)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+	span.SetAttributes(attribute.Int("fru_tracker.devices", len(devices)))
 	if err := ensureBackendReady(); err != nil {
 		return err
 	}
 
 	return WithTx(ctx, func(tx *ent.Tx) error {
+		specs := make(map[string]json.RawMessage, len(devices))
+		for _, device := range devices {
+			if device == nil || device.Metadata.UID == "" {
+				continue
+			}
+			spec, err := json.Marshal(device.Spec)
+			if err != nil {
+				return fmt.Errorf("failed to marshal Device spec: %w", err)
+			}
+			specs[device.Metadata.UID] = spec
+		}
+		if err := releaseStaleIdentities(ctx, tx.Client(), specs); err != nil {
+			return err
+		}
+
 		for _, device := range devices {
 			if device == nil {
 				continue
@@ -121,6 +165,7 @@ func SaveDevicesBulk(ctx context.Context, devices []*v1.Device) error {
 				if _, err := builder.Save(ctx); err != nil {
 					return fmt.Errorf("failed to create Device %s: %w", device.Metadata.UID, err)
 				}
+				slog.DebugContext(ctx, "Created device", "device_uid", device.Metadata.UID, "device", device.Metadata.Name)
 				continue
 			}
 
@@ -136,6 +181,7 @@ func SaveDevicesBulk(ctx context.Context, devices []*v1.Device) error {
 			if _, err := builder.Save(ctx); err != nil {
 				return fmt.Errorf("failed to update Device %s: %w", device.Metadata.UID, err)
 			}
+			slog.DebugContext(ctx, "Updated device", "device_uid", device.Metadata.UID, "device", device.Metadata.Name)
 		}
 		return nil
 	})
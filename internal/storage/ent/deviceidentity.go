@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/example/fru-tracker/internal/storage/ent/deviceidentity"
+)
+
+// DeviceIdentity is the model entity for the DeviceIdentity schema.
+type DeviceIdentity struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// What the value is: serial
+	Scheme string `json:"scheme,omitempty"`
+	// The serial number
+	Value string `json:"value,omitempty"`
+	// UID of the Device holding the identity
+	DeviceUID    string `json:"device_uid,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*DeviceIdentity) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case deviceidentity.FieldID:
+			values[i] = new(sql.NullInt64)
+		case deviceidentity.FieldScheme, deviceidentity.FieldValue, deviceidentity.FieldDeviceUID:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the DeviceIdentity fields.
+func (_m *DeviceIdentity) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case deviceidentity.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case deviceidentity.FieldScheme:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field scheme", values[i])
+			} else if value.Valid {
+				_m.Scheme = value.String
+			}
+		case deviceidentity.FieldValue:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field value", values[i])
+			} else if value.Valid {
+				_m.Value = value.String
+			}
+		case deviceidentity.FieldDeviceUID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field device_uid", values[i])
+			} else if value.Valid {
+				_m.DeviceUID = value.String
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// GetValue returns the ent.Value that was dynamically selected and assigned to the DeviceIdentity.
+// This includes values selected through modifiers, order, etc.
+func (_m *DeviceIdentity) GetValue(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this DeviceIdentity.
+// Note that you need to call DeviceIdentity.Unwrap() before calling this method if this DeviceIdentity
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *DeviceIdentity) Update() *DeviceIdentityUpdateOne {
+	return NewDeviceIdentityClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the DeviceIdentity entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *DeviceIdentity) Unwrap() *DeviceIdentity {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: DeviceIdentity is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *DeviceIdentity) String() string {
+	var builder strings.Builder
+	builder.WriteString("DeviceIdentity(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("scheme=")
+	builder.WriteString(_m.Scheme)
+	builder.WriteString(", ")
+	builder.WriteString("value=")
+	builder.WriteString(_m.Value)
+	builder.WriteString(", ")
+	builder.WriteString("device_uid=")
+	builder.WriteString(_m.DeviceUID)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// DeviceIdentities is a parsable slice of DeviceIdentity.
+type DeviceIdentities []*DeviceIdentity
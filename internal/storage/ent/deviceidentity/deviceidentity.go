@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Code generated by ent, DO NOT EDIT.
+
+package deviceidentity
+
+import (
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the deviceidentity type in the database.
+	Label = "device_identity"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldScheme holds the string denoting the scheme field in the database.
+	FieldScheme = "scheme"
+	// FieldValue holds the string denoting the value field in the database.
+	FieldValue = "value"
+	// FieldDeviceUID holds the string denoting the device_uid field in the database.
+	FieldDeviceUID = "device_uid"
+	// Table holds the table name of the deviceidentity in the database.
+	Table = "device_identities"
+)
+
+// Columns holds all SQL columns for deviceidentity fields.
+var Columns = []string{
+	FieldID,
+	FieldScheme,
+	FieldValue,
+	FieldDeviceUID,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// SchemeValidator is a validator for the "scheme" field. It is called by the builders before save.
+	SchemeValidator func(string) error
+	// ValueValidator is a validator for the "value" field. It is called by the builders before save.
+	ValueValidator func(string) error
+	// DeviceUIDValidator is a validator for the "device_uid" field. It is called by the builders before save.
+	DeviceUIDValidator func(string) error
+)
+
+// OrderOption defines the ordering options for the DeviceIdentity queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByScheme orders the results by the scheme field.
+func ByScheme(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldScheme, opts...).ToFunc()
+}
+
+// ByValue orders the results by the value field.
+func ByValue(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldValue, opts...).ToFunc()
+}
+
+// ByDeviceUID orders the results by the device_uid field.
+func ByDeviceUID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDeviceUID, opts...).ToFunc()
+}
@@ -0,0 +1,282 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Code generated by ent, DO NOT EDIT.
+
+package deviceidentity
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"github.com/example/fru-tracker/internal/storage/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldLTE(FieldID, id))
+}
+
+// Scheme applies equality check predicate on the "scheme" field. It's identical to SchemeEQ.
+func Scheme(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldEQ(FieldScheme, v))
+}
+
+// Value applies equality check predicate on the "value" field. It's identical to ValueEQ.
+func Value(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldEQ(FieldValue, v))
+}
+
+// DeviceUID applies equality check predicate on the "device_uid" field. It's identical to DeviceUIDEQ.
+func DeviceUID(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldEQ(FieldDeviceUID, v))
+}
+
+// SchemeEQ applies the EQ predicate on the "scheme" field.
+func SchemeEQ(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldEQ(FieldScheme, v))
+}
+
+// SchemeNEQ applies the NEQ predicate on the "scheme" field.
+func SchemeNEQ(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldNEQ(FieldScheme, v))
+}
+
+// SchemeIn applies the In predicate on the "scheme" field.
+func SchemeIn(vs ...string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldIn(FieldScheme, vs...))
+}
+
+// SchemeNotIn applies the NotIn predicate on the "scheme" field.
+func SchemeNotIn(vs ...string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldNotIn(FieldScheme, vs...))
+}
+
+// SchemeGT applies the GT predicate on the "scheme" field.
+func SchemeGT(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldGT(FieldScheme, v))
+}
+
+// SchemeGTE applies the GTE predicate on the "scheme" field.
+func SchemeGTE(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldGTE(FieldScheme, v))
+}
+
+// SchemeLT applies the LT predicate on the "scheme" field.
+func SchemeLT(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldLT(FieldScheme, v))
+}
+
+// SchemeLTE applies the LTE predicate on the "scheme" field.
+func SchemeLTE(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldLTE(FieldScheme, v))
+}
+
+// SchemeContains applies the Contains predicate on the "scheme" field.
+func SchemeContains(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldContains(FieldScheme, v))
+}
+
+// SchemeHasPrefix applies the HasPrefix predicate on the "scheme" field.
+func SchemeHasPrefix(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldHasPrefix(FieldScheme, v))
+}
+
+// SchemeHasSuffix applies the HasSuffix predicate on the "scheme" field.
+func SchemeHasSuffix(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldHasSuffix(FieldScheme, v))
+}
+
+// SchemeEqualFold applies the EqualFold predicate on the "scheme" field.
+func SchemeEqualFold(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldEqualFold(FieldScheme, v))
+}
+
+// SchemeContainsFold applies the ContainsFold predicate on the "scheme" field.
+func SchemeContainsFold(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldContainsFold(FieldScheme, v))
+}
+
+// ValueEQ applies the EQ predicate on the "value" field.
+func ValueEQ(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldEQ(FieldValue, v))
+}
+
+// ValueNEQ applies the NEQ predicate on the "value" field.
+func ValueNEQ(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldNEQ(FieldValue, v))
+}
+
+// ValueIn applies the In predicate on the "value" field.
+func ValueIn(vs ...string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldIn(FieldValue, vs...))
+}
+
+// ValueNotIn applies the NotIn predicate on the "value" field.
+func ValueNotIn(vs ...string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldNotIn(FieldValue, vs...))
+}
+
+// ValueGT applies the GT predicate on the "value" field.
+func ValueGT(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldGT(FieldValue, v))
+}
+
+// ValueGTE applies the GTE predicate on the "value" field.
+func ValueGTE(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldGTE(FieldValue, v))
+}
+
+// ValueLT applies the LT predicate on the "value" field.
+func ValueLT(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldLT(FieldValue, v))
+}
+
+// ValueLTE applies the LTE predicate on the "value" field.
+func ValueLTE(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldLTE(FieldValue, v))
+}
+
+// ValueContains applies the Contains predicate on the "value" field.
+func ValueContains(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldContains(FieldValue, v))
+}
+
+// ValueHasPrefix applies the HasPrefix predicate on the "value" field.
+func ValueHasPrefix(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldHasPrefix(FieldValue, v))
+}
+
+// ValueHasSuffix applies the HasSuffix predicate on the "value" field.
+func ValueHasSuffix(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldHasSuffix(FieldValue, v))
+}
+
+// ValueEqualFold applies the EqualFold predicate on the "value" field.
+func ValueEqualFold(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldEqualFold(FieldValue, v))
+}
+
+// ValueContainsFold applies the ContainsFold predicate on the "value" field.
+func ValueContainsFold(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldContainsFold(FieldValue, v))
+}
+
+// DeviceUIDEQ applies the EQ predicate on the "device_uid" field.
+func DeviceUIDEQ(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldEQ(FieldDeviceUID, v))
+}
+
+// DeviceUIDNEQ applies the NEQ predicate on the "device_uid" field.
+func DeviceUIDNEQ(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldNEQ(FieldDeviceUID, v))
+}
+
+// DeviceUIDIn applies the In predicate on the "device_uid" field.
+func DeviceUIDIn(vs ...string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldIn(FieldDeviceUID, vs...))
+}
+
+// DeviceUIDNotIn applies the NotIn predicate on the "device_uid" field.
+func DeviceUIDNotIn(vs ...string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldNotIn(FieldDeviceUID, vs...))
+}
+
+// DeviceUIDGT applies the GT predicate on the "device_uid" field.
+func DeviceUIDGT(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldGT(FieldDeviceUID, v))
+}
+
+// DeviceUIDGTE applies the GTE predicate on the "device_uid" field.
+func DeviceUIDGTE(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldGTE(FieldDeviceUID, v))
+}
+
+// DeviceUIDLT applies the LT predicate on the "device_uid" field.
+func DeviceUIDLT(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldLT(FieldDeviceUID, v))
+}
+
+// DeviceUIDLTE applies the LTE predicate on the "device_uid" field.
+func DeviceUIDLTE(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldLTE(FieldDeviceUID, v))
+}
+
+// DeviceUIDContains applies the Contains predicate on the "device_uid" field.
+func DeviceUIDContains(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldContains(FieldDeviceUID, v))
+}
+
+// DeviceUIDHasPrefix applies the HasPrefix predicate on the "device_uid" field.
+func DeviceUIDHasPrefix(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldHasPrefix(FieldDeviceUID, v))
+}
+
+// DeviceUIDHasSuffix applies the HasSuffix predicate on the "device_uid" field.
+func DeviceUIDHasSuffix(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldHasSuffix(FieldDeviceUID, v))
+}
+
+// DeviceUIDEqualFold applies the EqualFold predicate on the "device_uid" field.
+func DeviceUIDEqualFold(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldEqualFold(FieldDeviceUID, v))
+}
+
+// DeviceUIDContainsFold applies the ContainsFold predicate on the "device_uid" field.
+func DeviceUIDContainsFold(v string) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.FieldContainsFold(FieldDeviceUID, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.DeviceIdentity) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.DeviceIdentity) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.DeviceIdentity) predicate.DeviceIdentity {
+	return predicate.DeviceIdentity(sql.NotPredicates(p))
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/example/fru-tracker/internal/storage/ent/deviceidentity"
+)
+
+// DeviceIdentityCreate is the builder for creating a DeviceIdentity entity.
+type DeviceIdentityCreate struct {
+	config
+	mutation *DeviceIdentityMutation
+	hooks    []Hook
+}
+
+// SetScheme sets the "scheme" field.
+func (_c *DeviceIdentityCreate) SetScheme(v string) *DeviceIdentityCreate {
+	_c.mutation.SetScheme(v)
+	return _c
+}
+
+// SetValue sets the "value" field.
+func (_c *DeviceIdentityCreate) SetValue(v string) *DeviceIdentityCreate {
+	_c.mutation.SetValue(v)
+	return _c
+}
+
+// SetDeviceUID sets the "device_uid" field.
+func (_c *DeviceIdentityCreate) SetDeviceUID(v string) *DeviceIdentityCreate {
+	_c.mutation.SetDeviceUID(v)
+	return _c
+}
+
+// Mutation returns the DeviceIdentityMutation object of the builder.
+func (_c *DeviceIdentityCreate) Mutation() *DeviceIdentityMutation {
+	return _c.mutation
+}
+
+// Save creates the DeviceIdentity in the database.
+func (_c *DeviceIdentityCreate) Save(ctx context.Context) (*DeviceIdentity, error) {
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *DeviceIdentityCreate) SaveX(ctx context.Context) *DeviceIdentity {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *DeviceIdentityCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *DeviceIdentityCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *DeviceIdentityCreate) check() error {
+	if _, ok := _c.mutation.Scheme(); !ok {
+		return &ValidationError{Name: "scheme", err: errors.New(`ent: missing required field "DeviceIdentity.scheme"`)}
+	}
+	if v, ok := _c.mutation.Scheme(); ok {
+		if err := deviceidentity.SchemeValidator(v); err != nil {
+			return &ValidationError{Name: "scheme", err: fmt.Errorf(`ent: validator failed for field "DeviceIdentity.scheme": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Value(); !ok {
+		return &ValidationError{Name: "value", err: errors.New(`ent: missing required field "DeviceIdentity.value"`)}
+	}
+	if v, ok := _c.mutation.Value(); ok {
+		if err := deviceidentity.ValueValidator(v); err != nil {
+			return &ValidationError{Name: "value", err: fmt.Errorf(`ent: validator failed for field "DeviceIdentity.value": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.DeviceUID(); !ok {
+		return &ValidationError{Name: "device_uid", err: errors.New(`ent: missing required field "DeviceIdentity.device_uid"`)}
+	}
+	if v, ok := _c.mutation.DeviceUID(); ok {
+		if err := deviceidentity.DeviceUIDValidator(v); err != nil {
+			return &ValidationError{Name: "device_uid", err: fmt.Errorf(`ent: validator failed for field "DeviceIdentity.device_uid": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_c *DeviceIdentityCreate) sqlSave(ctx context.Context) (*DeviceIdentity, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *DeviceIdentityCreate) createSpec() (*DeviceIdentity, *sqlgraph.CreateSpec) {
+	var (
+		_node = &DeviceIdentity{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(deviceidentity.Table, sqlgraph.NewFieldSpec(deviceidentity.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Scheme(); ok {
+		_spec.SetField(deviceidentity.FieldScheme, field.TypeString, value)
+		_node.Scheme = value
+	}
+	if value, ok := _c.mutation.Value(); ok {
+		_spec.SetField(deviceidentity.FieldValue, field.TypeString, value)
+		_node.Value = value
+	}
+	if value, ok := _c.mutation.DeviceUID(); ok {
+		_spec.SetField(deviceidentity.FieldDeviceUID, field.TypeString, value)
+		_node.DeviceUID = value
+	}
+	return _node, _spec
+}
+
+// DeviceIdentityCreateBulk is the builder for creating many DeviceIdentity entities in bulk.
+type DeviceIdentityCreateBulk struct {
+	config
+	err      error
+	builders []*DeviceIdentityCreate
+}
+
+// Save creates the DeviceIdentity entities in the database.
+func (_c *DeviceIdentityCreateBulk) Save(ctx context.Context) ([]*DeviceIdentity, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*DeviceIdentity, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*DeviceIdentityMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *DeviceIdentityCreateBulk) SaveX(ctx context.Context) []*DeviceIdentity {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *DeviceIdentityCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *DeviceIdentityCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
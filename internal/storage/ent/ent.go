@@ -17,8 +17,11 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/example/fru-tracker/internal/storage/ent/annotation"
+	"github.com/example/fru-tracker/internal/storage/ent/deviceidentity"
 	"github.com/example/fru-tracker/internal/storage/ent/label"
+	"github.com/example/fru-tracker/internal/storage/ent/outboxevent"
 	"github.com/example/fru-tracker/internal/storage/ent/resource"
+	"github.com/example/fru-tracker/internal/storage/ent/tombstone"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -79,9 +82,12 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			annotation.Table: annotation.ValidColumn,
-			label.Table:      label.ValidColumn,
-			resource.Table:   resource.ValidColumn,
+			annotation.Table:     annotation.ValidColumn,
+			deviceidentity.Table: deviceidentity.ValidColumn,
+			label.Table:          label.ValidColumn,
+			outboxevent.Table:    outboxevent.ValidColumn,
+			resource.Table:       resource.ValidColumn,
+			tombstone.Table:      tombstone.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
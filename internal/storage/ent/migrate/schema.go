@@ -40,6 +40,36 @@ var (
 			},
 		},
 	}
+	// DeviceIdentitiesColumns holds the columns for the "device_identities" table.
+	DeviceIdentitiesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "scheme", Type: field.TypeString},
+		{Name: "value", Type: field.TypeString},
+		{Name: "device_uid", Type: field.TypeString},
+	}
+	// DeviceIdentitiesTable holds the schema information for the "device_identities" table.
+	DeviceIdentitiesTable = &schema.Table{
+		Name:       "device_identities",
+		Columns:    DeviceIdentitiesColumns,
+		PrimaryKey: []*schema.Column{DeviceIdentitiesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "deviceidentity_scheme_value",
+				Unique:  true,
+				Columns: []*schema.Column{DeviceIdentitiesColumns[1], DeviceIdentitiesColumns[2]},
+			},
+			{
+				Name:    "deviceidentity_device_uid",
+				Unique:  false,
+				Columns: []*schema.Column{DeviceIdentitiesColumns[3]},
+			},
+			{
+				Name:    "deviceidentity_value",
+				Unique:  false,
+				Columns: []*schema.Column{DeviceIdentitiesColumns[2]},
+			},
+		},
+	}
 	// LabelsColumns holds the columns for the "labels" table.
 	LabelsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
@@ -68,6 +98,28 @@ var (
 			},
 		},
 	}
+	// OutboxEventsColumns holds the columns for the "outbox_events" table.
+	OutboxEventsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "event_id", Type: field.TypeString},
+		{Name: "type", Type: field.TypeString},
+		{Name: "payload", Type: field.TypeJSON, SchemaType: map[string]string{"postgres": "json"}},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "delivered_at", Type: field.TypeTime, Nullable: true},
+	}
+	// OutboxEventsTable holds the schema information for the "outbox_events" table.
+	OutboxEventsTable = &schema.Table{
+		Name:       "outbox_events",
+		Columns:    OutboxEventsColumns,
+		PrimaryKey: []*schema.Column{OutboxEventsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "outboxevent_delivered_at",
+				Unique:  false,
+				Columns: []*schema.Column{OutboxEventsColumns[5]},
+			},
+		},
+	}
 	// ResourcesColumns holds the columns for the "resources" table.
 	ResourcesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
@@ -76,8 +128,8 @@ var (
 		{Name: "api_version", Type: field.TypeString, Default: "v1"},
 		{Name: "kind", Type: field.TypeString},
 		{Name: "resource_type", Type: field.TypeString},
-		{Name: "spec", Type: field.TypeJSON},
-		{Name: "status", Type: field.TypeJSON, Nullable: true},
+		{Name: "spec", Type: field.TypeJSON, SchemaType: map[string]string{"postgres": "json"}},
+		{Name: "status", Type: field.TypeJSON, Nullable: true, SchemaType: map[string]string{"postgres": "json"}},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 		{Name: "resource_version", Type: field.TypeString, Default: "1"},
@@ -111,11 +163,41 @@ var (
 			},
 		},
 	}
+	// TombstonesColumns holds the columns for the "tombstones" table.
+	TombstonesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "uid", Type: field.TypeString},
+		{Name: "name", Type: field.TypeString},
+		{Name: "kind", Type: field.TypeString},
+		{Name: "spec", Type: field.TypeJSON, Nullable: true, SchemaType: map[string]string{"postgres": "json"}},
+		{Name: "deleted_at", Type: field.TypeTime},
+	}
+	// TombstonesTable holds the schema information for the "tombstones" table.
+	TombstonesTable = &schema.Table{
+		Name:       "tombstones",
+		Columns:    TombstonesColumns,
+		PrimaryKey: []*schema.Column{TombstonesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "tombstone_kind_deleted_at",
+				Unique:  false,
+				Columns: []*schema.Column{TombstonesColumns[3], TombstonesColumns[5]},
+			},
+			{
+				Name:    "tombstone_uid",
+				Unique:  false,
+				Columns: []*schema.Column{TombstonesColumns[1]},
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		AnnotationsTable,
+		DeviceIdentitiesTable,
 		LabelsTable,
+		OutboxEventsTable,
 		ResourcesTable,
+		TombstonesTable,
 	}
 )
 
@@ -17,9 +17,12 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"github.com/example/fru-tracker/internal/storage/ent/annotation"
+	"github.com/example/fru-tracker/internal/storage/ent/deviceidentity"
 	"github.com/example/fru-tracker/internal/storage/ent/label"
+	"github.com/example/fru-tracker/internal/storage/ent/outboxevent"
 	"github.com/example/fru-tracker/internal/storage/ent/predicate"
 	"github.com/example/fru-tracker/internal/storage/ent/resource"
+	"github.com/example/fru-tracker/internal/storage/ent/tombstone"
 )
 
 const (
@@ -31,9 +34,12 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypeAnnotation = "Annotation"
-	TypeLabel      = "Label"
-	TypeResource   = "Resource"
+	TypeAnnotation     = "Annotation"
+	TypeDeviceIdentity = "DeviceIdentity"
+	TypeLabel          = "Label"
+	TypeOutboxEvent    = "OutboxEvent"
+	TypeResource       = "Resource"
+	TypeTombstone      = "Tombstone"
 )
 
 // AnnotationMutation represents an operation that mutates the Annotation nodes in the graph.
@@ -483,33 +489,32 @@ func (m *AnnotationMutation) ResetEdge(name string) error {
 	return fmt.Errorf("unknown Annotation edge %s", name)
 }
 
-// LabelMutation represents an operation that mutates the Label nodes in the graph.
-type LabelMutation struct {
+// DeviceIdentityMutation represents an operation that mutates the DeviceIdentity nodes in the graph.
+type DeviceIdentityMutation struct {
 	config
-	op              Op
-	typ             string
-	id              *int
-	key             *string
-	value           *string
-	clearedFields   map[string]struct{}
-	resource        *int
-	clearedresource bool
-	done            bool
-	oldValue        func(context.Context) (*Label, error)
-	predicates      []predicate.Label
-}
-
-var _ ent.Mutation = (*LabelMutation)(nil)
-
-// labelOption allows management of the mutation configuration using functional options.
-type labelOption func(*LabelMutation)
-
-// newLabelMutation creates new mutation for the Label entity.
-func newLabelMutation(c config, op Op, opts ...labelOption) *LabelMutation {
-	m := &LabelMutation{
+	op            Op
+	typ           string
+	id            *int
+	scheme        *string
+	value         *string
+	device_uid    *string
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*DeviceIdentity, error)
+	predicates    []predicate.DeviceIdentity
+}
+
+var _ ent.Mutation = (*DeviceIdentityMutation)(nil)
+
+// deviceidentityOption allows management of the mutation configuration using functional options.
+type deviceidentityOption func(*DeviceIdentityMutation)
+
+// newDeviceIdentityMutation creates new mutation for the DeviceIdentity entity.
+func newDeviceIdentityMutation(c config, op Op, opts ...deviceidentityOption) *DeviceIdentityMutation {
+	m := &DeviceIdentityMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeLabel,
+		typ:           TypeDeviceIdentity,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -518,20 +523,20 @@ func newLabelMutation(c config, op Op, opts ...labelOption) *LabelMutation {
 	return m
 }
 
-// withLabelID sets the ID field of the mutation.
-func withLabelID(id int) labelOption {
-	return func(m *LabelMutation) {
+// withDeviceIdentityID sets the ID field of the mutation.
+func withDeviceIdentityID(id int) deviceidentityOption {
+	return func(m *DeviceIdentityMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Label
+			value *DeviceIdentity
 		)
-		m.oldValue = func(ctx context.Context) (*Label, error) {
+		m.oldValue = func(ctx context.Context) (*DeviceIdentity, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Label.Get(ctx, id)
+					value, err = m.Client().DeviceIdentity.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -540,10 +545,10 @@ func withLabelID(id int) labelOption {
 	}
 }
 
-// withLabel sets the old Label of the mutation.
-func withLabel(node *Label) labelOption {
-	return func(m *LabelMutation) {
-		m.oldValue = func(context.Context) (*Label, error) {
+// withDeviceIdentity sets the old DeviceIdentity of the mutation.
+func withDeviceIdentity(node *DeviceIdentity) deviceidentityOption {
+	return func(m *DeviceIdentityMutation) {
+		m.oldValue = func(context.Context) (*DeviceIdentity, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -552,7 +557,7 @@ func withLabel(node *Label) labelOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m LabelMutation) Client() *Client {
+func (m DeviceIdentityMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -560,7 +565,7 @@ func (m LabelMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m LabelMutation) Tx() (*Tx, error) {
+func (m DeviceIdentityMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -571,7 +576,7 @@ func (m LabelMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *LabelMutation) ID() (id int, exists bool) {
+func (m *DeviceIdentityMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -582,7 +587,7 @@ func (m *LabelMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *LabelMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *DeviceIdentityMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -591,55 +596,55 @@ func (m *LabelMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Label.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().DeviceIdentity.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetKey sets the "key" field.
-func (m *LabelMutation) SetKey(s string) {
-	m.key = &s
+// SetScheme sets the "scheme" field.
+func (m *DeviceIdentityMutation) SetScheme(s string) {
+	m.scheme = &s
 }
 
-// Key returns the value of the "key" field in the mutation.
-func (m *LabelMutation) Key() (r string, exists bool) {
-	v := m.key
+// Scheme returns the value of the "scheme" field in the mutation.
+func (m *DeviceIdentityMutation) Scheme() (r string, exists bool) {
+	v := m.scheme
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldKey returns the old "key" field's value of the Label entity.
-// If the Label object wasn't provided to the builder, the object is fetched from the database.
+// OldScheme returns the old "scheme" field's value of the DeviceIdentity entity.
+// If the DeviceIdentity object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *LabelMutation) OldKey(ctx context.Context) (v string, err error) {
+func (m *DeviceIdentityMutation) OldScheme(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldKey is only allowed on UpdateOne operations")
+		return v, errors.New("OldScheme is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldKey requires an ID field in the mutation")
+		return v, errors.New("OldScheme requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldKey: %w", err)
+		return v, fmt.Errorf("querying old value for OldScheme: %w", err)
 	}
-	return oldValue.Key, nil
+	return oldValue.Scheme, nil
 }
 
-// ResetKey resets all changes to the "key" field.
-func (m *LabelMutation) ResetKey() {
-	m.key = nil
+// ResetScheme resets all changes to the "scheme" field.
+func (m *DeviceIdentityMutation) ResetScheme() {
+	m.scheme = nil
 }
 
 // SetValue sets the "value" field.
-func (m *LabelMutation) SetValue(s string) {
+func (m *DeviceIdentityMutation) SetValue(s string) {
 	m.value = &s
 }
 
 // Value returns the value of the "value" field in the mutation.
-func (m *LabelMutation) Value() (r string, exists bool) {
+func (m *DeviceIdentityMutation) Value() (r string, exists bool) {
 	v := m.value
 	if v == nil {
 		return
@@ -647,10 +652,10 @@ func (m *LabelMutation) Value() (r string, exists bool) {
 	return *v, true
 }
 
-// OldValue returns the old "value" field's value of the Label entity.
-// If the Label object wasn't provided to the builder, the object is fetched from the database.
+// OldValue returns the old "value" field's value of the DeviceIdentity entity.
+// If the DeviceIdentity object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *LabelMutation) OldValue(ctx context.Context) (v string, err error) {
+func (m *DeviceIdentityMutation) OldValue(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldValue is only allowed on UpdateOne operations")
 	}
@@ -665,58 +670,55 @@ func (m *LabelMutation) OldValue(ctx context.Context) (v string, err error) {
 }
 
 // ResetValue resets all changes to the "value" field.
-func (m *LabelMutation) ResetValue() {
+func (m *DeviceIdentityMutation) ResetValue() {
 	m.value = nil
 }
 
-// SetResourceID sets the "resource" edge to the Resource entity by id.
-func (m *LabelMutation) SetResourceID(id int) {
-	m.resource = &id
-}
-
-// ClearResource clears the "resource" edge to the Resource entity.
-func (m *LabelMutation) ClearResource() {
-	m.clearedresource = true
-}
-
-// ResourceCleared reports if the "resource" edge to the Resource entity was cleared.
-func (m *LabelMutation) ResourceCleared() bool {
-	return m.clearedresource
+// SetDeviceUID sets the "device_uid" field.
+func (m *DeviceIdentityMutation) SetDeviceUID(s string) {
+	m.device_uid = &s
 }
 
-// ResourceID returns the "resource" edge ID in the mutation.
-func (m *LabelMutation) ResourceID() (id int, exists bool) {
-	if m.resource != nil {
-		return *m.resource, true
+// DeviceUID returns the value of the "device_uid" field in the mutation.
+func (m *DeviceIdentityMutation) DeviceUID() (r string, exists bool) {
+	v := m.device_uid
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// ResourceIDs returns the "resource" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ResourceID instead. It exists only for internal usage by the builders.
-func (m *LabelMutation) ResourceIDs() (ids []int) {
-	if id := m.resource; id != nil {
-		ids = append(ids, *id)
+// OldDeviceUID returns the old "device_uid" field's value of the DeviceIdentity entity.
+// If the DeviceIdentity object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *DeviceIdentityMutation) OldDeviceUID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeviceUID is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeviceUID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeviceUID: %w", err)
+	}
+	return oldValue.DeviceUID, nil
 }
 
-// ResetResource resets all changes to the "resource" edge.
-func (m *LabelMutation) ResetResource() {
-	m.resource = nil
-	m.clearedresource = false
+// ResetDeviceUID resets all changes to the "device_uid" field.
+func (m *DeviceIdentityMutation) ResetDeviceUID() {
+	m.device_uid = nil
 }
 
-// Where appends a list predicates to the LabelMutation builder.
-func (m *LabelMutation) Where(ps ...predicate.Label) {
+// Where appends a list predicates to the DeviceIdentityMutation builder.
+func (m *DeviceIdentityMutation) Where(ps ...predicate.DeviceIdentity) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the LabelMutation builder. Using this method,
+// WhereP appends storage-level predicates to the DeviceIdentityMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *LabelMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Label, len(ps))
+func (m *DeviceIdentityMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.DeviceIdentity, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -724,30 +726,33 @@ func (m *LabelMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *LabelMutation) Op() Op {
+func (m *DeviceIdentityMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *LabelMutation) SetOp(op Op) {
+func (m *DeviceIdentityMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Label).
-func (m *LabelMutation) Type() string {
+// Type returns the node type of this mutation (DeviceIdentity).
+func (m *DeviceIdentityMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *LabelMutation) Fields() []string {
-	fields := make([]string, 0, 2)
-	if m.key != nil {
-		fields = append(fields, label.FieldKey)
+func (m *DeviceIdentityMutation) Fields() []string {
+	fields := make([]string, 0, 3)
+	if m.scheme != nil {
+		fields = append(fields, deviceidentity.FieldScheme)
 	}
 	if m.value != nil {
-		fields = append(fields, label.FieldValue)
+		fields = append(fields, deviceidentity.FieldValue)
+	}
+	if m.device_uid != nil {
+		fields = append(fields, deviceidentity.FieldDeviceUID)
 	}
 	return fields
 }
@@ -755,12 +760,14 @@ func (m *LabelMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *LabelMutation) Field(name string) (ent.Value, bool) {
+func (m *DeviceIdentityMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case label.FieldKey:
-		return m.Key()
-	case label.FieldValue:
+	case deviceidentity.FieldScheme:
+		return m.Scheme()
+	case deviceidentity.FieldValue:
 		return m.Value()
+	case deviceidentity.FieldDeviceUID:
+		return m.DeviceUID()
 	}
 	return nil, false
 }
@@ -768,210 +775,181 @@ func (m *LabelMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *LabelMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *DeviceIdentityMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case label.FieldKey:
-		return m.OldKey(ctx)
-	case label.FieldValue:
+	case deviceidentity.FieldScheme:
+		return m.OldScheme(ctx)
+	case deviceidentity.FieldValue:
 		return m.OldValue(ctx)
+	case deviceidentity.FieldDeviceUID:
+		return m.OldDeviceUID(ctx)
 	}
-	return nil, fmt.Errorf("unknown Label field %s", name)
+	return nil, fmt.Errorf("unknown DeviceIdentity field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *LabelMutation) SetField(name string, value ent.Value) error {
+func (m *DeviceIdentityMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case label.FieldKey:
+	case deviceidentity.FieldScheme:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetKey(v)
+		m.SetScheme(v)
 		return nil
-	case label.FieldValue:
+	case deviceidentity.FieldValue:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetValue(v)
 		return nil
+	case deviceidentity.FieldDeviceUID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeviceUID(v)
+		return nil
 	}
-	return fmt.Errorf("unknown Label field %s", name)
+	return fmt.Errorf("unknown DeviceIdentity field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *LabelMutation) AddedFields() []string {
+func (m *DeviceIdentityMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *LabelMutation) AddedField(name string) (ent.Value, bool) {
+func (m *DeviceIdentityMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *LabelMutation) AddField(name string, value ent.Value) error {
+func (m *DeviceIdentityMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Label numeric field %s", name)
+	return fmt.Errorf("unknown DeviceIdentity numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *LabelMutation) ClearedFields() []string {
+func (m *DeviceIdentityMutation) ClearedFields() []string {
 	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *LabelMutation) FieldCleared(name string) bool {
+func (m *DeviceIdentityMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *LabelMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown Label nullable field %s", name)
+func (m *DeviceIdentityMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown DeviceIdentity nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *LabelMutation) ResetField(name string) error {
+func (m *DeviceIdentityMutation) ResetField(name string) error {
 	switch name {
-	case label.FieldKey:
-		m.ResetKey()
+	case deviceidentity.FieldScheme:
+		m.ResetScheme()
 		return nil
-	case label.FieldValue:
+	case deviceidentity.FieldValue:
 		m.ResetValue()
 		return nil
+	case deviceidentity.FieldDeviceUID:
+		m.ResetDeviceUID()
+		return nil
 	}
-	return fmt.Errorf("unknown Label field %s", name)
+	return fmt.Errorf("unknown DeviceIdentity field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *LabelMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.resource != nil {
-		edges = append(edges, label.EdgeResource)
-	}
+func (m *DeviceIdentityMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *LabelMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case label.EdgeResource:
-		if id := m.resource; id != nil {
-			return []ent.Value{*id}
-		}
-	}
+func (m *DeviceIdentityMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *LabelMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
+func (m *DeviceIdentityMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *LabelMutation) RemovedIDs(name string) []ent.Value {
+func (m *DeviceIdentityMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *LabelMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.clearedresource {
-		edges = append(edges, label.EdgeResource)
-	}
+func (m *DeviceIdentityMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *LabelMutation) EdgeCleared(name string) bool {
-	switch name {
-	case label.EdgeResource:
-		return m.clearedresource
-	}
+func (m *DeviceIdentityMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *LabelMutation) ClearEdge(name string) error {
-	switch name {
-	case label.EdgeResource:
-		m.ClearResource()
-		return nil
-	}
-	return fmt.Errorf("unknown Label unique edge %s", name)
+func (m *DeviceIdentityMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown DeviceIdentity unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *LabelMutation) ResetEdge(name string) error {
-	switch name {
-	case label.EdgeResource:
-		m.ResetResource()
-		return nil
-	}
-	return fmt.Errorf("unknown Label edge %s", name)
+func (m *DeviceIdentityMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown DeviceIdentity edge %s", name)
 }
 
-// ResourceMutation represents an operation that mutates the Resource nodes in the graph.
-type ResourceMutation struct {
+// LabelMutation represents an operation that mutates the Label nodes in the graph.
+type LabelMutation struct {
 	config
-	op                 Op
-	typ                string
-	id                 *int
-	uid                *string
-	name               *string
-	api_version        *string
-	kind               *string
-	resource_type      *string
-	spec               *json.RawMessage
-	appendspec         json.RawMessage
-	status             *json.RawMessage
-	appendstatus       json.RawMessage
-	created_at         *time.Time
-	updated_at         *time.Time
-	resource_version   *string
-	namespace          *string
-	clearedFields      map[string]struct{}
-	labels             map[int]struct{}
-	removedlabels      map[int]struct{}
-	clearedlabels      bool
-	annotations        map[int]struct{}
-	removedannotations map[int]struct{}
-	clearedannotations bool
-	done               bool
-	oldValue           func(context.Context) (*Resource, error)
-	predicates         []predicate.Resource
+	op              Op
+	typ             string
+	id              *int
+	key             *string
+	value           *string
+	clearedFields   map[string]struct{}
+	resource        *int
+	clearedresource bool
+	done            bool
+	oldValue        func(context.Context) (*Label, error)
+	predicates      []predicate.Label
 }
 
-var _ ent.Mutation = (*ResourceMutation)(nil)
+var _ ent.Mutation = (*LabelMutation)(nil)
 
-// resourceOption allows management of the mutation configuration using functional options.
-type resourceOption func(*ResourceMutation)
+// labelOption allows management of the mutation configuration using functional options.
+type labelOption func(*LabelMutation)
 
-// newResourceMutation creates new mutation for the Resource entity.
-func newResourceMutation(c config, op Op, opts ...resourceOption) *ResourceMutation {
-	m := &ResourceMutation{
+// newLabelMutation creates new mutation for the Label entity.
+func newLabelMutation(c config, op Op, opts ...labelOption) *LabelMutation {
+	m := &LabelMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeResource,
+		typ:           TypeLabel,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -980,20 +958,20 @@ func newResourceMutation(c config, op Op, opts ...resourceOption) *ResourceMutat
 	return m
 }
 
-// withResourceID sets the ID field of the mutation.
-func withResourceID(id int) resourceOption {
-	return func(m *ResourceMutation) {
+// withLabelID sets the ID field of the mutation.
+func withLabelID(id int) labelOption {
+	return func(m *LabelMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Resource
+			value *Label
 		)
-		m.oldValue = func(ctx context.Context) (*Resource, error) {
+		m.oldValue = func(ctx context.Context) (*Label, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Resource.Get(ctx, id)
+					value, err = m.Client().Label.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -1002,10 +980,10 @@ func withResourceID(id int) resourceOption {
 	}
 }
 
-// withResource sets the old Resource of the mutation.
-func withResource(node *Resource) resourceOption {
-	return func(m *ResourceMutation) {
-		m.oldValue = func(context.Context) (*Resource, error) {
+// withLabel sets the old Label of the mutation.
+func withLabel(node *Label) labelOption {
+	return func(m *LabelMutation) {
+		m.oldValue = func(context.Context) (*Label, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -1014,7 +992,7 @@ func withResource(node *Resource) resourceOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m ResourceMutation) Client() *Client {
+func (m LabelMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -1022,7 +1000,7 @@ func (m ResourceMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m ResourceMutation) Tx() (*Tx, error) {
+func (m LabelMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -1033,7 +1011,7 @@ func (m ResourceMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *ResourceMutation) ID() (id int, exists bool) {
+func (m *LabelMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -1044,7 +1022,7 @@ func (m *ResourceMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *ResourceMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *LabelMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -1053,582 +1031,2375 @@ func (m *ResourceMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Resource.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Label.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetUID sets the "uid" field.
-func (m *ResourceMutation) SetUID(s string) {
-	m.uid = &s
+// SetKey sets the "key" field.
+func (m *LabelMutation) SetKey(s string) {
+	m.key = &s
 }
 
-// UID returns the value of the "uid" field in the mutation.
-func (m *ResourceMutation) UID() (r string, exists bool) {
-	v := m.uid
+// Key returns the value of the "key" field in the mutation.
+func (m *LabelMutation) Key() (r string, exists bool) {
+	v := m.key
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUID returns the old "uid" field's value of the Resource entity.
-// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// OldKey returns the old "key" field's value of the Label entity.
+// If the Label object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ResourceMutation) OldUID(ctx context.Context) (v string, err error) {
+func (m *LabelMutation) OldKey(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUID is only allowed on UpdateOne operations")
+		return v, errors.New("OldKey is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUID requires an ID field in the mutation")
+		return v, errors.New("OldKey requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUID: %w", err)
+		return v, fmt.Errorf("querying old value for OldKey: %w", err)
 	}
-	return oldValue.UID, nil
+	return oldValue.Key, nil
 }
 
-// ResetUID resets all changes to the "uid" field.
-func (m *ResourceMutation) ResetUID() {
-	m.uid = nil
+// ResetKey resets all changes to the "key" field.
+func (m *LabelMutation) ResetKey() {
+	m.key = nil
 }
 
-// SetName sets the "name" field.
-func (m *ResourceMutation) SetName(s string) {
-	m.name = &s
+// SetValue sets the "value" field.
+func (m *LabelMutation) SetValue(s string) {
+	m.value = &s
 }
 
-// Name returns the value of the "name" field in the mutation.
-func (m *ResourceMutation) Name() (r string, exists bool) {
-	v := m.name
+// Value returns the value of the "value" field in the mutation.
+func (m *LabelMutation) Value() (r string, exists bool) {
+	v := m.value
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldName returns the old "name" field's value of the Resource entity.
-// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// OldValue returns the old "value" field's value of the Label entity.
+// If the Label object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ResourceMutation) OldName(ctx context.Context) (v string, err error) {
+func (m *LabelMutation) OldValue(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
+		return v, errors.New("OldValue is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
+		return v, errors.New("OldValue requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
+		return v, fmt.Errorf("querying old value for OldValue: %w", err)
 	}
-	return oldValue.Name, nil
-}
-
-// ResetName resets all changes to the "name" field.
-func (m *ResourceMutation) ResetName() {
-	m.name = nil
-}
-
-// SetAPIVersion sets the "api_version" field.
-func (m *ResourceMutation) SetAPIVersion(s string) {
-	m.api_version = &s
+	return oldValue.Value, nil
 }
 
-// APIVersion returns the value of the "api_version" field in the mutation.
-func (m *ResourceMutation) APIVersion() (r string, exists bool) {
-	v := m.api_version
-	if v == nil {
-		return
-	}
-	return *v, true
+// ResetValue resets all changes to the "value" field.
+func (m *LabelMutation) ResetValue() {
+	m.value = nil
 }
 
-// OldAPIVersion returns the old "api_version" field's value of the Resource entity.
-// If the Resource object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ResourceMutation) OldAPIVersion(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAPIVersion is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAPIVersion requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAPIVersion: %w", err)
-	}
-	return oldValue.APIVersion, nil
+// SetResourceID sets the "resource" edge to the Resource entity by id.
+func (m *LabelMutation) SetResourceID(id int) {
+	m.resource = &id
 }
 
-// ResetAPIVersion resets all changes to the "api_version" field.
-func (m *ResourceMutation) ResetAPIVersion() {
-	m.api_version = nil
+// ClearResource clears the "resource" edge to the Resource entity.
+func (m *LabelMutation) ClearResource() {
+	m.clearedresource = true
 }
 
-// SetKind sets the "kind" field.
-func (m *ResourceMutation) SetKind(s string) {
-	m.kind = &s
+// ResourceCleared reports if the "resource" edge to the Resource entity was cleared.
+func (m *LabelMutation) ResourceCleared() bool {
+	return m.clearedresource
 }
 
-// Kind returns the value of the "kind" field in the mutation.
-func (m *ResourceMutation) Kind() (r string, exists bool) {
-	v := m.kind
-	if v == nil {
-		return
+// ResourceID returns the "resource" edge ID in the mutation.
+func (m *LabelMutation) ResourceID() (id int, exists bool) {
+	if m.resource != nil {
+		return *m.resource, true
 	}
-	return *v, true
+	return
 }
 
-// OldKind returns the old "kind" field's value of the Resource entity.
-// If the Resource object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ResourceMutation) OldKind(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldKind is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldKind requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldKind: %w", err)
+// ResourceIDs returns the "resource" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ResourceID instead. It exists only for internal usage by the builders.
+func (m *LabelMutation) ResourceIDs() (ids []int) {
+	if id := m.resource; id != nil {
+		ids = append(ids, *id)
 	}
-	return oldValue.Kind, nil
-}
-
-// ResetKind resets all changes to the "kind" field.
-func (m *ResourceMutation) ResetKind() {
-	m.kind = nil
+	return
 }
 
-// SetResourceType sets the "resource_type" field.
-func (m *ResourceMutation) SetResourceType(s string) {
-	m.resource_type = &s
+// ResetResource resets all changes to the "resource" edge.
+func (m *LabelMutation) ResetResource() {
+	m.resource = nil
+	m.clearedresource = false
 }
 
-// ResourceType returns the value of the "resource_type" field in the mutation.
-func (m *ResourceMutation) ResourceType() (r string, exists bool) {
-	v := m.resource_type
-	if v == nil {
-		return
-	}
-	return *v, true
+// Where appends a list predicates to the LabelMutation builder.
+func (m *LabelMutation) Where(ps ...predicate.Label) {
+	m.predicates = append(m.predicates, ps...)
 }
 
-// OldResourceType returns the old "resource_type" field's value of the Resource entity.
-// If the Resource object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ResourceMutation) OldResourceType(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldResourceType is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldResourceType requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldResourceType: %w", err)
+// WhereP appends storage-level predicates to the LabelMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *LabelMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Label, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
 	}
-	return oldValue.ResourceType, nil
+	m.Where(p...)
 }
 
-// ResetResourceType resets all changes to the "resource_type" field.
-func (m *ResourceMutation) ResetResourceType() {
-	m.resource_type = nil
+// Op returns the operation name.
+func (m *LabelMutation) Op() Op {
+	return m.op
 }
 
-// SetSpec sets the "spec" field.
-func (m *ResourceMutation) SetSpec(jm json.RawMessage) {
-	m.spec = &jm
-	m.appendspec = nil
+// SetOp allows setting the mutation operation.
+func (m *LabelMutation) SetOp(op Op) {
+	m.op = op
 }
 
-// Spec returns the value of the "spec" field in the mutation.
-func (m *ResourceMutation) Spec() (r json.RawMessage, exists bool) {
-	v := m.spec
-	if v == nil {
-		return
-	}
-	return *v, true
+// Type returns the node type of this mutation (Label).
+func (m *LabelMutation) Type() string {
+	return m.typ
 }
 
-// OldSpec returns the old "spec" field's value of the Resource entity.
-// If the Resource object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ResourceMutation) OldSpec(ctx context.Context) (v json.RawMessage, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSpec is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSpec requires an ID field in the mutation")
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *LabelMutation) Fields() []string {
+	fields := make([]string, 0, 2)
+	if m.key != nil {
+		fields = append(fields, label.FieldKey)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSpec: %w", err)
+	if m.value != nil {
+		fields = append(fields, label.FieldValue)
 	}
-	return oldValue.Spec, nil
+	return fields
 }
 
-// AppendSpec adds jm to the "spec" field.
-func (m *ResourceMutation) AppendSpec(jm json.RawMessage) {
-	m.appendspec = append(m.appendspec, jm...)
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *LabelMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case label.FieldKey:
+		return m.Key()
+	case label.FieldValue:
+		return m.Value()
+	}
+	return nil, false
 }
 
-// AppendedSpec returns the list of values that were appended to the "spec" field in this mutation.
-func (m *ResourceMutation) AppendedSpec() (json.RawMessage, bool) {
-	if len(m.appendspec) == 0 {
-		return nil, false
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *LabelMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case label.FieldKey:
+		return m.OldKey(ctx)
+	case label.FieldValue:
+		return m.OldValue(ctx)
 	}
-	return m.appendspec, true
+	return nil, fmt.Errorf("unknown Label field %s", name)
 }
 
-// ResetSpec resets all changes to the "spec" field.
-func (m *ResourceMutation) ResetSpec() {
-	m.spec = nil
-	m.appendspec = nil
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *LabelMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case label.FieldKey:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetKey(v)
+		return nil
+	case label.FieldValue:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetValue(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Label field %s", name)
 }
 
-// SetStatus sets the "status" field.
-func (m *ResourceMutation) SetStatus(jm json.RawMessage) {
-	m.status = &jm
-	m.appendstatus = nil
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *LabelMutation) AddedFields() []string {
+	return nil
 }
 
-// Status returns the value of the "status" field in the mutation.
-func (m *ResourceMutation) Status() (r json.RawMessage, exists bool) {
-	v := m.status
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *LabelMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *LabelMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Label numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *LabelMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *LabelMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *LabelMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Label nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *LabelMutation) ResetField(name string) error {
+	switch name {
+	case label.FieldKey:
+		m.ResetKey()
+		return nil
+	case label.FieldValue:
+		m.ResetValue()
+		return nil
+	}
+	return fmt.Errorf("unknown Label field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *LabelMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.resource != nil {
+		edges = append(edges, label.EdgeResource)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *LabelMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case label.EdgeResource:
+		if id := m.resource; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *LabelMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *LabelMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *LabelMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedresource {
+		edges = append(edges, label.EdgeResource)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *LabelMutation) EdgeCleared(name string) bool {
+	switch name {
+	case label.EdgeResource:
+		return m.clearedresource
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *LabelMutation) ClearEdge(name string) error {
+	switch name {
+	case label.EdgeResource:
+		m.ClearResource()
+		return nil
+	}
+	return fmt.Errorf("unknown Label unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *LabelMutation) ResetEdge(name string) error {
+	switch name {
+	case label.EdgeResource:
+		m.ResetResource()
+		return nil
+	}
+	return fmt.Errorf("unknown Label edge %s", name)
+}
+
+// OutboxEventMutation represents an operation that mutates the OutboxEvent nodes in the graph.
+type OutboxEventMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	event_id      *string
+	_type         *string
+	payload       *json.RawMessage
+	appendpayload json.RawMessage
+	created_at    *time.Time
+	delivered_at  *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*OutboxEvent, error)
+	predicates    []predicate.OutboxEvent
+}
+
+var _ ent.Mutation = (*OutboxEventMutation)(nil)
+
+// outboxeventOption allows management of the mutation configuration using functional options.
+type outboxeventOption func(*OutboxEventMutation)
+
+// newOutboxEventMutation creates new mutation for the OutboxEvent entity.
+func newOutboxEventMutation(c config, op Op, opts ...outboxeventOption) *OutboxEventMutation {
+	m := &OutboxEventMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeOutboxEvent,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withOutboxEventID sets the ID field of the mutation.
+func withOutboxEventID(id int) outboxeventOption {
+	return func(m *OutboxEventMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *OutboxEvent
+		)
+		m.oldValue = func(ctx context.Context) (*OutboxEvent, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().OutboxEvent.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withOutboxEvent sets the old OutboxEvent of the mutation.
+func withOutboxEvent(node *OutboxEvent) outboxeventOption {
+	return func(m *OutboxEventMutation) {
+		m.oldValue = func(context.Context) (*OutboxEvent, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m OutboxEventMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m OutboxEventMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *OutboxEventMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *OutboxEventMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().OutboxEvent.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetEventID sets the "event_id" field.
+func (m *OutboxEventMutation) SetEventID(s string) {
+	m.event_id = &s
+}
+
+// EventID returns the value of the "event_id" field in the mutation.
+func (m *OutboxEventMutation) EventID() (r string, exists bool) {
+	v := m.event_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldStatus returns the old "status" field's value of the Resource entity.
-// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// OldEventID returns the old "event_id" field's value of the OutboxEvent entity.
+// If the OutboxEvent object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ResourceMutation) OldStatus(ctx context.Context) (v json.RawMessage, err error) {
+func (m *OutboxEventMutation) OldEventID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+		return v, errors.New("OldEventID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldStatus requires an ID field in the mutation")
+		return v, errors.New("OldEventID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+		return v, fmt.Errorf("querying old value for OldEventID: %w", err)
 	}
-	return oldValue.Status, nil
+	return oldValue.EventID, nil
 }
 
-// AppendStatus adds jm to the "status" field.
-func (m *ResourceMutation) AppendStatus(jm json.RawMessage) {
-	m.appendstatus = append(m.appendstatus, jm...)
+// ResetEventID resets all changes to the "event_id" field.
+func (m *OutboxEventMutation) ResetEventID() {
+	m.event_id = nil
 }
 
-// AppendedStatus returns the list of values that were appended to the "status" field in this mutation.
-func (m *ResourceMutation) AppendedStatus() (json.RawMessage, bool) {
-	if len(m.appendstatus) == 0 {
+// SetType sets the "type" field.
+func (m *OutboxEventMutation) SetType(s string) {
+	m._type = &s
+}
+
+// GetType returns the value of the "type" field in the mutation.
+func (m *OutboxEventMutation) GetType() (r string, exists bool) {
+	v := m._type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldType returns the old "type" field's value of the OutboxEvent entity.
+// If the OutboxEvent object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OutboxEventMutation) OldType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
+	}
+	return oldValue.Type, nil
+}
+
+// ResetType resets all changes to the "type" field.
+func (m *OutboxEventMutation) ResetType() {
+	m._type = nil
+}
+
+// SetPayload sets the "payload" field.
+func (m *OutboxEventMutation) SetPayload(j json.RawMessage) {
+	m.payload = &j
+	m.appendpayload = nil
+}
+
+// Payload returns the value of the "payload" field in the mutation.
+func (m *OutboxEventMutation) Payload() (r json.RawMessage, exists bool) {
+	v := m.payload
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPayload returns the old "payload" field's value of the OutboxEvent entity.
+// If the OutboxEvent object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OutboxEventMutation) OldPayload(ctx context.Context) (v json.RawMessage, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPayload is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPayload requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPayload: %w", err)
+	}
+	return oldValue.Payload, nil
+}
+
+// AppendPayload adds j to the "payload" field.
+func (m *OutboxEventMutation) AppendPayload(j json.RawMessage) {
+	m.appendpayload = append(m.appendpayload, j...)
+}
+
+// AppendedPayload returns the list of values that were appended to the "payload" field in this mutation.
+func (m *OutboxEventMutation) AppendedPayload() (json.RawMessage, bool) {
+	if len(m.appendpayload) == 0 {
 		return nil, false
 	}
-	return m.appendstatus, true
+	return m.appendpayload, true
+}
+
+// ResetPayload resets all changes to the "payload" field.
+func (m *OutboxEventMutation) ResetPayload() {
+	m.payload = nil
+	m.appendpayload = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *OutboxEventMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *OutboxEventMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the OutboxEvent entity.
+// If the OutboxEvent object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OutboxEventMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *OutboxEventMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetDeliveredAt sets the "delivered_at" field.
+func (m *OutboxEventMutation) SetDeliveredAt(t time.Time) {
+	m.delivered_at = &t
+}
+
+// DeliveredAt returns the value of the "delivered_at" field in the mutation.
+func (m *OutboxEventMutation) DeliveredAt() (r time.Time, exists bool) {
+	v := m.delivered_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDeliveredAt returns the old "delivered_at" field's value of the OutboxEvent entity.
+// If the OutboxEvent object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OutboxEventMutation) OldDeliveredAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeliveredAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeliveredAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeliveredAt: %w", err)
+	}
+	return oldValue.DeliveredAt, nil
+}
+
+// ClearDeliveredAt clears the value of the "delivered_at" field.
+func (m *OutboxEventMutation) ClearDeliveredAt() {
+	m.delivered_at = nil
+	m.clearedFields[outboxevent.FieldDeliveredAt] = struct{}{}
+}
+
+// DeliveredAtCleared returns if the "delivered_at" field was cleared in this mutation.
+func (m *OutboxEventMutation) DeliveredAtCleared() bool {
+	_, ok := m.clearedFields[outboxevent.FieldDeliveredAt]
+	return ok
+}
+
+// ResetDeliveredAt resets all changes to the "delivered_at" field.
+func (m *OutboxEventMutation) ResetDeliveredAt() {
+	m.delivered_at = nil
+	delete(m.clearedFields, outboxevent.FieldDeliveredAt)
+}
+
+// Where appends a list predicates to the OutboxEventMutation builder.
+func (m *OutboxEventMutation) Where(ps ...predicate.OutboxEvent) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the OutboxEventMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *OutboxEventMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.OutboxEvent, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *OutboxEventMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *OutboxEventMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (OutboxEvent).
+func (m *OutboxEventMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *OutboxEventMutation) Fields() []string {
+	fields := make([]string, 0, 5)
+	if m.event_id != nil {
+		fields = append(fields, outboxevent.FieldEventID)
+	}
+	if m._type != nil {
+		fields = append(fields, outboxevent.FieldType)
+	}
+	if m.payload != nil {
+		fields = append(fields, outboxevent.FieldPayload)
+	}
+	if m.created_at != nil {
+		fields = append(fields, outboxevent.FieldCreatedAt)
+	}
+	if m.delivered_at != nil {
+		fields = append(fields, outboxevent.FieldDeliveredAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *OutboxEventMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case outboxevent.FieldEventID:
+		return m.EventID()
+	case outboxevent.FieldType:
+		return m.GetType()
+	case outboxevent.FieldPayload:
+		return m.Payload()
+	case outboxevent.FieldCreatedAt:
+		return m.CreatedAt()
+	case outboxevent.FieldDeliveredAt:
+		return m.DeliveredAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *OutboxEventMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case outboxevent.FieldEventID:
+		return m.OldEventID(ctx)
+	case outboxevent.FieldType:
+		return m.OldType(ctx)
+	case outboxevent.FieldPayload:
+		return m.OldPayload(ctx)
+	case outboxevent.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case outboxevent.FieldDeliveredAt:
+		return m.OldDeliveredAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown OutboxEvent field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *OutboxEventMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case outboxevent.FieldEventID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEventID(v)
+		return nil
+	case outboxevent.FieldType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetType(v)
+		return nil
+	case outboxevent.FieldPayload:
+		v, ok := value.(json.RawMessage)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPayload(v)
+		return nil
+	case outboxevent.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case outboxevent.FieldDeliveredAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeliveredAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown OutboxEvent field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *OutboxEventMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *OutboxEventMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *OutboxEventMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown OutboxEvent numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *OutboxEventMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(outboxevent.FieldDeliveredAt) {
+		fields = append(fields, outboxevent.FieldDeliveredAt)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *OutboxEventMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *OutboxEventMutation) ClearField(name string) error {
+	switch name {
+	case outboxevent.FieldDeliveredAt:
+		m.ClearDeliveredAt()
+		return nil
+	}
+	return fmt.Errorf("unknown OutboxEvent nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *OutboxEventMutation) ResetField(name string) error {
+	switch name {
+	case outboxevent.FieldEventID:
+		m.ResetEventID()
+		return nil
+	case outboxevent.FieldType:
+		m.ResetType()
+		return nil
+	case outboxevent.FieldPayload:
+		m.ResetPayload()
+		return nil
+	case outboxevent.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case outboxevent.FieldDeliveredAt:
+		m.ResetDeliveredAt()
+		return nil
+	}
+	return fmt.Errorf("unknown OutboxEvent field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *OutboxEventMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *OutboxEventMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *OutboxEventMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *OutboxEventMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *OutboxEventMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *OutboxEventMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *OutboxEventMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown OutboxEvent unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *OutboxEventMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown OutboxEvent edge %s", name)
+}
+
+// ResourceMutation represents an operation that mutates the Resource nodes in the graph.
+type ResourceMutation struct {
+	config
+	op                 Op
+	typ                string
+	id                 *int
+	uid                *string
+	name               *string
+	api_version        *string
+	kind               *string
+	resource_type      *string
+	spec               *json.RawMessage
+	appendspec         json.RawMessage
+	status             *json.RawMessage
+	appendstatus       json.RawMessage
+	created_at         *time.Time
+	updated_at         *time.Time
+	resource_version   *string
+	namespace          *string
+	clearedFields      map[string]struct{}
+	labels             map[int]struct{}
+	removedlabels      map[int]struct{}
+	clearedlabels      bool
+	annotations        map[int]struct{}
+	removedannotations map[int]struct{}
+	clearedannotations bool
+	done               bool
+	oldValue           func(context.Context) (*Resource, error)
+	predicates         []predicate.Resource
+}
+
+var _ ent.Mutation = (*ResourceMutation)(nil)
+
+// resourceOption allows management of the mutation configuration using functional options.
+type resourceOption func(*ResourceMutation)
+
+// newResourceMutation creates new mutation for the Resource entity.
+func newResourceMutation(c config, op Op, opts ...resourceOption) *ResourceMutation {
+	m := &ResourceMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeResource,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withResourceID sets the ID field of the mutation.
+func withResourceID(id int) resourceOption {
+	return func(m *ResourceMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Resource
+		)
+		m.oldValue = func(ctx context.Context) (*Resource, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Resource.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withResource sets the old Resource of the mutation.
+func withResource(node *Resource) resourceOption {
+	return func(m *ResourceMutation) {
+		m.oldValue = func(context.Context) (*Resource, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m ResourceMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m ResourceMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *ResourceMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *ResourceMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Resource.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetUID sets the "uid" field.
+func (m *ResourceMutation) SetUID(s string) {
+	m.uid = &s
+}
+
+// UID returns the value of the "uid" field in the mutation.
+func (m *ResourceMutation) UID() (r string, exists bool) {
+	v := m.uid
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUID returns the old "uid" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldUID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUID: %w", err)
+	}
+	return oldValue.UID, nil
+}
+
+// ResetUID resets all changes to the "uid" field.
+func (m *ResourceMutation) ResetUID() {
+	m.uid = nil
+}
+
+// SetName sets the "name" field.
+func (m *ResourceMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *ResourceMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *ResourceMutation) ResetName() {
+	m.name = nil
+}
+
+// SetAPIVersion sets the "api_version" field.
+func (m *ResourceMutation) SetAPIVersion(s string) {
+	m.api_version = &s
+}
+
+// APIVersion returns the value of the "api_version" field in the mutation.
+func (m *ResourceMutation) APIVersion() (r string, exists bool) {
+	v := m.api_version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAPIVersion returns the old "api_version" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldAPIVersion(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAPIVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAPIVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAPIVersion: %w", err)
+	}
+	return oldValue.APIVersion, nil
+}
+
+// ResetAPIVersion resets all changes to the "api_version" field.
+func (m *ResourceMutation) ResetAPIVersion() {
+	m.api_version = nil
+}
+
+// SetKind sets the "kind" field.
+func (m *ResourceMutation) SetKind(s string) {
+	m.kind = &s
+}
+
+// Kind returns the value of the "kind" field in the mutation.
+func (m *ResourceMutation) Kind() (r string, exists bool) {
+	v := m.kind
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldKind returns the old "kind" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldKind(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldKind is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldKind requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldKind: %w", err)
+	}
+	return oldValue.Kind, nil
+}
+
+// ResetKind resets all changes to the "kind" field.
+func (m *ResourceMutation) ResetKind() {
+	m.kind = nil
+}
+
+// SetResourceType sets the "resource_type" field.
+func (m *ResourceMutation) SetResourceType(s string) {
+	m.resource_type = &s
+}
+
+// ResourceType returns the value of the "resource_type" field in the mutation.
+func (m *ResourceMutation) ResourceType() (r string, exists bool) {
+	v := m.resource_type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResourceType returns the old "resource_type" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldResourceType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResourceType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResourceType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResourceType: %w", err)
+	}
+	return oldValue.ResourceType, nil
+}
+
+// ResetResourceType resets all changes to the "resource_type" field.
+func (m *ResourceMutation) ResetResourceType() {
+	m.resource_type = nil
+}
+
+// SetSpec sets the "spec" field.
+func (m *ResourceMutation) SetSpec(j json.RawMessage) {
+	m.spec = &j
+	m.appendspec = nil
+}
+
+// Spec returns the value of the "spec" field in the mutation.
+func (m *ResourceMutation) Spec() (r json.RawMessage, exists bool) {
+	v := m.spec
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSpec returns the old "spec" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldSpec(ctx context.Context) (v json.RawMessage, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSpec is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSpec requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSpec: %w", err)
+	}
+	return oldValue.Spec, nil
+}
+
+// AppendSpec adds j to the "spec" field.
+func (m *ResourceMutation) AppendSpec(j json.RawMessage) {
+	m.appendspec = append(m.appendspec, j...)
+}
+
+// AppendedSpec returns the list of values that were appended to the "spec" field in this mutation.
+func (m *ResourceMutation) AppendedSpec() (json.RawMessage, bool) {
+	if len(m.appendspec) == 0 {
+		return nil, false
+	}
+	return m.appendspec, true
+}
+
+// ResetSpec resets all changes to the "spec" field.
+func (m *ResourceMutation) ResetSpec() {
+	m.spec = nil
+	m.appendspec = nil
+}
+
+// SetStatus sets the "status" field.
+func (m *ResourceMutation) SetStatus(j json.RawMessage) {
+	m.status = &j
+	m.appendstatus = nil
+}
+
+// Status returns the value of the "status" field in the mutation.
+func (m *ResourceMutation) Status() (r json.RawMessage, exists bool) {
+	v := m.status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStatus returns the old "status" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldStatus(ctx context.Context) (v json.RawMessage, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatus requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+	}
+	return oldValue.Status, nil
+}
+
+// AppendStatus adds j to the "status" field.
+func (m *ResourceMutation) AppendStatus(j json.RawMessage) {
+	m.appendstatus = append(m.appendstatus, j...)
+}
+
+// AppendedStatus returns the list of values that were appended to the "status" field in this mutation.
+func (m *ResourceMutation) AppendedStatus() (json.RawMessage, bool) {
+	if len(m.appendstatus) == 0 {
+		return nil, false
+	}
+	return m.appendstatus, true
+}
+
+// ClearStatus clears the value of the "status" field.
+func (m *ResourceMutation) ClearStatus() {
+	m.status = nil
+	m.appendstatus = nil
+	m.clearedFields[resource.FieldStatus] = struct{}{}
+}
+
+// StatusCleared returns if the "status" field was cleared in this mutation.
+func (m *ResourceMutation) StatusCleared() bool {
+	_, ok := m.clearedFields[resource.FieldStatus]
+	return ok
+}
+
+// ResetStatus resets all changes to the "status" field.
+func (m *ResourceMutation) ResetStatus() {
+	m.status = nil
+	m.appendstatus = nil
+	delete(m.clearedFields, resource.FieldStatus)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *ResourceMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *ResourceMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *ResourceMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *ResourceMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *ResourceMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *ResourceMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// SetResourceVersion sets the "resource_version" field.
+func (m *ResourceMutation) SetResourceVersion(s string) {
+	m.resource_version = &s
+}
+
+// ResourceVersion returns the value of the "resource_version" field in the mutation.
+func (m *ResourceMutation) ResourceVersion() (r string, exists bool) {
+	v := m.resource_version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResourceVersion returns the old "resource_version" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldResourceVersion(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResourceVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResourceVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResourceVersion: %w", err)
+	}
+	return oldValue.ResourceVersion, nil
+}
+
+// ResetResourceVersion resets all changes to the "resource_version" field.
+func (m *ResourceMutation) ResetResourceVersion() {
+	m.resource_version = nil
+}
+
+// SetNamespace sets the "namespace" field.
+func (m *ResourceMutation) SetNamespace(s string) {
+	m.namespace = &s
+}
+
+// Namespace returns the value of the "namespace" field in the mutation.
+func (m *ResourceMutation) Namespace() (r string, exists bool) {
+	v := m.namespace
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNamespace returns the old "namespace" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldNamespace(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNamespace is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNamespace requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNamespace: %w", err)
+	}
+	return oldValue.Namespace, nil
+}
+
+// ClearNamespace clears the value of the "namespace" field.
+func (m *ResourceMutation) ClearNamespace() {
+	m.namespace = nil
+	m.clearedFields[resource.FieldNamespace] = struct{}{}
+}
+
+// NamespaceCleared returns if the "namespace" field was cleared in this mutation.
+func (m *ResourceMutation) NamespaceCleared() bool {
+	_, ok := m.clearedFields[resource.FieldNamespace]
+	return ok
+}
+
+// ResetNamespace resets all changes to the "namespace" field.
+func (m *ResourceMutation) ResetNamespace() {
+	m.namespace = nil
+	delete(m.clearedFields, resource.FieldNamespace)
+}
+
+// AddLabelIDs adds the "labels" edge to the Label entity by ids.
+func (m *ResourceMutation) AddLabelIDs(ids ...int) {
+	if m.labels == nil {
+		m.labels = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.labels[ids[i]] = struct{}{}
+	}
+}
+
+// ClearLabels clears the "labels" edge to the Label entity.
+func (m *ResourceMutation) ClearLabels() {
+	m.clearedlabels = true
+}
+
+// LabelsCleared reports if the "labels" edge to the Label entity was cleared.
+func (m *ResourceMutation) LabelsCleared() bool {
+	return m.clearedlabels
+}
+
+// RemoveLabelIDs removes the "labels" edge to the Label entity by IDs.
+func (m *ResourceMutation) RemoveLabelIDs(ids ...int) {
+	if m.removedlabels == nil {
+		m.removedlabels = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.labels, ids[i])
+		m.removedlabels[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedLabels returns the removed IDs of the "labels" edge to the Label entity.
+func (m *ResourceMutation) RemovedLabelsIDs() (ids []int) {
+	for id := range m.removedlabels {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// LabelsIDs returns the "labels" edge IDs in the mutation.
+func (m *ResourceMutation) LabelsIDs() (ids []int) {
+	for id := range m.labels {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetLabels resets all changes to the "labels" edge.
+func (m *ResourceMutation) ResetLabels() {
+	m.labels = nil
+	m.clearedlabels = false
+	m.removedlabels = nil
+}
+
+// AddAnnotationIDs adds the "annotations" edge to the Annotation entity by ids.
+func (m *ResourceMutation) AddAnnotationIDs(ids ...int) {
+	if m.annotations == nil {
+		m.annotations = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.annotations[ids[i]] = struct{}{}
+	}
+}
+
+// ClearAnnotations clears the "annotations" edge to the Annotation entity.
+func (m *ResourceMutation) ClearAnnotations() {
+	m.clearedannotations = true
+}
+
+// AnnotationsCleared reports if the "annotations" edge to the Annotation entity was cleared.
+func (m *ResourceMutation) AnnotationsCleared() bool {
+	return m.clearedannotations
+}
+
+// RemoveAnnotationIDs removes the "annotations" edge to the Annotation entity by IDs.
+func (m *ResourceMutation) RemoveAnnotationIDs(ids ...int) {
+	if m.removedannotations == nil {
+		m.removedannotations = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.annotations, ids[i])
+		m.removedannotations[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedAnnotations returns the removed IDs of the "annotations" edge to the Annotation entity.
+func (m *ResourceMutation) RemovedAnnotationsIDs() (ids []int) {
+	for id := range m.removedannotations {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// AnnotationsIDs returns the "annotations" edge IDs in the mutation.
+func (m *ResourceMutation) AnnotationsIDs() (ids []int) {
+	for id := range m.annotations {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetAnnotations resets all changes to the "annotations" edge.
+func (m *ResourceMutation) ResetAnnotations() {
+	m.annotations = nil
+	m.clearedannotations = false
+	m.removedannotations = nil
+}
+
+// Where appends a list predicates to the ResourceMutation builder.
+func (m *ResourceMutation) Where(ps ...predicate.Resource) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the ResourceMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *ResourceMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Resource, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *ResourceMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *ResourceMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Resource).
+func (m *ResourceMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ResourceMutation) Fields() []string {
+	fields := make([]string, 0, 11)
+	if m.uid != nil {
+		fields = append(fields, resource.FieldUID)
+	}
+	if m.name != nil {
+		fields = append(fields, resource.FieldName)
+	}
+	if m.api_version != nil {
+		fields = append(fields, resource.FieldAPIVersion)
+	}
+	if m.kind != nil {
+		fields = append(fields, resource.FieldKind)
+	}
+	if m.resource_type != nil {
+		fields = append(fields, resource.FieldResourceType)
+	}
+	if m.spec != nil {
+		fields = append(fields, resource.FieldSpec)
+	}
+	if m.status != nil {
+		fields = append(fields, resource.FieldStatus)
+	}
+	if m.created_at != nil {
+		fields = append(fields, resource.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, resource.FieldUpdatedAt)
+	}
+	if m.resource_version != nil {
+		fields = append(fields, resource.FieldResourceVersion)
+	}
+	if m.namespace != nil {
+		fields = append(fields, resource.FieldNamespace)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ResourceMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case resource.FieldUID:
+		return m.UID()
+	case resource.FieldName:
+		return m.Name()
+	case resource.FieldAPIVersion:
+		return m.APIVersion()
+	case resource.FieldKind:
+		return m.Kind()
+	case resource.FieldResourceType:
+		return m.ResourceType()
+	case resource.FieldSpec:
+		return m.Spec()
+	case resource.FieldStatus:
+		return m.Status()
+	case resource.FieldCreatedAt:
+		return m.CreatedAt()
+	case resource.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case resource.FieldResourceVersion:
+		return m.ResourceVersion()
+	case resource.FieldNamespace:
+		return m.Namespace()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ResourceMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case resource.FieldUID:
+		return m.OldUID(ctx)
+	case resource.FieldName:
+		return m.OldName(ctx)
+	case resource.FieldAPIVersion:
+		return m.OldAPIVersion(ctx)
+	case resource.FieldKind:
+		return m.OldKind(ctx)
+	case resource.FieldResourceType:
+		return m.OldResourceType(ctx)
+	case resource.FieldSpec:
+		return m.OldSpec(ctx)
+	case resource.FieldStatus:
+		return m.OldStatus(ctx)
+	case resource.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case resource.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case resource.FieldResourceVersion:
+		return m.OldResourceVersion(ctx)
+	case resource.FieldNamespace:
+		return m.OldNamespace(ctx)
+	}
+	return nil, fmt.Errorf("unknown Resource field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ResourceMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case resource.FieldUID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUID(v)
+		return nil
+	case resource.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case resource.FieldAPIVersion:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAPIVersion(v)
+		return nil
+	case resource.FieldKind:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetKind(v)
+		return nil
+	case resource.FieldResourceType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResourceType(v)
+		return nil
+	case resource.FieldSpec:
+		v, ok := value.(json.RawMessage)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSpec(v)
+		return nil
+	case resource.FieldStatus:
+		v, ok := value.(json.RawMessage)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case resource.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case resource.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case resource.FieldResourceVersion:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResourceVersion(v)
+		return nil
+	case resource.FieldNamespace:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNamespace(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Resource field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ResourceMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ResourceMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ResourceMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Resource numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *ResourceMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(resource.FieldStatus) {
+		fields = append(fields, resource.FieldStatus)
+	}
+	if m.FieldCleared(resource.FieldNamespace) {
+		fields = append(fields, resource.FieldNamespace)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *ResourceMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *ResourceMutation) ClearField(name string) error {
+	switch name {
+	case resource.FieldStatus:
+		m.ClearStatus()
+		return nil
+	case resource.FieldNamespace:
+		m.ClearNamespace()
+		return nil
+	}
+	return fmt.Errorf("unknown Resource nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *ResourceMutation) ResetField(name string) error {
+	switch name {
+	case resource.FieldUID:
+		m.ResetUID()
+		return nil
+	case resource.FieldName:
+		m.ResetName()
+		return nil
+	case resource.FieldAPIVersion:
+		m.ResetAPIVersion()
+		return nil
+	case resource.FieldKind:
+		m.ResetKind()
+		return nil
+	case resource.FieldResourceType:
+		m.ResetResourceType()
+		return nil
+	case resource.FieldSpec:
+		m.ResetSpec()
+		return nil
+	case resource.FieldStatus:
+		m.ResetStatus()
+		return nil
+	case resource.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case resource.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	case resource.FieldResourceVersion:
+		m.ResetResourceVersion()
+		return nil
+	case resource.FieldNamespace:
+		m.ResetNamespace()
+		return nil
+	}
+	return fmt.Errorf("unknown Resource field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *ResourceMutation) AddedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.labels != nil {
+		edges = append(edges, resource.EdgeLabels)
+	}
+	if m.annotations != nil {
+		edges = append(edges, resource.EdgeAnnotations)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *ResourceMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case resource.EdgeLabels:
+		ids := make([]ent.Value, 0, len(m.labels))
+		for id := range m.labels {
+			ids = append(ids, id)
+		}
+		return ids
+	case resource.EdgeAnnotations:
+		ids := make([]ent.Value, 0, len(m.annotations))
+		for id := range m.annotations {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *ResourceMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.removedlabels != nil {
+		edges = append(edges, resource.EdgeLabels)
+	}
+	if m.removedannotations != nil {
+		edges = append(edges, resource.EdgeAnnotations)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *ResourceMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case resource.EdgeLabels:
+		ids := make([]ent.Value, 0, len(m.removedlabels))
+		for id := range m.removedlabels {
+			ids = append(ids, id)
+		}
+		return ids
+	case resource.EdgeAnnotations:
+		ids := make([]ent.Value, 0, len(m.removedannotations))
+		for id := range m.removedannotations {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *ResourceMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.clearedlabels {
+		edges = append(edges, resource.EdgeLabels)
+	}
+	if m.clearedannotations {
+		edges = append(edges, resource.EdgeAnnotations)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *ResourceMutation) EdgeCleared(name string) bool {
+	switch name {
+	case resource.EdgeLabels:
+		return m.clearedlabels
+	case resource.EdgeAnnotations:
+		return m.clearedannotations
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *ResourceMutation) ClearEdge(name string) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Resource unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *ResourceMutation) ResetEdge(name string) error {
+	switch name {
+	case resource.EdgeLabels:
+		m.ResetLabels()
+		return nil
+	case resource.EdgeAnnotations:
+		m.ResetAnnotations()
+		return nil
+	}
+	return fmt.Errorf("unknown Resource edge %s", name)
+}
+
+// TombstoneMutation represents an operation that mutates the Tombstone nodes in the graph.
+type TombstoneMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	uid           *string
+	name          *string
+	kind          *string
+	spec          *json.RawMessage
+	appendspec    json.RawMessage
+	deleted_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*Tombstone, error)
+	predicates    []predicate.Tombstone
+}
+
+var _ ent.Mutation = (*TombstoneMutation)(nil)
+
+// tombstoneOption allows management of the mutation configuration using functional options.
+type tombstoneOption func(*TombstoneMutation)
+
+// newTombstoneMutation creates new mutation for the Tombstone entity.
+func newTombstoneMutation(c config, op Op, opts ...tombstoneOption) *TombstoneMutation {
+	m := &TombstoneMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeTombstone,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withTombstoneID sets the ID field of the mutation.
+func withTombstoneID(id int) tombstoneOption {
+	return func(m *TombstoneMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Tombstone
+		)
+		m.oldValue = func(ctx context.Context) (*Tombstone, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Tombstone.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withTombstone sets the old Tombstone of the mutation.
+func withTombstone(node *Tombstone) tombstoneOption {
+	return func(m *TombstoneMutation) {
+		m.oldValue = func(context.Context) (*Tombstone, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m TombstoneMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
 }
 
-// ClearStatus clears the value of the "status" field.
-func (m *ResourceMutation) ClearStatus() {
-	m.status = nil
-	m.appendstatus = nil
-	m.clearedFields[resource.FieldStatus] = struct{}{}
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m TombstoneMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
 }
 
-// StatusCleared returns if the "status" field was cleared in this mutation.
-func (m *ResourceMutation) StatusCleared() bool {
-	_, ok := m.clearedFields[resource.FieldStatus]
-	return ok
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *TombstoneMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
 }
 
-// ResetStatus resets all changes to the "status" field.
-func (m *ResourceMutation) ResetStatus() {
-	m.status = nil
-	m.appendstatus = nil
-	delete(m.clearedFields, resource.FieldStatus)
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *TombstoneMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Tombstone.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *ResourceMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetUID sets the "uid" field.
+func (m *TombstoneMutation) SetUID(s string) {
+	m.uid = &s
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *ResourceMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// UID returns the value of the "uid" field in the mutation.
+func (m *TombstoneMutation) UID() (r string, exists bool) {
+	v := m.uid
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Resource entity.
-// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// OldUID returns the old "uid" field's value of the Tombstone entity.
+// If the Tombstone object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ResourceMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *TombstoneMutation) OldUID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldUID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldUID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldUID: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.UID, nil
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *ResourceMutation) ResetCreatedAt() {
-	m.created_at = nil
+// ResetUID resets all changes to the "uid" field.
+func (m *TombstoneMutation) ResetUID() {
+	m.uid = nil
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (m *ResourceMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// SetName sets the "name" field.
+func (m *TombstoneMutation) SetName(s string) {
+	m.name = &s
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *ResourceMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
+// Name returns the value of the "name" field in the mutation.
+func (m *TombstoneMutation) Name() (r string, exists bool) {
+	v := m.name
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the Resource entity.
-// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// OldName returns the old "name" field's value of the Tombstone entity.
+// If the Tombstone object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ResourceMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *TombstoneMutation) OldName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+		return v, errors.New("OldName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
 	}
-	return oldValue.UpdatedAt, nil
+	return oldValue.Name, nil
 }
 
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *ResourceMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+// ResetName resets all changes to the "name" field.
+func (m *TombstoneMutation) ResetName() {
+	m.name = nil
 }
 
-// SetResourceVersion sets the "resource_version" field.
-func (m *ResourceMutation) SetResourceVersion(s string) {
-	m.resource_version = &s
+// SetKind sets the "kind" field.
+func (m *TombstoneMutation) SetKind(s string) {
+	m.kind = &s
 }
 
-// ResourceVersion returns the value of the "resource_version" field in the mutation.
-func (m *ResourceMutation) ResourceVersion() (r string, exists bool) {
-	v := m.resource_version
+// Kind returns the value of the "kind" field in the mutation.
+func (m *TombstoneMutation) Kind() (r string, exists bool) {
+	v := m.kind
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldResourceVersion returns the old "resource_version" field's value of the Resource entity.
-// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// OldKind returns the old "kind" field's value of the Tombstone entity.
+// If the Tombstone object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ResourceMutation) OldResourceVersion(ctx context.Context) (v string, err error) {
+func (m *TombstoneMutation) OldKind(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldResourceVersion is only allowed on UpdateOne operations")
+		return v, errors.New("OldKind is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldResourceVersion requires an ID field in the mutation")
+		return v, errors.New("OldKind requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldResourceVersion: %w", err)
+		return v, fmt.Errorf("querying old value for OldKind: %w", err)
 	}
-	return oldValue.ResourceVersion, nil
+	return oldValue.Kind, nil
 }
 
-// ResetResourceVersion resets all changes to the "resource_version" field.
-func (m *ResourceMutation) ResetResourceVersion() {
-	m.resource_version = nil
+// ResetKind resets all changes to the "kind" field.
+func (m *TombstoneMutation) ResetKind() {
+	m.kind = nil
 }
 
-// SetNamespace sets the "namespace" field.
-func (m *ResourceMutation) SetNamespace(s string) {
-	m.namespace = &s
+// SetSpec sets the "spec" field.
+func (m *TombstoneMutation) SetSpec(j json.RawMessage) {
+	m.spec = &j
+	m.appendspec = nil
 }
 
-// Namespace returns the value of the "namespace" field in the mutation.
-func (m *ResourceMutation) Namespace() (r string, exists bool) {
-	v := m.namespace
+// Spec returns the value of the "spec" field in the mutation.
+func (m *TombstoneMutation) Spec() (r json.RawMessage, exists bool) {
+	v := m.spec
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldNamespace returns the old "namespace" field's value of the Resource entity.
-// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// OldSpec returns the old "spec" field's value of the Tombstone entity.
+// If the Tombstone object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ResourceMutation) OldNamespace(ctx context.Context) (v string, err error) {
+func (m *TombstoneMutation) OldSpec(ctx context.Context) (v json.RawMessage, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldNamespace is only allowed on UpdateOne operations")
+		return v, errors.New("OldSpec is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldNamespace requires an ID field in the mutation")
+		return v, errors.New("OldSpec requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldNamespace: %w", err)
-	}
-	return oldValue.Namespace, nil
-}
-
-// ClearNamespace clears the value of the "namespace" field.
-func (m *ResourceMutation) ClearNamespace() {
-	m.namespace = nil
-	m.clearedFields[resource.FieldNamespace] = struct{}{}
-}
-
-// NamespaceCleared returns if the "namespace" field was cleared in this mutation.
-func (m *ResourceMutation) NamespaceCleared() bool {
-	_, ok := m.clearedFields[resource.FieldNamespace]
-	return ok
-}
-
-// ResetNamespace resets all changes to the "namespace" field.
-func (m *ResourceMutation) ResetNamespace() {
-	m.namespace = nil
-	delete(m.clearedFields, resource.FieldNamespace)
-}
-
-// AddLabelIDs adds the "labels" edge to the Label entity by ids.
-func (m *ResourceMutation) AddLabelIDs(ids ...int) {
-	if m.labels == nil {
-		m.labels = make(map[int]struct{})
-	}
-	for i := range ids {
-		m.labels[ids[i]] = struct{}{}
-	}
-}
-
-// ClearLabels clears the "labels" edge to the Label entity.
-func (m *ResourceMutation) ClearLabels() {
-	m.clearedlabels = true
-}
-
-// LabelsCleared reports if the "labels" edge to the Label entity was cleared.
-func (m *ResourceMutation) LabelsCleared() bool {
-	return m.clearedlabels
-}
-
-// RemoveLabelIDs removes the "labels" edge to the Label entity by IDs.
-func (m *ResourceMutation) RemoveLabelIDs(ids ...int) {
-	if m.removedlabels == nil {
-		m.removedlabels = make(map[int]struct{})
-	}
-	for i := range ids {
-		delete(m.labels, ids[i])
-		m.removedlabels[ids[i]] = struct{}{}
+		return v, fmt.Errorf("querying old value for OldSpec: %w", err)
 	}
+	return oldValue.Spec, nil
 }
 
-// RemovedLabels returns the removed IDs of the "labels" edge to the Label entity.
-func (m *ResourceMutation) RemovedLabelsIDs() (ids []int) {
-	for id := range m.removedlabels {
-		ids = append(ids, id)
-	}
-	return
+// AppendSpec adds j to the "spec" field.
+func (m *TombstoneMutation) AppendSpec(j json.RawMessage) {
+	m.appendspec = append(m.appendspec, j...)
 }
 
-// LabelsIDs returns the "labels" edge IDs in the mutation.
-func (m *ResourceMutation) LabelsIDs() (ids []int) {
-	for id := range m.labels {
-		ids = append(ids, id)
+// AppendedSpec returns the list of values that were appended to the "spec" field in this mutation.
+func (m *TombstoneMutation) AppendedSpec() (json.RawMessage, bool) {
+	if len(m.appendspec) == 0 {
+		return nil, false
 	}
-	return
-}
-
-// ResetLabels resets all changes to the "labels" edge.
-func (m *ResourceMutation) ResetLabels() {
-	m.labels = nil
-	m.clearedlabels = false
-	m.removedlabels = nil
+	return m.appendspec, true
 }
 
-// AddAnnotationIDs adds the "annotations" edge to the Annotation entity by ids.
-func (m *ResourceMutation) AddAnnotationIDs(ids ...int) {
-	if m.annotations == nil {
-		m.annotations = make(map[int]struct{})
-	}
-	for i := range ids {
-		m.annotations[ids[i]] = struct{}{}
-	}
+// ClearSpec clears the value of the "spec" field.
+func (m *TombstoneMutation) ClearSpec() {
+	m.spec = nil
+	m.appendspec = nil
+	m.clearedFields[tombstone.FieldSpec] = struct{}{}
 }
 
-// ClearAnnotations clears the "annotations" edge to the Annotation entity.
-func (m *ResourceMutation) ClearAnnotations() {
-	m.clearedannotations = true
+// SpecCleared returns if the "spec" field was cleared in this mutation.
+func (m *TombstoneMutation) SpecCleared() bool {
+	_, ok := m.clearedFields[tombstone.FieldSpec]
+	return ok
 }
 
-// AnnotationsCleared reports if the "annotations" edge to the Annotation entity was cleared.
-func (m *ResourceMutation) AnnotationsCleared() bool {
-	return m.clearedannotations
+// ResetSpec resets all changes to the "spec" field.
+func (m *TombstoneMutation) ResetSpec() {
+	m.spec = nil
+	m.appendspec = nil
+	delete(m.clearedFields, tombstone.FieldSpec)
 }
 
-// RemoveAnnotationIDs removes the "annotations" edge to the Annotation entity by IDs.
-func (m *ResourceMutation) RemoveAnnotationIDs(ids ...int) {
-	if m.removedannotations == nil {
-		m.removedannotations = make(map[int]struct{})
-	}
-	for i := range ids {
-		delete(m.annotations, ids[i])
-		m.removedannotations[ids[i]] = struct{}{}
-	}
+// SetDeletedAt sets the "deleted_at" field.
+func (m *TombstoneMutation) SetDeletedAt(t time.Time) {
+	m.deleted_at = &t
 }
 
-// RemovedAnnotations returns the removed IDs of the "annotations" edge to the Annotation entity.
-func (m *ResourceMutation) RemovedAnnotationsIDs() (ids []int) {
-	for id := range m.removedannotations {
-		ids = append(ids, id)
+// DeletedAt returns the value of the "deleted_at" field in the mutation.
+func (m *TombstoneMutation) DeletedAt() (r time.Time, exists bool) {
+	v := m.deleted_at
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// AnnotationsIDs returns the "annotations" edge IDs in the mutation.
-func (m *ResourceMutation) AnnotationsIDs() (ids []int) {
-	for id := range m.annotations {
-		ids = append(ids, id)
+// OldDeletedAt returns the old "deleted_at" field's value of the Tombstone entity.
+// If the Tombstone object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TombstoneMutation) OldDeletedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
+	}
+	return oldValue.DeletedAt, nil
 }
 
-// ResetAnnotations resets all changes to the "annotations" edge.
-func (m *ResourceMutation) ResetAnnotations() {
-	m.annotations = nil
-	m.clearedannotations = false
-	m.removedannotations = nil
+// ResetDeletedAt resets all changes to the "deleted_at" field.
+func (m *TombstoneMutation) ResetDeletedAt() {
+	m.deleted_at = nil
 }
 
-// Where appends a list predicates to the ResourceMutation builder.
-func (m *ResourceMutation) Where(ps ...predicate.Resource) {
+// Where appends a list predicates to the TombstoneMutation builder.
+func (m *TombstoneMutation) Where(ps ...predicate.Tombstone) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the ResourceMutation builder. Using this method,
+// WhereP appends storage-level predicates to the TombstoneMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *ResourceMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Resource, len(ps))
+func (m *TombstoneMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Tombstone, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -1636,57 +3407,39 @@ func (m *ResourceMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *ResourceMutation) Op() Op {
+func (m *TombstoneMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *ResourceMutation) SetOp(op Op) {
+func (m *TombstoneMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Resource).
-func (m *ResourceMutation) Type() string {
+// Type returns the node type of this mutation (Tombstone).
+func (m *TombstoneMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *ResourceMutation) Fields() []string {
-	fields := make([]string, 0, 11)
+func (m *TombstoneMutation) Fields() []string {
+	fields := make([]string, 0, 5)
 	if m.uid != nil {
-		fields = append(fields, resource.FieldUID)
+		fields = append(fields, tombstone.FieldUID)
 	}
 	if m.name != nil {
-		fields = append(fields, resource.FieldName)
-	}
-	if m.api_version != nil {
-		fields = append(fields, resource.FieldAPIVersion)
+		fields = append(fields, tombstone.FieldName)
 	}
 	if m.kind != nil {
-		fields = append(fields, resource.FieldKind)
-	}
-	if m.resource_type != nil {
-		fields = append(fields, resource.FieldResourceType)
+		fields = append(fields, tombstone.FieldKind)
 	}
 	if m.spec != nil {
-		fields = append(fields, resource.FieldSpec)
-	}
-	if m.status != nil {
-		fields = append(fields, resource.FieldStatus)
-	}
-	if m.created_at != nil {
-		fields = append(fields, resource.FieldCreatedAt)
+		fields = append(fields, tombstone.FieldSpec)
 	}
-	if m.updated_at != nil {
-		fields = append(fields, resource.FieldUpdatedAt)
-	}
-	if m.resource_version != nil {
-		fields = append(fields, resource.FieldResourceVersion)
-	}
-	if m.namespace != nil {
-		fields = append(fields, resource.FieldNamespace)
+	if m.deleted_at != nil {
+		fields = append(fields, tombstone.FieldDeletedAt)
 	}
 	return fields
 }
@@ -1694,30 +3447,18 @@ func (m *ResourceMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *ResourceMutation) Field(name string) (ent.Value, bool) {
+func (m *TombstoneMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case resource.FieldUID:
+	case tombstone.FieldUID:
 		return m.UID()
-	case resource.FieldName:
+	case tombstone.FieldName:
 		return m.Name()
-	case resource.FieldAPIVersion:
-		return m.APIVersion()
-	case resource.FieldKind:
+	case tombstone.FieldKind:
 		return m.Kind()
-	case resource.FieldResourceType:
-		return m.ResourceType()
-	case resource.FieldSpec:
+	case tombstone.FieldSpec:
 		return m.Spec()
-	case resource.FieldStatus:
-		return m.Status()
-	case resource.FieldCreatedAt:
-		return m.CreatedAt()
-	case resource.FieldUpdatedAt:
-		return m.UpdatedAt()
-	case resource.FieldResourceVersion:
-		return m.ResourceVersion()
-	case resource.FieldNamespace:
-		return m.Namespace()
+	case tombstone.FieldDeletedAt:
+		return m.DeletedAt()
 	}
 	return nil, false
 }
@@ -1725,323 +3466,183 @@ func (m *ResourceMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *ResourceMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *TombstoneMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case resource.FieldUID:
+	case tombstone.FieldUID:
 		return m.OldUID(ctx)
-	case resource.FieldName:
+	case tombstone.FieldName:
 		return m.OldName(ctx)
-	case resource.FieldAPIVersion:
-		return m.OldAPIVersion(ctx)
-	case resource.FieldKind:
+	case tombstone.FieldKind:
 		return m.OldKind(ctx)
-	case resource.FieldResourceType:
-		return m.OldResourceType(ctx)
-	case resource.FieldSpec:
+	case tombstone.FieldSpec:
 		return m.OldSpec(ctx)
-	case resource.FieldStatus:
-		return m.OldStatus(ctx)
-	case resource.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
-	case resource.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
-	case resource.FieldResourceVersion:
-		return m.OldResourceVersion(ctx)
-	case resource.FieldNamespace:
-		return m.OldNamespace(ctx)
+	case tombstone.FieldDeletedAt:
+		return m.OldDeletedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown Resource field %s", name)
+	return nil, fmt.Errorf("unknown Tombstone field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ResourceMutation) SetField(name string, value ent.Value) error {
+func (m *TombstoneMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case resource.FieldUID:
+	case tombstone.FieldUID:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetUID(v)
 		return nil
-	case resource.FieldName:
+	case tombstone.FieldName:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetName(v)
 		return nil
-	case resource.FieldAPIVersion:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetAPIVersion(v)
-		return nil
-	case resource.FieldKind:
+	case tombstone.FieldKind:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetKind(v)
 		return nil
-	case resource.FieldResourceType:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetResourceType(v)
-		return nil
-	case resource.FieldSpec:
+	case tombstone.FieldSpec:
 		v, ok := value.(json.RawMessage)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetSpec(v)
 		return nil
-	case resource.FieldStatus:
-		v, ok := value.(json.RawMessage)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetStatus(v)
-		return nil
-	case resource.FieldCreatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCreatedAt(v)
-		return nil
-	case resource.FieldUpdatedAt:
+	case tombstone.FieldDeletedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUpdatedAt(v)
-		return nil
-	case resource.FieldResourceVersion:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetResourceVersion(v)
-		return nil
-	case resource.FieldNamespace:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetNamespace(v)
+		m.SetDeletedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Resource field %s", name)
+	return fmt.Errorf("unknown Tombstone field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *ResourceMutation) AddedFields() []string {
+func (m *TombstoneMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *ResourceMutation) AddedField(name string) (ent.Value, bool) {
+func (m *TombstoneMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ResourceMutation) AddField(name string, value ent.Value) error {
+func (m *TombstoneMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Resource numeric field %s", name)
+	return fmt.Errorf("unknown Tombstone numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *ResourceMutation) ClearedFields() []string {
+func (m *TombstoneMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(resource.FieldStatus) {
-		fields = append(fields, resource.FieldStatus)
-	}
-	if m.FieldCleared(resource.FieldNamespace) {
-		fields = append(fields, resource.FieldNamespace)
+	if m.FieldCleared(tombstone.FieldSpec) {
+		fields = append(fields, tombstone.FieldSpec)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *ResourceMutation) FieldCleared(name string) bool {
+func (m *TombstoneMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *ResourceMutation) ClearField(name string) error {
+func (m *TombstoneMutation) ClearField(name string) error {
 	switch name {
-	case resource.FieldStatus:
-		m.ClearStatus()
-		return nil
-	case resource.FieldNamespace:
-		m.ClearNamespace()
+	case tombstone.FieldSpec:
+		m.ClearSpec()
 		return nil
 	}
-	return fmt.Errorf("unknown Resource nullable field %s", name)
+	return fmt.Errorf("unknown Tombstone nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *ResourceMutation) ResetField(name string) error {
+func (m *TombstoneMutation) ResetField(name string) error {
 	switch name {
-	case resource.FieldUID:
+	case tombstone.FieldUID:
 		m.ResetUID()
 		return nil
-	case resource.FieldName:
+	case tombstone.FieldName:
 		m.ResetName()
 		return nil
-	case resource.FieldAPIVersion:
-		m.ResetAPIVersion()
-		return nil
-	case resource.FieldKind:
+	case tombstone.FieldKind:
 		m.ResetKind()
 		return nil
-	case resource.FieldResourceType:
-		m.ResetResourceType()
-		return nil
-	case resource.FieldSpec:
+	case tombstone.FieldSpec:
 		m.ResetSpec()
 		return nil
-	case resource.FieldStatus:
-		m.ResetStatus()
-		return nil
-	case resource.FieldCreatedAt:
-		m.ResetCreatedAt()
-		return nil
-	case resource.FieldUpdatedAt:
-		m.ResetUpdatedAt()
-		return nil
-	case resource.FieldResourceVersion:
-		m.ResetResourceVersion()
-		return nil
-	case resource.FieldNamespace:
-		m.ResetNamespace()
+	case tombstone.FieldDeletedAt:
+		m.ResetDeletedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Resource field %s", name)
+	return fmt.Errorf("unknown Tombstone field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *ResourceMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.labels != nil {
-		edges = append(edges, resource.EdgeLabels)
-	}
-	if m.annotations != nil {
-		edges = append(edges, resource.EdgeAnnotations)
-	}
+func (m *TombstoneMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *ResourceMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case resource.EdgeLabels:
-		ids := make([]ent.Value, 0, len(m.labels))
-		for id := range m.labels {
-			ids = append(ids, id)
-		}
-		return ids
-	case resource.EdgeAnnotations:
-		ids := make([]ent.Value, 0, len(m.annotations))
-		for id := range m.annotations {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *TombstoneMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *ResourceMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.removedlabels != nil {
-		edges = append(edges, resource.EdgeLabels)
-	}
-	if m.removedannotations != nil {
-		edges = append(edges, resource.EdgeAnnotations)
-	}
+func (m *TombstoneMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *ResourceMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case resource.EdgeLabels:
-		ids := make([]ent.Value, 0, len(m.removedlabels))
-		for id := range m.removedlabels {
-			ids = append(ids, id)
-		}
-		return ids
-	case resource.EdgeAnnotations:
-		ids := make([]ent.Value, 0, len(m.removedannotations))
-		for id := range m.removedannotations {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *TombstoneMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *ResourceMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.clearedlabels {
-		edges = append(edges, resource.EdgeLabels)
-	}
-	if m.clearedannotations {
-		edges = append(edges, resource.EdgeAnnotations)
-	}
+func (m *TombstoneMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *ResourceMutation) EdgeCleared(name string) bool {
-	switch name {
-	case resource.EdgeLabels:
-		return m.clearedlabels
-	case resource.EdgeAnnotations:
-		return m.clearedannotations
-	}
+func (m *TombstoneMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *ResourceMutation) ClearEdge(name string) error {
-	switch name {
-	}
-	return fmt.Errorf("unknown Resource unique edge %s", name)
+func (m *TombstoneMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Tombstone unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *ResourceMutation) ResetEdge(name string) error {
-	switch name {
-	case resource.EdgeLabels:
-		m.ResetLabels()
-		return nil
-	case resource.EdgeAnnotations:
-		m.ResetAnnotations()
-		return nil
-	}
-	return fmt.Errorf("unknown Resource edge %s", name)
+func (m *TombstoneMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Tombstone edge %s", name)
 }
@@ -13,8 +13,17 @@ import (
 // Annotation is the predicate function for annotation builders.
 type Annotation func(*sql.Selector)
 
+// DeviceIdentity is the predicate function for deviceidentity builders.
+type DeviceIdentity func(*sql.Selector)
+
 // Label is the predicate function for label builders.
 type Label func(*sql.Selector)
 
+// OutboxEvent is the predicate function for outboxevent builders.
+type OutboxEvent func(*sql.Selector)
+
 // Resource is the predicate function for resource builders.
 type Resource func(*sql.Selector)
+
+// Tombstone is the predicate function for tombstone builders.
+type Tombstone func(*sql.Selector)
@@ -10,9 +10,12 @@ import (
 	"time"
 
 	"github.com/example/fru-tracker/internal/storage/ent/annotation"
+	"github.com/example/fru-tracker/internal/storage/ent/deviceidentity"
 	"github.com/example/fru-tracker/internal/storage/ent/label"
+	"github.com/example/fru-tracker/internal/storage/ent/outboxevent"
 	"github.com/example/fru-tracker/internal/storage/ent/resource"
 	"github.com/example/fru-tracker/internal/storage/ent/schema"
+	"github.com/example/fru-tracker/internal/storage/ent/tombstone"
 )
 
 // The init function reads all schema descriptors with runtime code
@@ -39,6 +42,20 @@ func init() {
 			return nil
 		}
 	}()
+	deviceidentityFields := schema.DeviceIdentity{}.Fields()
+	_ = deviceidentityFields
+	// deviceidentityDescScheme is the schema descriptor for scheme field.
+	deviceidentityDescScheme := deviceidentityFields[0].Descriptor()
+	// deviceidentity.SchemeValidator is a validator for the "scheme" field. It is called by the builders before save.
+	deviceidentity.SchemeValidator = deviceidentityDescScheme.Validators[0].(func(string) error)
+	// deviceidentityDescValue is the schema descriptor for value field.
+	deviceidentityDescValue := deviceidentityFields[1].Descriptor()
+	// deviceidentity.ValueValidator is a validator for the "value" field. It is called by the builders before save.
+	deviceidentity.ValueValidator = deviceidentityDescValue.Validators[0].(func(string) error)
+	// deviceidentityDescDeviceUID is the schema descriptor for device_uid field.
+	deviceidentityDescDeviceUID := deviceidentityFields[2].Descriptor()
+	// deviceidentity.DeviceUIDValidator is a validator for the "device_uid" field. It is called by the builders before save.
+	deviceidentity.DeviceUIDValidator = deviceidentityDescDeviceUID.Validators[0].(func(string) error)
 	labelFields := schema.Label{}.Fields()
 	_ = labelFields
 	// labelDescKey is the schema descriptor for key field.
@@ -63,6 +80,20 @@ func init() {
 	labelDescValue := labelFields[1].Descriptor()
 	// label.ValueValidator is a validator for the "value" field. It is called by the builders before save.
 	label.ValueValidator = labelDescValue.Validators[0].(func(string) error)
+	outboxeventFields := schema.OutboxEvent{}.Fields()
+	_ = outboxeventFields
+	// outboxeventDescEventID is the schema descriptor for event_id field.
+	outboxeventDescEventID := outboxeventFields[0].Descriptor()
+	// outboxevent.EventIDValidator is a validator for the "event_id" field. It is called by the builders before save.
+	outboxevent.EventIDValidator = outboxeventDescEventID.Validators[0].(func(string) error)
+	// outboxeventDescType is the schema descriptor for type field.
+	outboxeventDescType := outboxeventFields[1].Descriptor()
+	// outboxevent.TypeValidator is a validator for the "type" field. It is called by the builders before save.
+	outboxevent.TypeValidator = outboxeventDescType.Validators[0].(func(string) error)
+	// outboxeventDescCreatedAt is the schema descriptor for created_at field.
+	outboxeventDescCreatedAt := outboxeventFields[3].Descriptor()
+	// outboxevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	outboxevent.DefaultCreatedAt = outboxeventDescCreatedAt.Default.(func() time.Time)
 	resourceFields := schema.Resource{}.Fields()
 	_ = resourceFields
 	// resourceDescUID is the schema descriptor for uid field.
@@ -113,4 +144,18 @@ func init() {
 	resourceDescResourceVersion := resourceFields[9].Descriptor()
 	// resource.DefaultResourceVersion holds the default value on creation for the resource_version field.
 	resource.DefaultResourceVersion = resourceDescResourceVersion.Default.(string)
+	tombstoneFields := schema.Tombstone{}.Fields()
+	_ = tombstoneFields
+	// tombstoneDescUID is the schema descriptor for uid field.
+	tombstoneDescUID := tombstoneFields[0].Descriptor()
+	// tombstone.UIDValidator is a validator for the "uid" field. It is called by the builders before save.
+	tombstone.UIDValidator = tombstoneDescUID.Validators[0].(func(string) error)
+	// tombstoneDescKind is the schema descriptor for kind field.
+	tombstoneDescKind := tombstoneFields[2].Descriptor()
+	// tombstone.KindValidator is a validator for the "kind" field. It is called by the builders before save.
+	tombstone.KindValidator = tombstoneDescKind.Validators[0].(func(string) error)
+	// tombstoneDescDeletedAt is the schema descriptor for deleted_at field.
+	tombstoneDescDeletedAt := tombstoneFields[4].Descriptor()
+	// tombstone.DefaultDeletedAt holds the default value on creation for the deleted_at field.
+	tombstone.DefaultDeletedAt = tombstoneDescDeletedAt.Default.(func() time.Time)
 }
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// DeviceIdentity claims a serial number for one Device. Its
+// unique index is what stops two devices from sharing an identity, however
+// many writers race to create them.
+type DeviceIdentity struct {
+	ent.Schema
+}
+
+// Fields of the DeviceIdentity.
+func (DeviceIdentity) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("scheme").
+			NotEmpty().
+			Immutable().
+			Comment("What the value is: serial"),
+
+		field.String("value").
+			NotEmpty().
+			Immutable().
+			Comment("The serial number"),
+
+		field.String("device_uid").
+			NotEmpty().
+			Immutable().
+			Comment("UID of the Device holding the identity"),
+	}
+}
+
+// Indexes of the DeviceIdentity.
+func (DeviceIdentity) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("scheme", "value").Unique(),
+		index.Fields("device_uid"),
+		index.Fields("value"),
+	}
+}
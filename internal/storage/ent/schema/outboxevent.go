@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// OutboxEvent is a published event waiting to be (or already) handed to
+// subscribers. Writing events here first lets delivery resume after a restart.
+type OutboxEvent struct {
+	ent.Schema
+}
+
+// Fields of the OutboxEvent.
+func (OutboxEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("event_id").
+			NotEmpty().
+			Immutable().
+			Comment("CloudEvents ID of the event"),
+
+		field.String("type").
+			NotEmpty().
+			Immutable().
+			Comment("CloudEvents type of the event"),
+
+		field.JSON("payload", json.RawMessage{}).
+			SchemaType(jsonText).
+			Immutable().
+			Comment("The complete event in CloudEvents JSON form"),
+
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now).
+			Comment("Publish timestamp"),
+
+		field.Time("delivered_at").
+			Optional().
+			Nillable().
+			Comment("When the event was handed to subscribers; null while pending"),
+	}
+}
+
+// Indexes of the OutboxEvent.
+func (OutboxEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("delivered_at"),
+	}
+}
@@ -16,11 +16,17 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 )
 
+// jsonText stores JSON columns as text on PostgreSQL, as SQLite does, so they
+// read back byte for byte. jsonb would reorder keys and respace raw property
+// values, changing the ETag of a resource that was never modified.
+var jsonText = map[string]string{dialect.Postgres: "json"}
+
 // Resource holds the schema definition for the generic resource entity.
 // This schema stores Kubernetes-style resources with Spec and Status as JSON.
 type Resource struct {
@@ -57,9 +63,11 @@ func (Resource) Fields() []ent.Field {
 
 		// Kubernetes-style Spec and Status (stored as JSON)
 		field.JSON("spec", json.RawMessage{}).
+			SchemaType(jsonText).
 			Comment("Desired state of the resource"),
 
 		field.JSON("status", json.RawMessage{}).
+			SchemaType(jsonText).
 			Optional().
 			Comment("Observed state of the resource"),
 
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Tombstone records a resource that has been deleted from storage so that
+// incremental exports can report the deletion to downstream consumers.
+type Tombstone struct {
+	ent.Schema
+}
+
+// Fields of the Tombstone.
+func (Tombstone) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("uid").
+			NotEmpty().
+			Immutable().
+			Comment("UID of the deleted resource"),
+
+		field.String("name").
+			Immutable().
+			Comment("Name of the deleted resource"),
+
+		field.String("kind").
+			NotEmpty().
+			Immutable().
+			Comment("Kind of the deleted resource"),
+
+		field.JSON("spec", json.RawMessage{}).
+			SchemaType(jsonText).
+			Optional().
+			Immutable().
+			Comment("Last known spec of the deleted resource"),
+
+		field.Time("deleted_at").
+			Immutable().
+			Default(time.Now).
+			Comment("Deletion timestamp"),
+	}
+}
+
+// Indexes of the Tombstone.
+func (Tombstone) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("kind", "deleted_at"),
+		index.Fields("uid"),
+	}
+}
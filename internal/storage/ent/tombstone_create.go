@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/example/fru-tracker/internal/storage/ent/tombstone"
+)
+
+// TombstoneCreate is the builder for creating a Tombstone entity.
+type TombstoneCreate struct {
+	config
+	mutation *TombstoneMutation
+	hooks    []Hook
+}
+
+// SetUID sets the "uid" field.
+func (_c *TombstoneCreate) SetUID(v string) *TombstoneCreate {
+	_c.mutation.SetUID(v)
+	return _c
+}
+
+// SetName sets the "name" field.
+func (_c *TombstoneCreate) SetName(v string) *TombstoneCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetKind sets the "kind" field.
+func (_c *TombstoneCreate) SetKind(v string) *TombstoneCreate {
+	_c.mutation.SetKind(v)
+	return _c
+}
+
+// SetSpec sets the "spec" field.
+func (_c *TombstoneCreate) SetSpec(v json.RawMessage) *TombstoneCreate {
+	_c.mutation.SetSpec(v)
+	return _c
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (_c *TombstoneCreate) SetDeletedAt(v time.Time) *TombstoneCreate {
+	_c.mutation.SetDeletedAt(v)
+	return _c
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (_c *TombstoneCreate) SetNillableDeletedAt(v *time.Time) *TombstoneCreate {
+	if v != nil {
+		_c.SetDeletedAt(*v)
+	}
+	return _c
+}
+
+// Mutation returns the TombstoneMutation object of the builder.
+func (_c *TombstoneCreate) Mutation() *TombstoneMutation {
+	return _c.mutation
+}
+
+// Save creates the Tombstone in the database.
+func (_c *TombstoneCreate) Save(ctx context.Context) (*Tombstone, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *TombstoneCreate) SaveX(ctx context.Context) *Tombstone {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TombstoneCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TombstoneCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *TombstoneCreate) defaults() {
+	if _, ok := _c.mutation.DeletedAt(); !ok {
+		v := tombstone.DefaultDeletedAt()
+		_c.mutation.SetDeletedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *TombstoneCreate) check() error {
+	if _, ok := _c.mutation.UID(); !ok {
+		return &ValidationError{Name: "uid", err: errors.New(`ent: missing required field "Tombstone.uid"`)}
+	}
+	if v, ok := _c.mutation.UID(); ok {
+		if err := tombstone.UIDValidator(v); err != nil {
+			return &ValidationError{Name: "uid", err: fmt.Errorf(`ent: validator failed for field "Tombstone.uid": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Tombstone.name"`)}
+	}
+	if _, ok := _c.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "Tombstone.kind"`)}
+	}
+	if v, ok := _c.mutation.Kind(); ok {
+		if err := tombstone.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "Tombstone.kind": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.DeletedAt(); !ok {
+		return &ValidationError{Name: "deleted_at", err: errors.New(`ent: missing required field "Tombstone.deleted_at"`)}
+	}
+	return nil
+}
+
+func (_c *TombstoneCreate) sqlSave(ctx context.Context) (*Tombstone, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *TombstoneCreate) createSpec() (*Tombstone, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Tombstone{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(tombstone.Table, sqlgraph.NewFieldSpec(tombstone.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.UID(); ok {
+		_spec.SetField(tombstone.FieldUID, field.TypeString, value)
+		_node.UID = value
+	}
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(tombstone.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.Kind(); ok {
+		_spec.SetField(tombstone.FieldKind, field.TypeString, value)
+		_node.Kind = value
+	}
+	if value, ok := _c.mutation.Spec(); ok {
+		_spec.SetField(tombstone.FieldSpec, field.TypeJSON, value)
+		_node.Spec = value
+	}
+	if value, ok := _c.mutation.DeletedAt(); ok {
+		_spec.SetField(tombstone.FieldDeletedAt, field.TypeTime, value)
+		_node.DeletedAt = value
+	}
+	return _node, _spec
+}
+
+// TombstoneCreateBulk is the builder for creating many Tombstone entities in bulk.
+type TombstoneCreateBulk struct {
+	config
+	err      error
+	builders []*TombstoneCreate
+}
+
+// Save creates the Tombstone entities in the database.
+func (_c *TombstoneCreateBulk) Save(ctx context.Context) ([]*Tombstone, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Tombstone, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TombstoneMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *TombstoneCreateBulk) SaveX(ctx context.Context) []*Tombstone {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TombstoneCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TombstoneCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -18,10 +18,16 @@ type Tx struct {
 	config
 	// Annotation is the client for interacting with the Annotation builders.
 	Annotation *AnnotationClient
+	// DeviceIdentity is the client for interacting with the DeviceIdentity builders.
+	DeviceIdentity *DeviceIdentityClient
 	// Label is the client for interacting with the Label builders.
 	Label *LabelClient
+	// OutboxEvent is the client for interacting with the OutboxEvent builders.
+	OutboxEvent *OutboxEventClient
 	// Resource is the client for interacting with the Resource builders.
 	Resource *ResourceClient
+	// Tombstone is the client for interacting with the Tombstone builders.
+	Tombstone *TombstoneClient
 
 	// lazily loaded.
 	client     *Client
@@ -154,8 +160,11 @@ func (tx *Tx) Client() *Client {
 
 func (tx *Tx) init() {
 	tx.Annotation = NewAnnotationClient(tx.config)
+	tx.DeviceIdentity = NewDeviceIdentityClient(tx.config)
 	tx.Label = NewLabelClient(tx.config)
+	tx.OutboxEvent = NewOutboxEventClient(tx.config)
 	tx.Resource = NewResourceClient(tx.config)
+	tx.Tombstone = NewTombstoneClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
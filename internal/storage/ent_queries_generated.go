@@ -135,3 +135,50 @@ func ListdiscoverysnapshotsByLabels(ctx context.Context, labels map[string]strin
 	}
 	return out, nil
 }
+
+// Querywebhooksubscriptions returns a query builder for webhooksubscriptions
+func Querywebhooksubscriptions(ctx context.Context) *ent.ResourceQuery {
+	return QueryResources(ctx, "WebhookSubscription")
+}
+
+// GetWebhookSubscriptionByUID loads a single WebhookSubscription by UID
+func GetWebhookSubscriptionByUID(ctx context.Context, uid string) (*v1.WebhookSubscription, error) {
+	ensureEntClient()
+	r, err := entClient.Resource.Query().
+		Where(entresource.UIDEQ(uid), entresource.KindEQ("WebhookSubscription")).
+		WithLabels().
+		WithAnnotations().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to load WebhookSubscription %s: %w", uid, err)
+	}
+	v, err := FromEntResource(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*v1.WebhookSubscription), nil
+}
+
+// ListwebhooksubscriptionsByLabels returns webhooksubscriptions matching all provided labels
+func ListwebhooksubscriptionsByLabels(ctx context.Context, labels map[string]string) ([]*v1.WebhookSubscription, error) {
+	q, err := QueryResourcesByLabels(ctx, "WebhookSubscription", labels)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := q.WithLabels().WithAnnotations().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*v1.WebhookSubscription, 0, len(rs))
+	for _, r := range rs {
+		v, err := FromEntResource(ctx, r)
+		if err != nil {
+			continue
+		}
+		out = append(out, v.(*v1.WebhookSubscription))
+	}
+	return out, nil
+}
@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// Ping runs a trivial query to check that the database answers, for example
+// that SQLite is not locked by another process or PostgreSQL is reachable.
+func Ping(ctx context.Context) error {
+	if err := ensureBackendReady(); err != nil {
+		return err
+	}
+	if _, err := entClient.Resource.Query().Limit(1).IDs(ctx); err != nil {
+		return fmt.Errorf("database query failed: %w", err)
+	}
+	return nil
+}
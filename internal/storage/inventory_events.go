@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage/ent"
+	"github.com/example/fru-tracker/internal/storage/ent/hook"
+	"github.com/openchami/fabrica/pkg/events"
+)
+
+// InventoryEventSource is the CloudEvents source of every inventory event.
+const InventoryEventSource = "/fru-tracker/inventory"
+
+type snapshotKey struct{}
+
+// WithSnapshotUID returns a context whose device writes are attributed to
+// the discovery snapshot uid in the inventory events they raise.
+func WithSnapshotUID(ctx context.Context, uid string) context.Context {
+	return context.WithValue(ctx, snapshotKey{}, uid)
+}
+
+// InventoryChanges returns the inventory events describing how device differs
+// from its previous spec, by event type. A first parent link or a first part
+// number is not a change: only replacing an existing value is.
+func InventoryChanges(device *v1.Device, previous v1.DeviceSpec) map[string]v1.DeviceChange {
+	changes := make(map[string]v1.DeviceChange)
+	if previous.ParentID != "" && device.Spec.ParentID != "" && previous.ParentID != device.Spec.ParentID {
+		change := v1.NewDeviceChange(device)
+		change.PreviousParentID = previous.ParentID
+		changes[v1.DeviceReparentedEventType] = change
+	}
+	if previous.PartNumber != "" && device.Spec.PartNumber != "" && previous.PartNumber != device.Spec.PartNumber {
+		change := v1.NewDeviceChange(device)
+		change.PreviousPartNumber = previous.PartNumber
+		changes[v1.DevicePartNumberChangedEventType] = change
+	}
+	return changes
+}
+
+// RecordInventoryChanges installs a hook on client that records the
+// re-parent and part number change events of every Device update made
+// through it in the outbox, in the same transaction as the update. Discovery
+// snapshots and direct API edits alike raise them, whichever code path
+// performs the write.
+func (b *DurableEventBus) RecordInventoryChanges(client *ent.Client) {
+	client.Resource.Use(b.inventoryChangeHook)
+}
+
+func (b *DurableEventBus) inventoryChangeHook(next ent.Mutator) ent.Mutator {
+	return hook.ResourceFunc(func(ctx context.Context, m *ent.ResourceMutation) (ent.Value, error) {
+		spec, ok := m.Spec()
+		if !ok || !m.Op().Is(ent.OpUpdateOne) {
+			return next.Mutate(ctx, m)
+		}
+		kind, uid, oldSpec, err := mutatedResource(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		if kind != "Device" {
+			return next.Mutate(ctx, m)
+		}
+
+		value, err := next.Mutate(ctx, m)
+		if err != nil {
+			return value, err
+		}
+
+		var previous v1.DeviceSpec
+		device := &v1.Device{}
+		if json.Unmarshal(oldSpec, &previous) != nil || json.Unmarshal(spec, &device.Spec) != nil {
+			// Specs are written by this package, so this never happens in practice
+			return value, nil
+		}
+		device.Metadata.UID = uid
+		if updated, ok := value.(*ent.Resource); ok {
+			device.Metadata.Name = updated.Name
+		}
+
+		changes := InventoryChanges(device, previous)
+		if len(changes) == 0 {
+			return value, nil
+		}
+		snapshotUID, _ := ctx.Value(snapshotKey{}).(string)
+		for _, eventType := range v1.InventoryEventTypes {
+			change, ok := changes[eventType]
+			if !ok {
+				continue
+			}
+			change.SnapshotUID = snapshotUID
+			event, err := events.NewEvent(eventType, InventoryEventSource, change)
+			if err != nil {
+				return value, fmt.Errorf("failed to create %s event: %w", eventType, err)
+			}
+			if err := recordEvent(ctx, m.Client(), *event); err != nil {
+				return value, err
+			}
+		}
+
+		// Relay the events as soon as they are visible to it
+		if tx, err := m.Tx(); err == nil {
+			tx.OnCommit(func(next ent.Committer) ent.Committer {
+				return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+					err := next.Commit(ctx, tx)
+					if err == nil {
+						b.notify()
+					}
+					return err
+				})
+			})
+		} else {
+			b.notify()
+		}
+		return value, nil
+	})
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/example/fru-tracker/internal/metrics"
+	entoutbox "github.com/example/fru-tracker/internal/storage/ent/outboxevent"
+	entresource "github.com/example/fru-tracker/internal/storage/ent/resource"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PhasePending is the phase reported for snapshots the reconciler has not
+// picked up yet, which have no phase of their own.
+const PhasePending = "Pending"
+
+var (
+	eventsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fru_tracker_events_dropped_total",
+		Help: "Events removed from the outbox without being delivered, by reason.",
+	}, []string{"reason"})
+	eventBusRejected = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "fru_tracker_event_bus_rejected_total",
+		Help: "Times the event bus refused an event, usually because its buffer was full. The outbox retries the event, so it is delayed, not lost.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(metrics.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "fru_tracker_events_pending",
+		Help: "Events recorded in the outbox and not yet delivered to subscribers.",
+	}, "", func(ctx context.Context) (map[string]float64, error) {
+		pending, err := CountPendingEvents(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]float64{"": float64(pending)}, nil
+	}))
+}
+
+// CountPendingEvents returns the number of outbox events not yet delivered.
+func CountPendingEvents(ctx context.Context) (int, error) {
+	if err := ensureBackendReady(); err != nil {
+		return 0, err
+	}
+	count, err := entClient.OutboxEvent.Query().
+		Where(entoutbox.DeliveredAtIsNil()).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending events: %w", err)
+	}
+	return count, nil
+}
+
+// CountDevicesByType returns the number of devices of each deviceType. Only
+// the spec column is read, so it stays cheap enough to run on every scrape.
+func CountDevicesByType(ctx context.Context) (map[string]int, error) {
+	if err := ensureBackendReady(); err != nil {
+		return nil, err
+	}
+	rows, err := entClient.Resource.Query().
+		Where(entresource.KindEQ("Device")).
+		Select(entresource.FieldSpec).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query devices: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, row := range rows {
+		var spec struct {
+			DeviceType string `json:"deviceType"`
+		}
+		if err := json.Unmarshal(row.Spec, &spec); err != nil {
+			continue
+		}
+		counts[spec.DeviceType]++
+	}
+	return counts, nil
+}
+
+// CountDiscoverySnapshotsByPhase returns the number of discovery snapshots in
+// each status phase; snapshots without a phase count as PhasePending. Only
+// the status column is read, so raw snapshot data is never loaded.
+func CountDiscoverySnapshotsByPhase(ctx context.Context) (map[string]int, error) {
+	if err := ensureBackendReady(); err != nil {
+		return nil, err
+	}
+	rows, err := entClient.Resource.Query().
+		Where(entresource.KindEQ("DiscoverySnapshot")).
+		Select(entresource.FieldStatus).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query discovery snapshots: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, row := range rows {
+		var status struct {
+			Phase string `json:"phase"`
+		}
+		_ = json.Unmarshal(row.Status, &status)
+		if status.Phase == "" {
+			status.Phase = PhasePending
+		}
+		counts[status.Phase]++
+	}
+	return counts, nil
+}
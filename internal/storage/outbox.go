@@ -0,0 +1,480 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/example/fru-tracker/internal/storage/ent"
+	entoutbox "github.com/example/fru-tracker/internal/storage/ent/outboxevent"
+	"github.com/example/fru-tracker/internal/tracing"
+	"github.com/openchami/fabrica/pkg/events"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultEventRetention is how long delivered events stay in the outbox.
+	DefaultEventRetention = 24 * time.Hour
+
+	outboxBatchSize    = 100
+	outboxPollInterval = time.Second
+	outboxPruneEvery   = time.Hour
+
+	// traceparentExtension is the CloudEvents distributed tracing extension.
+	traceparentExtension = "traceparent"
+)
+
+// DurableEventBus is an events.EventBus that writes every published event to
+// the outbox table before any subscriber sees it. A relay hands pending events
+// to the wrapped bus in publish order, and each is marked delivered once every
+// subscriber has handled it, including work a handler finishes later under
+// DeferAck. Events not yet handled when the server stops or crashes are
+// delivered again on the next start, so delivery is at least once.
+type DurableEventBus struct {
+	events.EventBus
+
+	retention    time.Duration
+	subscribeErr error
+	started      atomic.Bool
+	wake         chan struct{}
+	stop         chan struct{}
+	done         chan struct{}
+	startOnce    sync.Once
+	closeOnce    sync.Once
+
+	mu            sync.RWMutex
+	subscriptions []subscription
+	lastID        int
+	// inflight maps the IDs of events handed to the wrapped bus and not yet
+	// acknowledged to their outbox rows, so the relay does not repeat them.
+	// delivered holds the events acknowledged since the relay pass began,
+	// which that pass may still have read as pending.
+	inflight  map[string]int
+	delivered map[string]bool
+}
+
+type subscription struct {
+	id        events.SubscriptionID
+	eventType string
+	handler   events.EventHandler
+}
+
+// NewDurableEventBus wraps inner, which queues relayed events for its workers.
+// Subscriptions are kept by the DurableEventBus itself, so that it knows when
+// every subscriber has handled an event. Delivered events older than retention
+// are pruned.
+func NewDurableEventBus(inner events.EventBus, retention time.Duration) *DurableEventBus {
+	if retention <= 0 {
+		retention = DefaultEventRetention
+	}
+	b := &DurableEventBus{
+		EventBus:  inner,
+		retention: retention,
+		wake:      make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		inflight:  make(map[string]int),
+		delivered: make(map[string]bool),
+	}
+	_, b.subscribeErr = inner.Subscribe("*", b.dispatch)
+	return b
+}
+
+// Publish records event in the outbox. Subscribers receive it once the relay
+// picks it up, which is immediate after Start. The trace of ctx travels with
+// the event in its traceparent extension. Failures are logged as well as
+// returned, since the generated handlers only print them.
+func (b *DurableEventBus) Publish(ctx context.Context, event events.Event) (err error) {
+	ctx, span := tracing.Start(ctx, "publish "+event.Type(), trace.SpanKindProducer)
+	defer func() {
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to publish event", "event_type", event.Type(), "event_id", event.ID(),
+				"resource_uid", event.ResourceUID(), "error", err)
+		}
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+	span.SetAttributes(
+		attribute.String("messaging.message.id", event.ID()),
+		attribute.String("fru_tracker.resource.uid", event.ResourceUID()),
+	)
+
+	if err := ensureBackendReady(); err != nil {
+		return err
+	}
+	// The event must be recorded even if the request that raised it is cancelled
+	if err := recordEvent(context.WithoutCancel(ctx), entClient, event); err != nil {
+		return err
+	}
+	b.notify()
+	return nil
+}
+
+// recordEvent writes event to the outbox through client, which may be bound
+// to a transaction, with the trace of ctx in its traceparent extension.
+func recordEvent(ctx context.Context, client *ent.Client, event events.Event) error {
+	if traceparent := tracing.Traceparent(ctx); traceparent != "" {
+		if err := event.SetExtension(traceparentExtension, traceparent); err != nil {
+			return fmt.Errorf("failed to attach trace to event %s: %w", event.ID(), err)
+		}
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event %s: %w", event.ID(), err)
+	}
+	if err := client.OutboxEvent.Create().
+		SetEventID(event.ID()).
+		SetType(event.Type()).
+		SetPayload(payload).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record event %s: %w", event.ID(), err)
+	}
+	return nil
+}
+
+// notify wakes the relay to deliver newly recorded events.
+func (b *DurableEventBus) notify() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Subscribe registers handler for events of eventType, which is an event
+// type, "*" for every event, or a prefix ending in ".*". Each delivery runs in
+// a consumer span continuing the trace the event was published in.
+func (b *DurableEventBus) Subscribe(eventType string, handler events.EventHandler) (events.SubscriptionID, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lastID++
+	id := events.SubscriptionID(fmt.Sprintf("durable-%d", b.lastID))
+	b.subscriptions = append(b.subscriptions, subscription{id: id, eventType: eventType, handler: handler})
+	return id, nil
+}
+
+// Unsubscribe removes the subscription id.
+func (b *DurableEventBus) Unsubscribe(id events.SubscriptionID) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, sub := range b.subscriptions {
+		if sub.id == id {
+			b.subscriptions = append(b.subscriptions[:i:i], b.subscriptions[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown subscription %s", id)
+}
+
+// matches reports whether the subscription wants events of eventType.
+func (s subscription) matches(eventType string) bool {
+	if s.eventType == "*" || s.eventType == eventType {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(s.eventType, "*")
+	return ok && strings.HasSuffix(prefix, ".") && strings.HasPrefix(eventType, prefix)
+}
+
+type ackKey struct{}
+
+// ack counts the handlers still working on one relayed event.
+type ack struct {
+	bus     *DurableEventBus
+	eventID string
+	mu      sync.Mutex
+	holds   int
+	aborted bool
+}
+
+// release drops one hold on the event, and marks it delivered when it was
+// the last and no holder aborted.
+func (a *ack) release(ctx context.Context, handled bool) {
+	a.mu.Lock()
+	a.holds--
+	a.aborted = a.aborted || !handled
+	last, aborted := a.holds == 0, a.aborted
+	a.mu.Unlock()
+	if last && !aborted {
+		a.bus.markDelivered(ctx, a.eventID)
+	}
+}
+
+// DeferAck lets an event handler that finishes its work in the background,
+// after it has returned, hold back the event's acknowledgement. The event is
+// marked delivered once every handler has returned and every release has been
+// called with handled set. A release without it, for work abandoned at
+// shutdown, leaves the event to be delivered again on the next start.
+// Outside a DurableEventBus delivery the release does nothing.
+func DeferAck(ctx context.Context) (release func(handled bool)) {
+	a, ok := ctx.Value(ackKey{}).(*ack)
+	if !ok {
+		return func(bool) {}
+	}
+	a.mu.Lock()
+	a.holds++
+	a.mu.Unlock()
+	ctx = context.WithoutCancel(ctx)
+	return func(handled bool) { a.release(ctx, handled) }
+}
+
+// dispatch runs every subscription matching event, each in a consumer span,
+// and then acknowledges it unless a handler deferred that.
+func (b *DurableEventBus) dispatch(ctx context.Context, event events.Event) error {
+	b.mu.RLock()
+	var handlers []events.EventHandler
+	for _, sub := range b.subscriptions {
+		if sub.matches(event.Type()) {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	b.mu.RUnlock()
+
+	a := &ack{bus: b, eventID: event.ID(), holds: 1}
+	ctx = context.WithValue(tracing.Extract(ctx, eventTraceparent(event)), ackKey{}, a)
+	var errs []error
+	for _, handler := range handlers {
+		if err := b.consume(ctx, handler, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	// A handler that failed is not retried: retrying is up to the subscriber
+	a.release(ctx, true)
+	return errors.Join(errs...)
+}
+
+func (b *DurableEventBus) consume(ctx context.Context, handler events.EventHandler, event events.Event) error {
+	ctx, span := tracing.Start(ctx, "consume "+event.Type(), trace.SpanKindConsumer)
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("messaging.message.id", event.ID()),
+		attribute.String("fru_tracker.resource.uid", event.ResourceUID()),
+	)
+	err := handler(ctx, event)
+	tracing.RecordError(span, err)
+	return err
+}
+
+// markDelivered records that every subscriber has handled the event eventID.
+func (b *DurableEventBus) markDelivered(ctx context.Context, eventID string) {
+	b.mu.RLock()
+	rowID, ok := b.inflight[eventID]
+	b.mu.RUnlock()
+	if !ok {
+		return
+	}
+	err := entClient.OutboxEvent.UpdateOneID(rowID).
+		SetDeliveredAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		// Left pending, the event is delivered again by a later relay pass
+		slog.WarnContext(ctx, "Failed to mark event delivered", "event_id", eventID, "error", err)
+	}
+	b.mu.Lock()
+	delete(b.inflight, eventID)
+	if err == nil {
+		b.delivered[eventID] = true
+	}
+	b.mu.Unlock()
+}
+
+// eventTraceparent returns the traceparent extension of event, or "".
+func eventTraceparent(event events.Event) string {
+	traceparent, _ := event.Extensions()[traceparentExtension].(string)
+	return traceparent
+}
+
+// Start begins relaying, starting with events a previous run left pending.
+// Call it after every subscriber is registered so none misses the backlog.
+func (b *DurableEventBus) Start() {
+	b.startOnce.Do(func() {
+		b.started.Store(true)
+		go b.run()
+	})
+}
+
+// Running returns an error unless the relay has been started and not stopped.
+func (b *DurableEventBus) Running() error {
+	if b.subscribeErr != nil {
+		return fmt.Errorf("event relay cannot subscribe to the event bus: %w", b.subscribeErr)
+	}
+	if !b.started.Load() {
+		return errors.New("event relay not started")
+	}
+	select {
+	case <-b.done:
+		return errors.New("event relay stopped")
+	default:
+		return nil
+	}
+}
+
+// Close stops the relay and closes the wrapped bus. Undelivered events stay
+// in the outbox for the next start.
+func (b *DurableEventBus) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.stop)
+		// Never started: there is no relay to wait for
+		b.startOnce.Do(func() { close(b.done) })
+		<-b.done
+	})
+	return b.EventBus.Close()
+}
+
+// PendingResourceUIDs returns the resource UIDs of outbox events that have not
+// been delivered yet.
+func (b *DurableEventBus) PendingResourceUIDs(ctx context.Context) (map[string]bool, error) {
+	if err := ensureBackendReady(); err != nil {
+		return nil, err
+	}
+
+	rows, err := entClient.OutboxEvent.Query().
+		Where(entoutbox.DeliveredAtIsNil()).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending events: %w", err)
+	}
+
+	uids := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		var event events.Event
+		if err := json.Unmarshal(row.Payload, &event); err != nil {
+			continue
+		}
+		if uid := event.ResourceUID(); uid != "" {
+			uids[uid] = true
+		}
+	}
+	return uids, nil
+}
+
+func (b *DurableEventBus) run() {
+	defer close(b.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-b.stop
+		cancel()
+	}()
+
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		b.relayPending(ctx)
+
+		if time.Since(lastPrune) >= outboxPruneEvery {
+			b.prune(ctx)
+			lastPrune = time.Now()
+		}
+
+		select {
+		case <-b.stop:
+			return
+		case <-b.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayPending hands pending events that are not already in flight to the
+// wrapped bus in publish order. It stops at the first event the bus refuses
+// so ordering is kept; the next pass retries it. Every pass starts over from
+// the oldest pending event, as one written in a transaction may become
+// visible after newer ones.
+func (b *DurableEventBus) relayPending(ctx context.Context) {
+	// Events acknowledged before this pass's first query are not pending in it
+	b.mu.Lock()
+	clear(b.delivered)
+	b.mu.Unlock()
+
+	after := 0
+	for {
+		rows, err := entClient.OutboxEvent.Query().
+			Where(entoutbox.DeliveredAtIsNil(), entoutbox.IDGT(after)).
+			Order(ent.Asc(entoutbox.FieldID)).
+			Limit(outboxBatchSize).
+			All(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.WarnContext(ctx, "Failed to query pending events", "error", err)
+			}
+			return
+		}
+
+		for _, row := range rows {
+			after = row.ID
+			b.mu.RLock()
+			_, inflight := b.inflight[row.EventID]
+			delivered := b.delivered[row.EventID]
+			b.mu.RUnlock()
+			if inflight || delivered {
+				continue
+			}
+
+			var event events.Event
+			if err := json.Unmarshal(row.Payload, &event); err != nil {
+				// Undecodable events would block the outbox forever; drop them
+				slog.WarnContext(ctx, "Dropping undecodable event", "event_id", row.EventID, "error", err)
+				eventsDropped.WithLabelValues("undecodable").Inc()
+				if err := entClient.OutboxEvent.UpdateOneID(row.ID).
+					SetDeliveredAt(time.Now()).
+					Exec(ctx); err != nil {
+					if ctx.Err() == nil {
+						slog.WarnContext(ctx, "Failed to drop undecodable event", "event_id", row.EventID, "error", err)
+					}
+					return
+				}
+				continue
+			}
+			if err := b.relay(ctx, row.ID, event); err != nil {
+				slog.WarnContext(ctx, "Failed to deliver event, will retry", "event_id", row.EventID,
+					"resource_uid", event.ResourceUID(), "error", err)
+				eventBusRejected.Inc()
+				return
+			}
+		}
+
+		if len(rows) < outboxBatchSize {
+			return
+		}
+	}
+}
+
+// relay hands event, stored in the outbox row rowID, to the wrapped bus. The
+// event's trace is remembered for its resource so that queued work, like
+// reconciling it, can continue it.
+func (b *DurableEventBus) relay(ctx context.Context, rowID int, event events.Event) error {
+	tracing.Remember(tracing.Extract(ctx, eventTraceparent(event)), event.ResourceUID())
+	b.mu.Lock()
+	b.inflight[event.ID()] = rowID
+	b.mu.Unlock()
+	if err := b.EventBus.Publish(ctx, event); err != nil {
+		b.mu.Lock()
+		delete(b.inflight, event.ID())
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// prune deletes delivered events older than the retention period.
+func (b *DurableEventBus) prune(ctx context.Context) {
+	if _, err := entClient.OutboxEvent.Delete().
+		Where(entoutbox.DeliveredAtLT(time.Now().Add(-b.retention))).
+		Exec(ctx); err != nil && ctx.Err() == nil {
+		slog.WarnContext(ctx, "Failed to prune delivered events", "error", err)
+	}
+}
@@ -323,6 +323,150 @@ func DeleteDiscoverySnapshot(ctx context.Context, uid string) error {
 	return nil
 }
 
+// LoadAllWebhookSubscriptions loads all WebhookSubscription resources from Ent storage
+func LoadAllWebhookSubscriptions(ctx context.Context) ([]*v1.WebhookSubscription, error) {
+	if entClient == nil {
+		return nil, fmt.Errorf("ent client not initialized")
+	}
+
+	// Query all resources of this kind
+	entResources, err := entClient.Resource.Query().
+		Where(entresource.KindEQ("WebhookSubscription")).
+		WithLabels().
+		WithAnnotations().
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load WebhookSubscription resources: %w", err)
+	}
+
+	// Convert to Fabrica resources
+	var resources []*v1.WebhookSubscription
+	for _, entResource := range entResources {
+		fabricaResource, err := FromEntResource(ctx, entResource)
+		if err != nil {
+			// Log error but continue with other resources
+			continue
+		}
+		resources = append(resources, fabricaResource.(*v1.WebhookSubscription))
+	}
+
+	return resources, nil
+}
+
+// LoadWebhookSubscription loads a single WebhookSubscription resource by UID from Ent storage
+func LoadWebhookSubscription(ctx context.Context, uid string) (*v1.WebhookSubscription, error) {
+	if entClient == nil {
+		return nil, fmt.Errorf("ent client not initialized")
+	}
+
+	// Query by UID and kind
+	entResource, err := entClient.Resource.Query().
+		Where(
+			entresource.UIDEQ(uid),
+			entresource.KindEQ("WebhookSubscription"),
+		).
+		WithLabels().
+		WithAnnotations().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to load WebhookSubscription %s: %w", uid, err)
+	}
+
+	// Convert to Fabrica resource
+	fabricaResource, err := FromEntResource(ctx, entResource)
+	if err != nil {
+		return nil, err
+	}
+
+	return fabricaResource.(*v1.WebhookSubscription), nil
+}
+
+// SaveWebhookSubscription saves a WebhookSubscription resource to Ent storage
+func SaveWebhookSubscription(ctx context.Context, resource *v1.WebhookSubscription) error {
+	if entClient == nil {
+		return fmt.Errorf("ent client not initialized")
+	}
+
+	// Convert to Ent entity
+	createBuilder, labels, annotations, err := ToEntResource(resource)
+	if err != nil {
+		return fmt.Errorf("failed to convert WebhookSubscription to ent: %w", err)
+	}
+
+	// Use upsert pattern: try to update, if not exists then create
+	entResource, err := entClient.Resource.Query().
+		Where(entresource.UIDEQ(resource.GetUID())).
+		Only(ctx)
+
+	if err != nil && !ent.IsNotFound(err) {
+		return fmt.Errorf("failed to check WebhookSubscription existence: %w", err)
+	}
+
+	var savedResource *ent.Resource
+	if ent.IsNotFound(err) {
+		// Create new resource
+		savedResource, err = createBuilder.Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create WebhookSubscription: %w", err)
+		}
+	} else {
+		// Update existing resource
+		spec, _ := json.Marshal(resource.Spec)
+		status, _ := json.Marshal(resource.Status)
+
+		savedResource, err = entClient.Resource.UpdateOne(entResource).
+			SetName(resource.Metadata.Name).
+			SetAPIVersion(resource.APIVersion).
+			SetSpec(spec).
+			SetStatus(status).
+			SetUpdatedAt(time.Now()).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update WebhookSubscription: %w", err)
+		}
+	}
+
+	// Save labels
+	if err := saveLabels(ctx, savedResource.ID, labels); err != nil {
+		return err
+	}
+
+	// Save annotations
+	if err := saveAnnotations(ctx, savedResource.ID, annotations); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteWebhookSubscription deletes a WebhookSubscription resource from Ent storage
+func DeleteWebhookSubscription(ctx context.Context, uid string) error {
+	if entClient == nil {
+		return fmt.Errorf("ent client not initialized")
+	}
+
+	// Delete by UID
+	deleted, err := entClient.Resource.Delete().
+		Where(
+			entresource.UIDEQ(uid),
+			entresource.KindEQ("WebhookSubscription"),
+		).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete WebhookSubscription %s: %w", uid, err)
+	}
+
+	if deleted == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
 var Backend fabricaStorage.StorageBackend = &entStorageBackend{}
 
 var _ fabricaStorage.StorageBackend = (*entStorageBackend)(nil)
@@ -353,6 +497,12 @@ func (b *entStorageBackend) LoadAll(ctx context.Context, resourceType string) ([
 			return nil, err
 		}
 		return marshalTypedList(resources)
+	case "WebhookSubscription":
+		resources, err := LoadAllWebhookSubscriptions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return marshalTypedList(resources)
 	default:
 		return nil, fmt.Errorf("storage: unsupported resource type %s", resourceType)
 	}
@@ -375,6 +525,12 @@ func (b *entStorageBackend) Load(ctx context.Context, resourceType, uid string)
 			return nil, err
 		}
 		return marshalResource(resource)
+	case "WebhookSubscription":
+		resource, err := LoadWebhookSubscription(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		return marshalResource(resource)
 	default:
 		return nil, fmt.Errorf("storage: unsupported resource type %s", resourceType)
 	}
@@ -399,6 +555,13 @@ func (b *entStorageBackend) Save(ctx context.Context, resourceType, uid string,
 		}
 		res.Metadata.UID = uid
 		return SaveDiscoverySnapshot(ctx, &res)
+	case "WebhookSubscription":
+		var res v1.WebhookSubscription
+		if err := json.Unmarshal(data, &res); err != nil {
+			return fmt.Errorf("failed to unmarshal WebhookSubscription: %w", err)
+		}
+		res.Metadata.UID = uid
+		return SaveWebhookSubscription(ctx, &res)
 	default:
 		return fmt.Errorf("storage: unsupported resource type %s", resourceType)
 	}
@@ -413,6 +576,8 @@ func (b *entStorageBackend) Delete(ctx context.Context, resourceType, uid string
 		return DeleteDevice(ctx, uid)
 	case "DiscoverySnapshot":
 		return DeleteDiscoverySnapshot(ctx, uid)
+	case "WebhookSubscription":
+		return DeleteWebhookSubscription(ctx, uid)
 	default:
 		return fmt.Errorf("storage: unsupported resource type %s", resourceType)
 	}
@@ -501,6 +666,12 @@ func (c *StorageClient) Get(ctx context.Context, kind, uid string) (interface{},
 			return nil, fmt.Errorf("failed to unmarshal DiscoverySnapshot: %w", err)
 		}
 		return &resource, nil
+	case "WebhookSubscription":
+		var resource v1.WebhookSubscription
+		if err := json.Unmarshal(raw, &resource); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal WebhookSubscription: %w", err)
+		}
+		return &resource, nil
 	default:
 		return nil, fmt.Errorf("unknown resource kind: %s", kind)
 	}
@@ -534,6 +705,16 @@ func (c *StorageClient) List(ctx context.Context, kind string) ([]interface{}, e
 			result = append(result, &resource)
 		}
 		return result, nil
+	case "WebhookSubscription":
+		result := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			var resource v1.WebhookSubscription
+			if err := json.Unmarshal(item, &resource); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal WebhookSubscription: %w", err)
+			}
+			result = append(result, &resource)
+		}
+		return result, nil
 	default:
 		return nil, fmt.Errorf("unknown resource kind: %s", kind)
 	}
@@ -551,6 +732,8 @@ func (c *StorageClient) Update(ctx context.Context, resource interface{}) error
 		return c.backend.Save(ctx, "Device", res.Metadata.UID, data)
 	case *v1.DiscoverySnapshot:
 		return c.backend.Save(ctx, "DiscoverySnapshot", res.Metadata.UID, data)
+	case *v1.WebhookSubscription:
+		return c.backend.Save(ctx, "WebhookSubscription", res.Metadata.UID, data)
 	default:
 		return fmt.Errorf("unsupported resource type: %T", resource)
 	}
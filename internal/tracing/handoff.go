@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// handoffSize bounds the span contexts kept for resources nobody recalls.
+const handoffSize = 1024
+
+// handoffs keeps the span context of the last event for a resource, for work
+// that is queued by resource rather than by event and so loses the event's
+// context on the way. The reconcile controller is one: it reconciles a
+// resource UID, not the event that named it.
+var handoffs = struct {
+	sync.Mutex
+	byKey map[string]trace.SpanContext
+	order []string
+}{byKey: make(map[string]trace.SpanContext)}
+
+// Remember keeps the span context of ctx for key, replacing any earlier one.
+func Remember(ctx context.Context, key string) {
+	sc := trace.SpanContextFromContext(ctx)
+	if key == "" || !sc.IsValid() {
+		return
+	}
+	handoffs.Lock()
+	defer handoffs.Unlock()
+	if _, ok := handoffs.byKey[key]; !ok {
+		handoffs.order = append(handoffs.order, key)
+	}
+	handoffs.byKey[key] = sc
+	for len(handoffs.order) > handoffSize {
+		delete(handoffs.byKey, handoffs.order[0])
+		handoffs.order = handoffs.order[1:]
+	}
+}
+
+// Recall removes the span context remembered for key and, unless ctx already
+// carries a trace, returns ctx with it as the parent of new spans.
+func Recall(ctx context.Context, key string) context.Context {
+	handoffs.Lock()
+	sc, ok := handoffs.byKey[key]
+	if ok {
+		delete(handoffs.byKey, key)
+		for i, k := range handoffs.order {
+			if k == key {
+				handoffs.order = append(handoffs.order[:i], handoffs.order[i+1:]...)
+				break
+			}
+		}
+	}
+	handoffs.Unlock()
+
+	if !ok || trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// The OTLP JSON encoding of an ExportTraceServiceRequest. IDs are hex and
+// 64-bit integers are decimal strings, as the OTLP/JSON mapping requires.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+	otlpSpan struct {
+		TraceID           string          `json:"traceId"`
+		SpanID            string          `json:"spanId"`
+		ParentSpanID      string          `json:"parentSpanId,omitempty"`
+		Name              string          `json:"name"`
+		Kind              int             `json:"kind"`
+		StartTimeUnixNano string          `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+		Attributes        []otlpAttribute `json:"attributes,omitempty"`
+		Events            []otlpEvent     `json:"events,omitempty"`
+		Status            *otlpStatus     `json:"status,omitempty"`
+	}
+	otlpEvent struct {
+		Name         string          `json:"name"`
+		TimeUnixNano string          `json:"timeUnixNano"`
+		Attributes   []otlpAttribute `json:"attributes,omitempty"`
+	}
+	otlpAttribute struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+)
+
+// otlpStatusError is STATUS_CODE_ERROR.
+const otlpStatusError = 2
+
+// otlpAttributes encodes attrs. Slices are encoded as their string form.
+func otlpAttributes(attrs []attribute.KeyValue) []otlpAttribute {
+	encoded := make([]otlpAttribute, 0, len(attrs))
+	for _, attr := range attrs {
+		var v otlpValue
+		switch attr.Value.Type() {
+		case attribute.BOOL:
+			b := attr.Value.AsBool()
+			v.BoolValue = &b
+		case attribute.INT64:
+			s := strconv.FormatInt(attr.Value.AsInt64(), 10)
+			v.IntValue = &s
+		case attribute.FLOAT64:
+			f := attr.Value.AsFloat64()
+			v.DoubleValue = &f
+		default:
+			s := attr.Value.Emit()
+			v.StringValue = &s
+		}
+		encoded = append(encoded, otlpAttribute{Key: string(attr.Key), Value: v})
+	}
+	return encoded
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// encodeOTLP encodes spans as one ExportTraceServiceRequest, grouped by
+// instrumentation scope. Every span of a provider shares its resource.
+func encodeOTLP(spans []sdktrace.ReadOnlySpan) ([]byte, error) {
+	var (
+		resourceSpans otlpResourceSpans
+		scopes        = make(map[string]int)
+	)
+	if len(spans) > 0 && spans[0].Resource() != nil {
+		resourceSpans.Resource.Attributes = otlpAttributes(spans[0].Resource().Attributes())
+	}
+	for _, span := range spans {
+		s := otlpSpan{
+			TraceID:           span.SpanContext().TraceID().String(),
+			SpanID:            span.SpanContext().SpanID().String(),
+			Name:              span.Name(),
+			Kind:              int(span.SpanKind()),
+			StartTimeUnixNano: unixNano(span.StartTime()),
+			EndTimeUnixNano:   unixNano(span.EndTime()),
+			Attributes:        otlpAttributes(span.Attributes()),
+		}
+		if span.Parent().HasSpanID() {
+			s.ParentSpanID = span.Parent().SpanID().String()
+		}
+		for _, event := range span.Events() {
+			s.Events = append(s.Events, otlpEvent{
+				Name:         event.Name,
+				TimeUnixNano: unixNano(event.Time),
+				Attributes:   otlpAttributes(event.Attributes),
+			})
+		}
+		if status := span.Status(); status.Code == codes.Error {
+			s.Status = &otlpStatus{Code: otlpStatusError, Message: status.Description}
+		}
+
+		scope := span.InstrumentationScope()
+		i, ok := scopes[scope.Name]
+		if !ok {
+			i = len(resourceSpans.ScopeSpans)
+			scopes[scope.Name] = i
+			resourceSpans.ScopeSpans = append(resourceSpans.ScopeSpans, otlpScopeSpans{
+				Scope: otlpScope{Name: scope.Name, Version: scope.Version},
+			})
+		}
+		resourceSpans.ScopeSpans[i].Spans = append(resourceSpans.ScopeSpans[i].Spans, s)
+	}
+	return json.Marshal(otlpRequest{ResourceSpans: []otlpResourceSpans{resourceSpans}})
+}
+
+// NewOTLPExporter returns a span exporter that posts spans as OTLP JSON to an
+// OTLP/HTTP collector. endpoint is the collector's base URL, such as
+// http://localhost:4318; /v1/traces is appended unless it is already there.
+func NewOTLPExporter(endpoint string, client *http.Client) sdktrace.SpanExporter {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	url := strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	return &otlpExporter{url: url, client: client}
+}
+
+type otlpExporter struct {
+	url    string
+	client *http.Client
+}
+
+func (e *otlpExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	request, err := encodeOTLP(spans)
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(request))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post spans to %s: %w", e.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("collector at %s answered %s: %s", e.url, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// Shutdown does nothing: every export is a request of its own.
+func (e *otlpExporter) Shutdown(context.Context) error {
+	return nil
+}
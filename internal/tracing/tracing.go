@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package tracing sets up the OpenTelemetry SDK for the server and carries
+// trace context where the OpenTelemetry propagators do not reach.
+//
+// Trace context travels in context.Context within the process and as a W3C
+// traceparent between processes: in HTTP headers and in the traceparent
+// extension of CloudEvents. Until Setup installs an exporter, the global
+// tracer provider is the no-op one, so Start returns spans that record
+// nothing but still carry an incoming trace to log lines and events.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName names the tracer every span of the server comes from.
+const instrumentationName = "github.com/example/fru-tracker"
+
+// traceparentHeader is the W3C header, and CloudEvents extension, that
+// carries a span context between processes.
+const traceparentHeader = "traceparent"
+
+// propagator reads and writes traceparent values.
+var propagator = propagation.TraceContext{}
+
+// Start begins a span named name as a child of the span context in ctx, or as
+// the root of a new trace. The returned context carries the span.
+func Start(ctx context.Context, name string, kind trace.SpanKind) (context.Context, trace.Span) {
+	// Look the tracer up on each call so that it follows Setup
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithSpanKind(kind))
+}
+
+// RecordError records err on span and marks the span failed. A nil err is
+// ignored.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// Extract returns ctx with the span context in traceparent as the parent of
+// new spans. An empty or malformed traceparent leaves ctx unchanged.
+func Extract(ctx context.Context, traceparent string) context.Context {
+	if traceparent == "" {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier{traceparentHeader: traceparent})
+}
+
+// Traceparent returns the W3C traceparent of ctx, or "" when it has no trace.
+func Traceparent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier.Get(traceparentHeader)
+}
+
+// TraceIDFromContext returns the hex trace ID of ctx for log lines, or "".
+func TraceIDFromContext(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// Setup installs a tracer provider that batches the spans of every later
+// Start under serviceName and hands them to exporter. The returned function
+// exports what is left and shuts the provider down, after which spans are
+// no longer recorded.
+func Setup(exporter sdktrace.SpanExporter, serviceName string) (shutdown func(ctx context.Context) error) {
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+	return provider.Shutdown
+}
+
+// Flush exports the spans finished so far and waits until that is done. It is
+// meant for tests; spans are otherwise exported every few seconds.
+func Flush() {
+	if provider, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+		_ = provider.ForceFlush(context.Background())
+	}
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestTraceparent(t *testing.T) {
+	ctx := Extract(context.Background(), testTraceparent)
+	assert.Equal(t, testTraceparent, Traceparent(ctx))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", TraceIDFromContext(ctx))
+
+	for _, bad := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",
+	} {
+		ctx := Extract(context.Background(), bad)
+		assert.Empty(t, Traceparent(ctx), bad)
+		assert.Empty(t, TraceIDFromContext(ctx), bad)
+	}
+}
+
+func TestStartWithoutExporter(t *testing.T) {
+	ctx := Extract(context.Background(), testTraceparent)
+	ctx, span := Start(ctx, "off", trace.SpanKindInternal)
+	assert.False(t, span.IsRecording())
+	// The incoming trace still reaches log lines and events
+	assert.Equal(t, testTraceparent, Traceparent(ctx))
+	span.SetAttributes(attribute.String("key", "value"))
+	RecordError(span, errors.New("ignored"))
+	span.End()
+}
+
+func TestOTLPExport(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []otlpRequest
+	)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var request otlpRequest
+		require.NoError(t, json.Unmarshal(body, &request))
+		mu.Lock()
+		requests = append(requests, request)
+		mu.Unlock()
+	}))
+	defer collector.Close()
+
+	shutdown := Setup(NewOTLPExporter(collector.URL, collector.Client()), "test-service")
+
+	// A remote parent, a server span and an internal child that fails
+	ctx := Extract(context.Background(), testTraceparent)
+	ctx, server := Start(ctx, "GET", trace.SpanKindServer)
+	server.SetName("GET /devices")
+	server.SetAttributes(attribute.Int("http.response.status_code", 500))
+	childCtx, child := Start(ctx, "storage", trace.SpanKindInternal)
+	RecordError(child, errors.New("database is locked"))
+	RecordError(child, nil)
+	child.End()
+	server.End()
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", TraceIDFromContext(childCtx))
+
+	// Remembered contexts are recalled once, and only for contexts without a trace
+	Remember(childCtx, "snapshot-1")
+	recalled := Recall(context.Background(), "snapshot-1")
+	assert.Equal(t, child.SpanContext().SpanID(), trace.SpanContextFromContext(recalled).SpanID())
+	assert.False(t, trace.SpanContextFromContext(Recall(context.Background(), "snapshot-1")).IsValid())
+
+	require.NoError(t, shutdown(context.Background()))
+	_, span := Start(context.Background(), "after shutdown", trace.SpanKindInternal)
+	assert.False(t, span.IsRecording())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, requests, 1)
+	resource := requests[0].ResourceSpans[0]
+	assert.Contains(t, resource.Resource.Attributes, otlpAttributes([]attribute.KeyValue{attribute.String("service.name", "test-service")})[0])
+	assert.Equal(t, instrumentationName, resource.ScopeSpans[0].Scope.Name)
+	spans := resource.ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	storage, get := spans[0], spans[1]
+	assert.Equal(t, "storage", storage.Name)
+	assert.Equal(t, get.SpanID, storage.ParentSpanID)
+	assert.Equal(t, &otlpStatus{Code: otlpStatusError, Message: "database is locked"}, storage.Status)
+	require.Len(t, storage.Events, 1)
+	assert.Equal(t, "exception", storage.Events[0].Name)
+
+	assert.Equal(t, "GET /devices", get.Name)
+	assert.Equal(t, int(trace.SpanKindServer), get.Kind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", get.TraceID)
+	assert.Equal(t, "00f067aa0ba902b7", get.ParentSpanID)
+	assert.Nil(t, get.Status)
+	assert.Equal(t, "500", *get.Attributes[0].Value.IntValue)
+}
@@ -39,6 +39,14 @@ func init() {
 	}); err != nil {
 		panic(err)
 	}
+	if err := versioning.GlobalVersionRegistry.RegisterVersion("WebhookSubscription", "v1", versioning.ResourceTypeInfo{
+		Metadata: versioning.SchemaVersion{
+			Version:   "v1",
+			IsDefault: true,
+		},
+	}); err != nil {
+		panic(err)
+	}
 }
 
 // GetRegistry returns the global version registry
@@ -410,3 +410,90 @@ func (c *Client) DeleteDiscoverySnapshot(ctx context.Context, uid string) error
 	}
 	return nil
 }
+
+// GetWebhookSubscriptions retrieves all webhooksubscriptions
+func (c *Client) GetWebhookSubscriptions(ctx context.Context) ([]v1.WebhookSubscription, error) {
+	var response []v1.WebhookSubscription
+	if err := c.doRequest(ctx, "GET", "/webhooksubscriptions", nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetWebhookSubscription retrieves a specific WebhookSubscription by UID
+func (c *Client) GetWebhookSubscription(ctx context.Context, uid string) (*v1.WebhookSubscription, error) {
+	var result v1.WebhookSubscription
+	endpoint := fmt.Sprintf("/webhooksubscriptions/%s", uid)
+	if err := c.doRequest(ctx, "GET", endpoint, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateWebhookSubscription creates a new WebhookSubscription
+func (c *Client) CreateWebhookSubscription(ctx context.Context, req CreateWebhookSubscriptionRequest) (*v1.WebhookSubscription, error) {
+	var result v1.WebhookSubscription
+	if err := c.doRequest(ctx, "POST", "/webhooksubscriptions", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateWebhookSubscription updates an existing WebhookSubscription
+func (c *Client) UpdateWebhookSubscription(ctx context.Context, uid string, req UpdateWebhookSubscriptionRequest) (*v1.WebhookSubscription, error) {
+	var result v1.WebhookSubscription
+	endpoint := fmt.Sprintf("/webhooksubscriptions/%s", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchWebhookSubscription patches an existing WebhookSubscription spec with the specified patch data and content type
+func (c *Client) PatchWebhookSubscription(ctx context.Context, uid string, patchData []byte, contentType string) (*v1.WebhookSubscription, error) {
+	var result v1.WebhookSubscription
+	endpoint := fmt.Sprintf("/webhooksubscriptions/%s", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateWebhookSubscriptionStatus updates only the status of an existing WebhookSubscription
+// This method is intended for controllers, reconcilers, and monitoring systems.
+// It preserves the spec and only updates the status portion of the resource.
+func (c *Client) UpdateWebhookSubscriptionStatus(ctx context.Context, uid string, status v1.WebhookSubscriptionStatus) (*v1.WebhookSubscription, error) {
+	var result v1.WebhookSubscription
+	endpoint := fmt.Sprintf("/webhooksubscriptions/%s/status", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, status, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchWebhookSubscriptionStatus patches only the status of an existing WebhookSubscription
+// Supports JSON Merge Patch by default. Use PatchWebhookSubscriptionStatusWithType for other patch formats.
+func (c *Client) PatchWebhookSubscriptionStatus(ctx context.Context, uid string, patchData []byte) (*v1.WebhookSubscription, error) {
+	return c.PatchWebhookSubscriptionStatusWithType(ctx, uid, patchData, "application/merge-patch+json")
+}
+
+// PatchWebhookSubscriptionStatusWithType patches status with a specific patch content type
+// Supported types: application/merge-patch+json, application/json-patch+json, application/fabrica-patch+json
+func (c *Client) PatchWebhookSubscriptionStatusWithType(ctx context.Context, uid string, patchData []byte, contentType string) (*v1.WebhookSubscription, error) {
+	var result v1.WebhookSubscription
+	endpoint := fmt.Sprintf("/webhooksubscriptions/%s/status", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteWebhookSubscription deletes a WebhookSubscription by UID
+func (c *Client) DeleteWebhookSubscription(ctx context.Context, uid string) error {
+	endpoint := fmt.Sprintf("/webhooksubscriptions/%s", uid)
+	var response DeleteResponse
+	if err := c.doRequest(ctx, "DELETE", endpoint, nil, &response); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+)
+
+// ErrPreconditionFailed is returned, wrapped, when a conditional update,
+// patch or delete finds the resource was modified after its ETag was read.
+var ErrPreconditionFailed = errors.New("resource was modified since it was read")
+
+type ifMatchKey struct{}
+type etagKey struct{}
+
+// WithConditionalRequests returns a copy of httpClient (http.DefaultClient if
+// nil) that honours IfMatch and CaptureETag. Pass it to NewClient to use them.
+func WithConditionalRequests(httpClient *http.Client) *http.Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	conditional := *httpClient
+	base := conditional.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	conditional.Transport = &conditionalTransport{base: base}
+	return &conditional
+}
+
+// conditionalTransport adds the preconditions carried by a request's context
+// and records the ETag of its response.
+type conditionalTransport struct {
+	base http.RoundTripper
+}
+
+func (t *conditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if etag, ok := ctx.Value(ifMatchKey{}).(string); ok && etag != "" {
+		req = req.Clone(ctx)
+		req.Header.Set("If-Match", etag)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if etag, ok := ctx.Value(etagKey{}).(*string); ok {
+		*etag = resp.Header.Get("ETag")
+	}
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w (If-Match: %s)", ErrPreconditionFailed, req.Header.Get("If-Match"))
+	}
+	return resp, nil
+}
+
+// IfMatch returns a context under which requests are sent with If-Match:
+// etag, so the server refuses updates, patches and deletes of a resource that
+// no longer has that ETag. The Client must use WithConditionalRequests.
+func IfMatch(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, etag)
+}
+
+// CaptureETag returns a context under which the ETag of each response is
+// stored in etag. Pass it to a Get to learn the ETag to update with, or to an
+// update to learn the ETag of the result. The Client must use
+// WithConditionalRequests.
+func CaptureETag(ctx context.Context, etag *string) context.Context {
+	return context.WithValue(ctx, etagKey{}, etag)
+}
+
+// maxModifyAttempts bounds how often ModifyDevice starts over after losing a
+// race with another writer.
+const maxModifyAttempts = 5
+
+// ModifyDevice reads a device, lets modify change it and writes it back with
+// If-Match, so changes made by others in between, such as the reconciler
+// merging a new snapshot, are not overwritten. When the device changed, it is
+// read and modified again. modify may be called several times and must not
+// have other side effects. The Client must use WithConditionalRequests.
+func (c *Client) ModifyDevice(ctx context.Context, uid string, modify func(*v1.Device) error) (*v1.Device, error) {
+	var err error
+	for range maxModifyAttempts {
+		var etag string
+		device, getErr := c.GetDevice(CaptureETag(ctx, &etag), uid)
+		if getErr != nil {
+			return nil, getErr
+		}
+		if etag == "" {
+			return nil, fmt.Errorf("no ETag for device %s: create the client with WithConditionalRequests", uid)
+		}
+		if err := modify(device); err != nil {
+			return nil, err
+		}
+		var updated *v1.Device
+		updated, err = c.UpdateDevice(IfMatch(ctx, etag), uid, UpdateDeviceRequest{
+			Metadata:    device.Metadata,
+			Spec:        device.Spec,
+			Labels:      device.Metadata.Labels,
+			Annotations: device.Metadata.Annotations,
+		})
+		if !errors.Is(err, ErrPreconditionFailed) {
+			return updated, err
+		}
+	}
+	return nil, fmt.Errorf("device %s kept changing, gave up after %d attempts: %w", uid, maxModifyAttempts, err)
+}
@@ -73,6 +73,22 @@ type UpdateDiscoverySnapshotRequest struct {
 	Annotations map[string]string        `json:"annotations,omitempty"`
 }
 
+// CreateWebhookSubscriptionRequest represents a request to create a WebhookSubscription
+type CreateWebhookSubscriptionRequest struct {
+	Metadata    fabrica.Metadata           `json:"metadata" validate:"required"`
+	Spec        v1.WebhookSubscriptionSpec `json:"spec" validate:"required"`
+	Labels      map[string]string          `json:"labels,omitempty"`
+	Annotations map[string]string          `json:"annotations,omitempty"`
+}
+
+// UpdateWebhookSubscriptionRequest represents a request to update a WebhookSubscription
+type UpdateWebhookSubscriptionRequest struct {
+	Metadata    fabrica.Metadata           `json:"metadata,omitempty"`
+	Spec        v1.WebhookSubscriptionSpec `json:"spec,omitempty" validate:"omitempty"`
+	Labels      map[string]string          `json:"labels,omitempty"`
+	Annotations map[string]string          `json:"annotations,omitempty"`
+}
+
 // DeleteResponse represents a successful deletion response
 type DeleteResponse struct {
 	Message string `json:"message"`
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WatchActionBookmark is the action of the first event on every stream. It
+// carries the revision the stream starts from and no object.
+const WatchActionBookmark = "bookmark"
+
+var (
+	// ErrWatchExpired is returned when the requested revision is no longer held
+	// by the server. Re-list and watch again without a revision.
+	ErrWatchExpired = errors.New("watch revision expired")
+
+	// ErrWatchClosed is returned when the server ends the stream. Watch again
+	// from the last revision seen to continue without missing events.
+	ErrWatchClosed = errors.New("watch stream closed by server")
+)
+
+// WatchEvent is one message from the watch stream. Object holds the current
+// state of the resource for created, updated and patched events.
+type WatchEvent struct {
+	Revision uint64          `json:"revision"`
+	Action   string          `json:"action"`
+	Kind     string          `json:"kind,omitempty"`
+	UID      string          `json:"uid,omitempty"`
+	Time     time.Time       `json:"time"`
+	Object   json.RawMessage `json:"object,omitempty"`
+}
+
+// WatchOptions selects what a watch stream delivers.
+type WatchOptions struct {
+	// Kinds limits the stream to these resource kinds (default: all).
+	Kinds []string
+	// Revision resumes after a previously seen revision. Zero starts from now.
+	Revision uint64
+}
+
+// Watch streams resource lifecycle events from GET /events, calling handler for
+// each one until ctx is cancelled (returns nil), the server closes the stream
+// (ErrWatchClosed) or handler returns an error.
+func (c *Client) Watch(ctx context.Context, opts WatchOptions, handler func(WatchEvent) error) error {
+	query := url.Values{}
+	if len(opts.Kinds) > 0 {
+		query.Set("kinds", strings.Join(opts.Kinds, ","))
+	}
+	return c.watch(ctx, "/events", query, opts.Revision, handler)
+}
+
+// WatchDevices streams Device events from GET /devices?watch=true.
+func (c *Client) WatchDevices(ctx context.Context, revision uint64, handler func(WatchEvent) error) error {
+	query := url.Values{}
+	query.Set("watch", "true")
+	return c.watch(ctx, "/devices", query, revision, handler)
+}
+
+func (c *Client) watch(ctx context.Context, endpoint string, query url.Values, revision uint64, handler func(WatchEvent) error) error {
+	if revision > 0 {
+		query.Set("revision", strconv.FormatUint(revision, 10))
+	}
+
+	u := *c.baseURL
+	u.Path = path.Join(u.Path, endpoint)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create watch request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("watch request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusGone {
+		return ErrWatchExpired
+	}
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		var errorResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errorResp); err != nil {
+			return fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(respBody))
+		}
+		return fmt.Errorf("API error (%d): %s", resp.StatusCode, errorResp.Error)
+	}
+
+	// Server-Sent Events: "field: value" lines, blank line ends an event
+	reader := bufio.NewReader(resp.Body)
+	var data strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, io.EOF) {
+				return ErrWatchClosed
+			}
+			return fmt.Errorf("failed to read watch stream: %w", err)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+			var event WatchEvent
+			if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+				return fmt.Errorf("failed to decode watch event: %w", err)
+			}
+			data.Reset()
+			if err := handler(event); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+}
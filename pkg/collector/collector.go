@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package collector discovers hardware through the Redfish API of a BMC and
+// submits it to the fru-tracker API as a DiscoverySnapshot.
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	fabricaclient "github.com/example/fru-tracker/pkg/client"
+	"github.com/openchami/fabrica/pkg/fabrica"
+)
+
+// Collector walks BMCs and posts what it finds.
+type Collector struct {
+	api         *fabricaclient.Client
+	httpClient  *http.Client
+	credentials *CredentialStore
+	auth        string
+	redfish     RedfishOptions
+
+	// output, when set, is where snapshots are saved instead of posted
+	output    string
+	outputDir bool
+}
+
+// New creates a Collector posting to api. httpClient carries the TLS settings
+// and timeout for BMC requests; auth is AuthSession or AuthBasic.
+func New(api *fabricaclient.Client, httpClient *http.Client, credentials *CredentialStore, auth string) *Collector {
+	return &Collector{
+		api:         api,
+		httpClient:  httpClient,
+		credentials: credentials,
+		auth:        auth,
+		redfish:     DefaultRedfishOptions(),
+	}
+}
+
+// SetRedfishOptions changes the retry, concurrency and $expand settings used
+// for each BMC.
+func (c *Collector) SetRedfishOptions(options RedfishOptions) {
+	c.redfish = options
+}
+
+// Collect walks the Redfish tree of bmc and returns the devices found.
+func (c *Collector) Collect(ctx context.Context, bmc string) ([]*v1.DeviceSpec, error) {
+	credentials, err := c.credentials.Lookup(bmc)
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewRedfishClient(bmc, credentials, c.auth, c.httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Redfish client: %w", err)
+	}
+	client.SetOptions(c.redfish)
+	defer func() {
+		if err := client.Close(context.WithoutCancel(ctx)); err != nil {
+			log.Printf("Warning: Failed to close Redfish session on %s: %v", bmc, err)
+		}
+	}()
+
+	specs, err := NewRedfishSource(client).Collect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("redfish discovery failed: %w", err)
+	}
+	if len(specs) == 0 {
+		return nil, errors.New("redfish discovery found no devices to post")
+	}
+	return specs, nil
+}
+
+// Post submits specs collected from bmc as a new DiscoverySnapshot.
+func (c *Collector) Post(ctx context.Context, bmc string, specs []*v1.DeviceSpec) (*v1.DiscoverySnapshot, error) {
+	createReq, err := NewSnapshotRequest(bmc, specs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return c.Submit(ctx, createReq)
+}
+
+// Submit creates a DiscoverySnapshot from a prepared request.
+func (c *Collector) Submit(ctx context.Context, createReq fabricaclient.CreateDiscoverySnapshotRequest) (*v1.DiscoverySnapshot, error) {
+	snapshot, err := c.api.CreateDiscoverySnapshot(ctx, createReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// NewSnapshotRequest wraps specs collected from bmc at collectedAt in a
+// DiscoverySnapshot, annotated with where and when they were collected.
+func NewSnapshotRequest(bmc string, specs []*v1.DeviceSpec, collectedAt time.Time) (fabricaclient.CreateDiscoverySnapshotRequest, error) {
+	return newSnapshotRequest(bmc, map[string]string{
+		v1.AnnotationSource:    RedfishSourceName,
+		v1.AnnotationSourceBMC: bmc,
+	}, specs, collectedAt)
+}
+
+// NewHostSnapshotRequest wraps specs that the named sources read from host, the
+// machine the collector runs on, in a DiscoverySnapshot.
+func NewHostSnapshotRequest(host string, sources []string, specs []*v1.DeviceSpec, collectedAt time.Time) (fabricaclient.CreateDiscoverySnapshotRequest, error) {
+	return newSnapshotRequest(host, map[string]string{
+		v1.AnnotationSource: strings.Join(sources, ","),
+	}, specs, collectedAt)
+}
+
+func newSnapshotRequest(target string, annotations map[string]string, specs []*v1.DeviceSpec, collectedAt time.Time) (fabricaclient.CreateDiscoverySnapshotRequest, error) {
+	snapshotData, err := json.Marshal(specs)
+	if err != nil {
+		return fabricaclient.CreateDiscoverySnapshotRequest{}, fmt.Errorf("failed to marshal device list into snapshot data: %w", err)
+	}
+
+	annotations[v1.AnnotationCollectedAt] = collectedAt.UTC().Format(time.RFC3339)
+	if host, err := os.Hostname(); err == nil {
+		annotations[v1.AnnotationCollectorHost] = host
+	}
+	return fabricaclient.CreateDiscoverySnapshotRequest{
+		Metadata: fabrica.Metadata{
+			Name:        SnapshotName(target, collectedAt),
+			Annotations: annotations,
+		},
+		Spec: v1.DiscoverySnapshotSpec{
+			RawData: json.RawMessage(snapshotData),
+		},
+	}, nil
+}
+
+// CollectAndPost collects bmc and posts the result as a DiscoverySnapshot.
+func (c *Collector) CollectAndPost(ctx context.Context, bmc string) (*v1.DiscoverySnapshot, error) {
+	specs, err := c.Collect(ctx, bmc)
+	if err != nil {
+		return nil, err
+	}
+	return c.Post(ctx, bmc, specs)
+}
+
+// SnapshotName names the snapshot of bmc taken at t. Characters a resource
+// name cannot hold, such as the colons of a port or URL, become dashes.
+func SnapshotName(bmc string, t time.Time) string {
+	host := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, strings.TrimPrefix(strings.TrimPrefix(bmc, "https://"), "http://"))
+	return fmt.Sprintf("snapshot-%s-%d", strings.Trim(host, "-"), t.Unix())
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Credentials are the username and password for one BMC.
+type Credentials struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// CredentialStore looks up BMC credentials by host, falling back to a
+// default for BMCs without an entry of their own.
+type CredentialStore struct {
+	defaults Credentials
+	hosts    map[string]Credentials
+}
+
+// credentialsFile is the on-disk form of a CredentialStore:
+//
+//	default:
+//	  username: root
+//	  password: secret
+//	bmcs:
+//	  10.0.0.5:
+//	    username: admin
+//	    password: other
+type credentialsFile struct {
+	Default Credentials            `yaml:"default"`
+	BMCs    map[string]Credentials `yaml:"bmcs"`
+}
+
+// NewCredentialStore returns a store holding only default credentials.
+func NewCredentialStore(defaults Credentials) *CredentialStore {
+	return &CredentialStore{defaults: defaults, hosts: make(map[string]Credentials)}
+}
+
+// LoadCredentialsFile reads a YAML credentials file. The file holds
+// passwords, so it is refused when other users can read it.
+func LoadCredentialsFile(path string) (*CredentialStore, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("credentials file %s is accessible by other users (mode %s); chmod 600 it", path, info.Mode().Perm())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	var file credentialsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file %s: %w", path, err)
+	}
+
+	store := NewCredentialStore(file.Default)
+	for bmc, credentials := range file.BMCs {
+		store.hosts[bmcHost(bmc)] = credentials
+	}
+	return store, nil
+}
+
+// SetDefault replaces the credentials used for BMCs without an entry.
+func (s *CredentialStore) SetDefault(credentials Credentials) {
+	s.defaults = credentials
+}
+
+// Lookup returns the credentials for bmc, given as a host, host:port or URL.
+func (s *CredentialStore) Lookup(bmc string) (Credentials, error) {
+	if credentials, ok := s.hosts[bmcHost(bmc)]; ok {
+		return credentials, nil
+	}
+	if s.defaults.Username == "" {
+		return Credentials{}, fmt.Errorf("no credentials configured for BMC %s", bmc)
+	}
+	return s.defaults, nil
+}
+
+// bmcHost reduces a BMC address to its lower-cased host name or IP.
+func bmcHost(bmc string) string {
+	if strings.Contains(bmc, "://") {
+		if u, err := url.Parse(bmc); err == nil {
+			bmc = u.Host
+		}
+	}
+	if host, _, err := net.SplitHostPort(bmc); err == nil {
+		bmc = host
+	}
+	return strings.ToLower(strings.Trim(bmc, "[]"))
+}
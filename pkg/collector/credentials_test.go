@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCredentialsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+default:
+  username: root
+  password: initial0
+bmcs:
+  10.0.0.5:
+    username: admin
+    password: other
+  BMC02.example.com:
+    username: ops
+    password: third
+`), 0o600))
+
+	store, err := LoadCredentialsFile(path)
+	require.NoError(t, err)
+
+	tests := []struct {
+		bmc  string
+		want Credentials
+	}{
+		{bmc: "10.0.0.5", want: Credentials{Username: "admin", Password: "other"}},
+		{bmc: "https://10.0.0.5:8443", want: Credentials{Username: "admin", Password: "other"}},
+		{bmc: "bmc02.example.com", want: Credentials{Username: "ops", Password: "third"}},
+		{bmc: "10.0.0.6", want: Credentials{Username: "root", Password: "initial0"}},
+	}
+	for _, tt := range tests {
+		got, err := store.Lookup(tt.bmc)
+		require.NoError(t, err, tt.bmc)
+		assert.Equal(t, tt.want, got, tt.bmc)
+	}
+
+	// Environment credentials replace the file default but not host entries
+	store.SetDefault(Credentials{Username: "env", Password: "env-secret"})
+	got, err := store.Lookup("10.0.0.6")
+	require.NoError(t, err)
+	assert.Equal(t, "env", got.Username)
+	got, err = store.Lookup("10.0.0.5")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", got.Username)
+}
+
+func TestLoadCredentialsFileRejectsReadableFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("default: {username: root, password: x}\n"), 0o644))
+
+	_, err := LoadCredentialsFile(path)
+	assert.ErrorContains(t, err, "accessible by other users")
+}
+
+func TestCredentialStoreWithoutDefault(t *testing.T) {
+	_, err := NewCredentialStore(Credentials{}).Lookup("10.0.0.5")
+	assert.ErrorContains(t, err, "no credentials configured")
+}
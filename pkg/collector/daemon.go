@@ -0,0 +1,349 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+)
+
+// DaemonOptions control how often a Daemon re-collects its BMCs.
+type DaemonOptions struct {
+	// Interval is the time between collections of a reachable BMC.
+	Interval time.Duration
+	// Jitter is the fraction of each delay added or removed at random, so
+	// BMCs started together drift apart instead of being collected in bursts.
+	Jitter float64
+	// RetryInterval is the delay after a first failure. It doubles with each
+	// further failure up to MaxBackoff.
+	RetryInterval time.Duration
+	MaxBackoff    time.Duration
+	// Workers and HostTimeout are as for Sweep.
+	Workers     int
+	HostTimeout time.Duration
+}
+
+// DefaultDaemonOptions returns the options used when none are configured.
+func DefaultDaemonOptions() DaemonOptions {
+	return DaemonOptions{
+		Interval:      time.Hour,
+		Jitter:        0.1,
+		RetryInterval: time.Minute,
+		MaxBackoff:    time.Hour,
+		Workers:       DefaultWorkers,
+		HostTimeout:   10 * time.Minute,
+	}
+}
+
+// BMCStatus is what a Daemon knows about one BMC.
+type BMCStatus struct {
+	BMC         string    `json:"bmc"`
+	LastAttempt time.Time `json:"lastAttempt,omitzero"`
+	LastSuccess time.Time `json:"lastSuccess,omitzero"`
+	LastError   string    `json:"lastError,omitempty"`
+	Failures    int       `json:"consecutiveFailures"`
+	NextRun     time.Time `json:"nextRun,omitzero"`
+	Devices     int       `json:"devices"`
+	SnapshotUID string    `json:"snapshotUid,omitempty"`
+	ContentHash string    `json:"contentHash,omitempty"`
+}
+
+// Daemon re-collects a fixed set of BMCs on a schedule and posts a snapshot
+// only when what it collected differs from the last snapshot it posted.
+type Daemon struct {
+	collector *Collector
+	bmcs      []string
+	options   DaemonOptions
+	started   time.Time
+
+	mu        sync.Mutex
+	status    map[string]*BMCStatus
+	succeeded int
+	failed    int
+	posted    int
+	unchanged int
+}
+
+// NewDaemon creates a Daemon for bmcs. Unset durations and workers take
+// their defaults.
+func NewDaemon(c *Collector, bmcs []string, options DaemonOptions) *Daemon {
+	defaults := DefaultDaemonOptions()
+	if options.Interval <= 0 {
+		options.Interval = defaults.Interval
+	}
+	if options.Jitter < 0 || options.Jitter >= 1 {
+		options.Jitter = defaults.Jitter
+	}
+	if options.RetryInterval <= 0 {
+		options.RetryInterval = defaults.RetryInterval
+	}
+	if options.MaxBackoff < options.RetryInterval {
+		options.MaxBackoff = max(defaults.MaxBackoff, options.RetryInterval)
+	}
+	if options.Workers <= 0 {
+		options.Workers = defaults.Workers
+	}
+
+	status := make(map[string]*BMCStatus, len(bmcs))
+	for _, bmc := range bmcs {
+		status[bmc] = &BMCStatus{BMC: bmc}
+	}
+	return &Daemon{
+		collector: c,
+		bmcs:      bmcs,
+		options:   options,
+		status:    status,
+	}
+}
+
+// Run collects every BMC right away and then on its schedule until ctx is
+// done, with at most Workers collections running at once.
+func (d *Daemon) Run(ctx context.Context) {
+	d.mu.Lock()
+	d.started = time.Now()
+	d.mu.Unlock()
+
+	slots := make(chan struct{}, d.options.Workers)
+	var wg sync.WaitGroup
+	for _, bmc := range d.bmcs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.schedule(ctx, bmc, slots)
+		}()
+	}
+	wg.Wait()
+}
+
+// schedule is the collection loop of one BMC.
+func (d *Daemon) schedule(ctx context.Context, bmc string, slots chan struct{}) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		err := d.collect(ctx, bmc)
+		<-slots
+		if ctx.Err() != nil {
+			return
+		}
+
+		delay := d.reschedule(bmc, err)
+		timer.Reset(delay)
+	}
+}
+
+// collect walks bmc once and posts the result if it changed.
+func (d *Daemon) collect(ctx context.Context, bmc string) error {
+	if d.options.HostTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.options.HostTimeout)
+		defer cancel()
+	}
+
+	d.mu.Lock()
+	d.status[bmc].LastAttempt = time.Now()
+	lastHash := d.status[bmc].ContentHash
+	d.mu.Unlock()
+
+	specs, err := d.collector.Collect(ctx, bmc)
+	if err != nil {
+		return err
+	}
+	hash, err := contentHash(specs)
+	if err != nil {
+		return err
+	}
+
+	snapshotUID := ""
+	if hash != lastHash {
+		snapshot, err := d.collector.Post(ctx, bmc, specs)
+		if err != nil {
+			return err
+		}
+		snapshotUID = snapshot.Metadata.UID
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	status := d.status[bmc]
+	status.Devices = len(specs)
+	if snapshotUID != "" {
+		status.SnapshotUID = snapshotUID
+		status.ContentHash = hash
+		d.posted++
+	} else {
+		d.unchanged++
+	}
+	return nil
+}
+
+// reschedule records the outcome of a collection and returns the delay until
+// the next one.
+func (d *Daemon) reschedule(bmc string, err error) time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	status := d.status[bmc]
+	var delay time.Duration
+	if err != nil {
+		d.failed++
+		status.Failures++
+		status.LastError = err.Error()
+		delay = backoff(d.options.RetryInterval, d.options.MaxBackoff, status.Failures)
+		log.Printf("Warning: Collection of %s failed (%d in a row), retrying in %s: %v",
+			bmc, status.Failures, delay.Round(time.Second), err)
+	} else {
+		d.succeeded++
+		status.Failures = 0
+		status.LastError = ""
+		status.LastSuccess = time.Now()
+		delay = d.options.Interval
+	}
+	delay = jitter(delay, d.options.Jitter)
+	status.NextRun = time.Now().Add(delay)
+	return delay
+}
+
+// Status returns the state of every BMC, in the order they were given.
+func (d *Daemon) Status() []BMCStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	statuses := make([]BMCStatus, 0, len(d.bmcs))
+	for _, bmc := range d.bmcs {
+		statuses = append(statuses, *d.status[bmc])
+	}
+	return statuses
+}
+
+// Handler serves the daemon's health at /healthz and Prometheus metrics at
+// /metrics.
+func (d *Daemon) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", d.serveHealth)
+	mux.HandleFunc("GET /metrics", d.serveMetrics)
+	return mux
+}
+
+// serveHealth reports "ok" while every BMC's last collection succeeded and
+// "degraded" otherwise. Unreachable BMCs do not make the daemon itself
+// unhealthy, so the status code is always 200.
+func (d *Daemon) serveHealth(w http.ResponseWriter, r *http.Request) {
+	statuses := d.Status()
+	health := "ok"
+	for _, status := range statuses {
+		if status.Failures > 0 {
+			health = "degraded"
+		}
+	}
+
+	d.mu.Lock()
+	started := d.started
+	d.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"status":  health,
+		"service": "fru-collector",
+		"started": started,
+		"bmcs":    statuses,
+	})
+}
+
+// metricLabel escapes a value for a Prometheus label.
+var metricLabel = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// serveMetrics writes the daemon's counters in the Prometheus text format.
+func (d *Daemon) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	statuses := d.Status()
+	d.mu.Lock()
+	succeeded, failed, posted, unchanged := d.succeeded, d.failed, d.posted, d.unchanged
+	d.mu.Unlock()
+
+	var b strings.Builder
+	metric := func(name, kind, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	}
+
+	metric("fru_collector_collections_total", "counter", "BMC collections by result.")
+	fmt.Fprintf(&b, "fru_collector_collections_total{result=\"success\"} %d\n", succeeded)
+	fmt.Fprintf(&b, "fru_collector_collections_total{result=\"failure\"} %d\n", failed)
+	metric("fru_collector_snapshots_total", "counter", "Successful collections by whether a snapshot was posted or the inventory was unchanged.")
+	fmt.Fprintf(&b, "fru_collector_snapshots_total{result=\"posted\"} %d\n", posted)
+	fmt.Fprintf(&b, "fru_collector_snapshots_total{result=\"unchanged\"} %d\n", unchanged)
+
+	metric("fru_collector_bmc_up", "gauge", "Whether the last collection of a BMC succeeded.")
+	for _, status := range statuses {
+		up := 0
+		if status.Failures == 0 && !status.LastSuccess.IsZero() {
+			up = 1
+		}
+		fmt.Fprintf(&b, "fru_collector_bmc_up{bmc=\"%s\"} %d\n", metricLabel.Replace(status.BMC), up)
+	}
+	metric("fru_collector_bmc_devices", "gauge", "Devices found in the last successful collection of a BMC.")
+	for _, status := range statuses {
+		fmt.Fprintf(&b, "fru_collector_bmc_devices{bmc=\"%s\"} %d\n", metricLabel.Replace(status.BMC), status.Devices)
+	}
+	metric("fru_collector_bmc_last_success_timestamp_seconds", "gauge", "Unix time of the last successful collection of a BMC.")
+	for _, status := range statuses {
+		var ts int64
+		if !status.LastSuccess.IsZero() {
+			ts = status.LastSuccess.Unix()
+		}
+		fmt.Fprintf(&b, "fru_collector_bmc_last_success_timestamp_seconds{bmc=\"%s\"} %d\n", metricLabel.Replace(status.BMC), ts)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(b.String()))
+}
+
+// contentHash fingerprints a collection so unchanged inventory is not posted
+// again. Property maps marshal with sorted keys, so equal inventories hash
+// equally.
+func contentHash(specs []*v1.DeviceSpec) (string, error) {
+	data, err := json.Marshal(specs)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode devices for hashing: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// backoff returns retry doubled for each failure after the first, capped at
+// limit.
+func backoff(retry, limit time.Duration, failures int) time.Duration {
+	delay := retry
+	for i := 1; i < failures && delay < limit; i++ {
+		delay *= 2
+	}
+	return min(delay, limit)
+}
+
+// jitter moves delay by up to fraction of itself in either direction.
+func jitter(delay time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return delay
+	}
+	return delay + time.Duration((rand.Float64()*2-1)*fraction*float64(delay))
+}
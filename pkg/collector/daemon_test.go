@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	fabricaclient "github.com/example/fru-tracker/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaemonPostsOnlyChanges(t *testing.T) {
+	var serial atomic.Value
+	serial.Store("NODE-1")
+	bmc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Systems":
+			_, _ = w.Write([]byte(`{"Members":[{"@odata.id":"/redfish/v1/Systems/1"}]}`))
+		case "/redfish/v1/Systems/1":
+			_, _ = fmt.Fprintf(w, `{"SerialNumber":%q}`, serial.Load())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer bmc.Close()
+
+	var posts atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := posts.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"metadata":{"uid":"discoverysnapshot-%d"}}`, n)
+	}))
+	defer api.Close()
+
+	apiClient, err := fabricaclient.NewClient(api.URL, api.Client())
+	require.NoError(t, err)
+	c := New(apiClient, bmc.Client(), NewCredentialStore(Credentials{Username: "root"}), AuthBasic)
+	d := NewDaemon(c, []string{bmc.URL}, DaemonOptions{Interval: 10 * time.Millisecond, Jitter: 0})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		d.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	collections := func() int {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return d.succeeded
+	}
+
+	// Repeated collections of the same inventory post once
+	require.Eventually(t, func() bool { return collections() >= 3 }, 5*time.Second, 5*time.Millisecond)
+	assert.EqualValues(t, 1, posts.Load())
+
+	// A change is posted on the next collection
+	serial.Store("NODE-2")
+	require.Eventually(t, func() bool { return posts.Load() == 2 }, 5*time.Second, 5*time.Millisecond)
+
+	status := d.Status()
+	require.Len(t, status, 1)
+	assert.Equal(t, 1, status[0].Devices)
+	assert.Zero(t, status[0].Failures)
+	assert.Equal(t, "discoverysnapshot-2", status[0].SnapshotUID)
+}
+
+func TestDaemonBacksOffUnreachableBMCs(t *testing.T) {
+	c := New(nil, http.DefaultClient, NewCredentialStore(Credentials{Username: "root"}), AuthBasic)
+	d := NewDaemon(c, []string{"http://127.0.0.1:1"}, DaemonOptions{RetryInterval: time.Minute, MaxBackoff: 5 * time.Minute, Jitter: 0})
+
+	var delays []time.Duration
+	for i := 0; i < 5; i++ {
+		delays = append(delays, d.reschedule("http://127.0.0.1:1", fmt.Errorf("unreachable")))
+	}
+	assert.Equal(t, []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}, delays)
+	assert.Equal(t, 5, d.Status()[0].Failures)
+
+	// A success resets the backoff to the regular interval
+	assert.Equal(t, time.Hour, d.reschedule("http://127.0.0.1:1", nil))
+	assert.Zero(t, d.Status()[0].Failures)
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		delay := jitter(time.Hour, 0.1)
+		assert.GreaterOrEqual(t, delay, 54*time.Minute)
+		assert.LessOrEqual(t, delay, 66*time.Minute)
+	}
+	assert.Equal(t, time.Hour, jitter(time.Hour, 0))
+}
+
+func TestDaemonHandler(t *testing.T) {
+	c := New(nil, http.DefaultClient, NewCredentialStore(Credentials{}), AuthBasic)
+	d := NewDaemon(c, []string{"10.0.0.5", "10.0.0.6"}, DaemonOptions{})
+	d.reschedule("10.0.0.5", nil)
+	d.reschedule("10.0.0.6", fmt.Errorf("unreachable"))
+	server := httptest.NewServer(d.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/healthz")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var health struct {
+		Status string
+		BMCs   []BMCStatus
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&health))
+	assert.Equal(t, "degraded", health.Status)
+	require.Len(t, health.BMCs, 2)
+	assert.Equal(t, "unreachable", health.BMCs[1].LastError)
+
+	resp, err = http.Get(server.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `fru_collector_collections_total{result="success"} 1`)
+	assert.Contains(t, string(body), `fru_collector_collections_total{result="failure"} 1`)
+	assert.Contains(t, string(body), `fru_collector_bmc_up{bmc="10.0.0.5"} 1`)
+	assert.Contains(t, string(body), `fru_collector_bmc_up{bmc="10.0.0.6"} 0`)
+}
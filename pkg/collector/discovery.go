@@ -0,0 +1,566 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+)
+
+// parentRef identifies the device that components of a chassis or system attach to.
+type parentRef struct {
+	URI    string
+	Serial string
+}
+
+// member is a resource listed in a collection or linked from another
+// resource. Body is set once it has been fetched, or straight away when the
+// service expanded it into the collection.
+type member struct {
+	URI  string
+	Body json.RawMessage
+	Err  error
+}
+
+// discovery holds the state of one walk over a BMC's Redfish tree.
+type discovery struct {
+	client *RedfishClient
+	// expand is the $expand query collections are requested with, if the
+	// service supports one
+	expand string
+	specs  []*v1.DeviceSpec
+	// seen holds the URIs already reported, since a resource such as a drive
+	// can be reachable from both its system and its chassis
+	seen map[string]bool
+	// parents maps chassis and system URIs to the device their components attach to
+	parents map[string]parentRef
+}
+
+// Discover walks the Chassis, Systems and Managers trees of the BMC behind c
+// and returns a DeviceSpec for every component found.
+//
+// Components a system uses (CPUs, GPUs, DIMMs, drives behind its storage
+// controllers, its PCIe devices) are parented to the system's Node. Components
+// a chassis houses (PSUs, fans, NICs, chassis PCIe devices and drives) are
+// parented to the chassis, and the BMC to the chassis it sits in. A chassis
+// that only wraps a single system, with the same or no serial number, is
+// folded into that system's Node so the node stays the top of its hierarchy.
+//
+// Collections are requested with $expand when the service root advertises
+// it, saving a request per member. Otherwise members are fetched
+// concurrently, within the client's concurrency limit.
+func Discover(ctx context.Context, c *RedfishClient) ([]*v1.DeviceSpec, error) {
+	d := &discovery{
+		client:  c,
+		seen:    make(map[string]bool),
+		parents: make(map[string]parentRef),
+	}
+
+	if !c.options.NoExpand {
+		d.expand = d.expandQuery(ctx)
+	}
+
+	systemMembers, err := d.getMembers(ctx, "/Systems")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Systems collection: %w", err)
+	}
+	chassisMembers, err := d.getMembers(ctx, "/Chassis")
+	if err != nil {
+		d.warnf("Failed to get Chassis collection: %v", err)
+	}
+	managerMembers, err := d.getMembers(ctx, "/Managers")
+	if err != nil {
+		d.warnf("Failed to get Managers collection: %v", err)
+	}
+	d.fetch(ctx, systemMembers, chassisMembers, managerMembers)
+
+	chassisURIs := make([]string, 0, len(chassisMembers))
+	chassis := make(map[string]*RedfishChassis, len(chassisMembers))
+	for _, m := range chassisMembers {
+		chassisURIs = append(chassisURIs, m.URI)
+		var chassisData RedfishChassis
+		if err := decodeMember(m, &chassisData); err != nil {
+			d.warnf("Failed to get chassis %s: %v", m.URI, err)
+			continue
+		}
+		chassis[m.URI] = &chassisData
+		d.parents[m.URI] = parentRef{URI: m.URI, Serial: chassisData.SerialNumber}
+	}
+
+	systemURIs := make([]string, 0, len(systemMembers))
+	systems := make(map[string]*RedfishSystem, len(systemMembers))
+	for _, m := range systemMembers {
+		systemURIs = append(systemURIs, m.URI)
+		var systemData RedfishSystem
+		if err := decodeMember(m, &systemData); err != nil {
+			d.warnf("Failed to get system %s: %v", m.URI, err)
+			continue
+		}
+		systems[m.URI] = &systemData
+		d.parents[m.URI] = parentRef{URI: m.URI, Serial: systemData.SerialNumber}
+	}
+
+	// Fold single-system chassis into their Node, which then sits in
+	// whatever enclosure contains the chassis
+	folded := make(map[string]bool)
+	nodeParents := make(map[string]string, len(systems))
+	for _, uri := range systemURIs {
+		systemData, ok := systems[uri]
+		if !ok || len(systemData.Links.Chassis) == 0 {
+			continue
+		}
+		chassisURI := redfishPath(systemData.Links.Chassis[0].ODataID)
+		nodeParents[uri] = chassisURI
+		chassisData, ok := chassis[chassisURI]
+		if !ok || len(chassisData.Links.ComputerSystems) > 1 {
+			continue
+		}
+		if chassisData.SerialNumber == "" || chassisData.SerialNumber == systemData.SerialNumber {
+			folded[chassisURI] = true
+			nodeParents[uri] = redfishPath(chassisData.Links.ContainedBy.ODataID)
+			d.parents[chassisURI] = d.parents[uri]
+		}
+	}
+
+	// Enclosures first, then the systems in them and what each one houses
+	for _, uri := range chassisURIs {
+		chassisData, ok := chassis[uri]
+		if !ok || folded[uri] {
+			continue
+		}
+		parent := d.parentOf(chassisData.Links.ContainedBy.ODataID)
+		d.add(uri, mapCommonProperties(chassisData.CommonRedfishProperties, "Chassis", uri, parent.URI, parent.Serial))
+	}
+	for _, uri := range systemURIs {
+		if systemData, ok := systems[uri]; ok {
+			d.addSystem(ctx, uri, systemData, d.parentOf(nodeParents[uri]))
+		}
+	}
+	for _, uri := range chassisURIs {
+		if chassisData, ok := chassis[uri]; ok {
+			d.addChassisComponents(ctx, uri, chassisData)
+		}
+	}
+	for _, m := range managerMembers {
+		d.addManager(m)
+	}
+
+	// A walk cut short would look like missing hardware; report nothing instead
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return d.specs, nil
+}
+
+// addSystem adds a system's Node under parent and the components the system uses.
+func (d *discovery) addSystem(ctx context.Context, uri string, systemData *RedfishSystem, parent parentRef) {
+	d.add(uri, mapCommonProperties(systemData.CommonRedfishProperties, "Node", uri, parent.URI, parent.Serial))
+
+	// Pass the Node's Serial Number as the parent identifier
+	node := d.parents[uri]
+	if systemData.Processors.ODataID != "" {
+		d.addCollection(ctx, systemData.Processors.ODataID, "CPU", node, &RedfishProcessor{})
+	}
+	if systemData.Memory.ODataID != "" {
+		d.addCollection(ctx, systemData.Memory.ODataID, "DIMM", node, &RedfishMemory{})
+	}
+	if systemData.Storage.ODataID != "" {
+		controllers, err := d.getMembers(ctx, redfishPath(systemData.Storage.ODataID))
+		if err != nil {
+			d.warnf("Failed to retrieve storage inventory from %s: %v", systemData.Storage.ODataID, err)
+		}
+		d.fetch(ctx, controllers)
+		for _, controller := range controllers {
+			var storage RedfishStorage
+			if err := decodeMember(controller, &storage); err != nil {
+				d.warnf("Failed to get storage %s: %v", controller.URI, err)
+				continue
+			}
+			d.addMembers(ctx, linkMembers(storage.Drives), "Drive", node, &RedfishDrive{})
+		}
+	}
+	d.addMembers(ctx, linkMembers(systemData.PCIeDevices), "PCIeDevice", node, &RedfishPCIeDevice{})
+}
+
+// addChassisComponents adds the components a chassis houses. Newer BMCs
+// describe PSUs and fans under PowerSubsystem and ThermalSubsystem; older ones
+// list them inline in the Power and Thermal resources.
+func (d *discovery) addChassisComponents(ctx context.Context, uri string, chassisData *RedfishChassis) {
+	parent := d.parents[uri]
+
+	if chassisData.PowerSubsystem.ODataID != "" {
+		var subsystem RedfishPowerSubsystem
+		if err := d.getResource(ctx, redfishPath(chassisData.PowerSubsystem.ODataID), &subsystem); err != nil {
+			d.warnf("Failed to get power subsystem %s: %v", chassisData.PowerSubsystem.ODataID, err)
+		} else if subsystem.PowerSupplies.ODataID != "" {
+			d.addCollection(ctx, subsystem.PowerSupplies.ODataID, "PSU", parent, &RedfishPowerSupply{})
+		}
+	} else if chassisData.Power.ODataID != "" {
+		powerURI := redfishPath(chassisData.Power.ODataID)
+		var power RedfishPower
+		if err := d.getResource(ctx, powerURI, &power); err != nil {
+			d.warnf("Failed to get power %s: %v", chassisData.Power.ODataID, err)
+		}
+		for i, psu := range power.PowerSupplies {
+			d.addInline(psu.ODataID, fmt.Sprintf("%s#/PowerSupplies/%d", powerURI, i), psu.CommonRedfishProperties, "PSU", parent)
+		}
+	}
+
+	if chassisData.ThermalSubsystem.ODataID != "" {
+		var subsystem RedfishThermalSubsystem
+		if err := d.getResource(ctx, redfishPath(chassisData.ThermalSubsystem.ODataID), &subsystem); err != nil {
+			d.warnf("Failed to get thermal subsystem %s: %v", chassisData.ThermalSubsystem.ODataID, err)
+		} else if subsystem.Fans.ODataID != "" {
+			d.addCollection(ctx, subsystem.Fans.ODataID, "Fan", parent, &RedfishFan{})
+		}
+	} else if chassisData.Thermal.ODataID != "" {
+		thermalURI := redfishPath(chassisData.Thermal.ODataID)
+		var thermal RedfishThermal
+		if err := d.getResource(ctx, thermalURI, &thermal); err != nil {
+			d.warnf("Failed to get thermal %s: %v", chassisData.Thermal.ODataID, err)
+		}
+		for i, fan := range thermal.Fans {
+			d.addInline(fan.ODataID, fmt.Sprintf("%s#/Fans/%d", thermalURI, i), fan.CommonRedfishProperties, "Fan", parent)
+		}
+	}
+
+	if chassisData.NetworkAdapters.ODataID != "" {
+		d.addCollection(ctx, chassisData.NetworkAdapters.ODataID, "NIC", parent, &RedfishNetworkAdapter{})
+	}
+	if chassisData.PCIeDevices.ODataID != "" {
+		d.addCollection(ctx, chassisData.PCIeDevices.ODataID, "PCIeDevice", parent, &RedfishPCIeDevice{})
+	}
+	if chassisData.Drives.ODataID != "" {
+		d.addCollection(ctx, chassisData.Drives.ODataID, "Drive", parent, &RedfishDrive{})
+	}
+	d.addMembers(ctx, linkMembers(chassisData.Links.Drives), "Drive", parent, &RedfishDrive{})
+}
+
+// addManager adds a BMC, parented to the chassis it sits in or, failing
+// that, the first chassis or system it manages.
+func (d *discovery) addManager(m member) {
+	var manager RedfishManager
+	if err := decodeMember(m, &manager); err != nil {
+		d.warnf("Failed to get manager %s: %v", m.URI, err)
+		return
+	}
+	uri := m.URI
+
+	parent := d.parentOf(manager.Links.ManagerInChassis.ODataID)
+	if parent.URI == "" && len(manager.Links.ManagerForChassis) > 0 {
+		parent = d.parentOf(manager.Links.ManagerForChassis[0].ODataID)
+	}
+	if parent.URI == "" && len(manager.Links.ManagerForServers) > 0 {
+		parent = d.parentOf(manager.Links.ManagerForServers[0].ODataID)
+	}
+	d.add(uri, mapCommonProperties(manager.CommonRedfishProperties, "BMC", uri, parent.URI, parent.Serial))
+}
+
+// addCollection retrieves a collection and adds its members.
+func (d *discovery) addCollection(ctx context.Context, collectionODataID, deviceType string, parent parentRef, componentTypeExample interface{}) {
+	members, err := d.getMembers(ctx, redfishPath(collectionODataID))
+	if err != nil {
+		d.warnf("Failed to retrieve %s inventory from %s: %v", deviceType, collectionODataID, err)
+		return
+	}
+	d.addMembers(ctx, members, deviceType, parent, componentTypeExample)
+}
+
+// addMembers retrieves each resource, maps it and adds it under parent.
+// Resources already reported elsewhere in the tree are skipped. Devices are
+// added in the order listed, however the fetches complete.
+func (d *discovery) addMembers(ctx context.Context, members []member, deviceType string, parent parentRef, componentTypeExample interface{}) {
+	pending := make([]member, 0, len(members))
+	for _, m := range members {
+		if !d.seen[m.URI] {
+			pending = append(pending, m)
+		}
+	}
+	d.fetch(ctx, pending)
+
+	for _, m := range pending {
+		memberURI := m.URI
+		if d.seen[memberURI] {
+			continue
+		}
+		component := reflect.New(reflect.TypeOf(componentTypeExample).Elem()).Interface()
+		if err := decodeMember(m, component); err != nil {
+			d.warnf("Failed to get member %s: %v", memberURI, err)
+			continue
+		}
+		rfProps := reflect.ValueOf(component).Elem().Field(0).Interface().(CommonRedfishProperties)
+
+		spec := mapCommonProperties(rfProps, deviceType, memberURI, parent.URI, parent.Serial)
+		mapCapacity(component, spec.Properties)
+		// Accelerators are listed with the CPUs
+		if processor, ok := component.(*RedfishProcessor); ok && strings.EqualFold(processor.ProcessorType, "GPU") {
+			spec.DeviceType = "GPU"
+		}
+		d.add(memberURI, spec)
+	}
+}
+
+// addInline adds a component embedded in its parent resource, such as an
+// entry of the legacy Power or Thermal arrays. fallbackURI identifies it when
+// the BMC gives the entry no @odata.id.
+func (d *discovery) addInline(odataID, fallbackURI string, rfProps CommonRedfishProperties, deviceType string, parent parentRef) {
+	uri := redfishPath(odataID)
+	if uri == "" {
+		uri = fallbackURI
+	}
+	if d.seen[uri] {
+		return
+	}
+	d.add(uri, mapCommonProperties(rfProps, deviceType, uri, parent.URI, parent.Serial))
+}
+
+// warnf logs a problem that does not stop the walk, naming the BMC since
+// sweeps walk many at once.
+func (d *discovery) warnf(format string, args ...interface{}) {
+	log.Printf("Warning: %s: "+format, append([]interface{}{d.client.Host()}, args...)...)
+}
+
+// add records a device spec discovered at uri.
+func (d *discovery) add(uri string, spec *v1.DeviceSpec) {
+	d.seen[uri] = true
+	d.specs = append(d.specs, spec)
+}
+
+// parentOf returns the device that components of the chassis or system at
+// odataID attach to, or the zero parentRef when it is unknown.
+func (d *discovery) parentOf(odataID string) parentRef {
+	if odataID == "" {
+		return parentRef{}
+	}
+	return d.parents[redfishPath(odataID)]
+}
+
+// expandQuery returns the $expand query that makes the service return
+// collection members inline, or "" when its root advertises none.
+func (d *discovery) expandQuery(ctx context.Context) string {
+	var root RedfishServiceRoot
+	if err := d.getResource(ctx, "/", &root); err != nil {
+		d.warnf("Failed to get service root, not using $expand: %v", err)
+		return ""
+	}
+	expand := root.ProtocolFeaturesSupported.ExpandQuery
+	switch {
+	case expand.NoLinks:
+		// Subordinate resources such as members, but not Links
+		return "$expand=."
+	case expand.ExpandAll:
+		return "$expand=*"
+	}
+	return ""
+}
+
+// getMembers retrieves a collection, following any further pages, and
+// returns its members. A service that rejects $expand on the collection is
+// asked again without it.
+func (d *discovery) getMembers(ctx context.Context, collectionURI string) ([]member, error) {
+	if d.expand != "" {
+		members, err := d.getPages(ctx, collectionURI, collectionURI+"?"+d.expand)
+		if err == nil || ctx.Err() != nil {
+			return members, err
+		}
+		d.warnf("Failed to get %s with %s, retrying without: %v", collectionURI, d.expand, err)
+	}
+	return d.getPages(ctx, collectionURI, collectionURI)
+}
+
+// getPages reads the pages of a collection starting at first.
+func (d *discovery) getPages(ctx context.Context, collectionURI, first string) ([]member, error) {
+	var members []member
+	count := 0
+	pages := make(map[string]bool)
+	for page := first; page != ""; {
+		if pages[page] {
+			return nil, fmt.Errorf("collection %s links back to page %s", collectionURI, page)
+		}
+		pages[page] = true
+
+		var collection RedfishCollection
+		if err := d.getResource(ctx, page, &collection); err != nil {
+			return nil, err
+		}
+		for _, raw := range collection.Members {
+			if m, ok := parseMember(raw); ok {
+				members = append(members, m)
+			}
+		}
+		count = max(count, collection.Count)
+		page = redfishPath(collection.NextLink)
+	}
+	if count > len(members) {
+		d.warnf("Collection %s has %d members but only %d were listed", collectionURI, count, len(members))
+	}
+	return members, nil
+}
+
+// parseMember reads an entry of a collection's Members. An entry with more
+// than its @odata.id is the member itself, expanded by the service.
+func parseMember(raw json.RawMessage) (member, bool) {
+	var resource map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &resource); err != nil {
+		return member{}, false
+	}
+	var odataID string
+	if err := json.Unmarshal(resource["@odata.id"], &odataID); err != nil || odataID == "" {
+		return member{}, false
+	}
+	m := member{URI: redfishPath(odataID)}
+	if len(resource) > 1 {
+		m.Body = raw
+	}
+	return m, true
+}
+
+// fetch gets the body of every member that has none yet, all at once; the
+// client holds the requests to its concurrency limit.
+func (d *discovery) fetch(ctx context.Context, lists ...[]member) {
+	var wg sync.WaitGroup
+	for _, list := range lists {
+		for i := range list {
+			m := &list[i]
+			if m.Body != nil || m.Err != nil {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.Body, m.Err = d.client.Get(ctx, m.URI)
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+// decodeMember decodes a fetched member into v.
+func decodeMember(m member, v interface{}) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	if err := json.Unmarshal(m.Body, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", m.URI, err)
+	}
+	return nil
+}
+
+// getResource retrieves a Redfish resource and decodes it into v.
+func (d *discovery) getResource(ctx context.Context, uri string, v interface{}) error {
+	body, err := d.client.Get(ctx, uri)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", uri, err)
+	}
+	return nil
+}
+
+// linkMembers returns the resources a list of Redfish links points to.
+func linkMembers(links []RedfishLink) []member {
+	members := make([]member, 0, len(links))
+	for _, link := range links {
+		if uri := redfishPath(link.ODataID); uri != "" {
+			members = append(members, member{URI: uri})
+		}
+	}
+	return members
+}
+
+// redfishPath turns an @odata.id into a path under the service root that can
+// be passed to Get. BMCs differ in whether ids carry the /redfish/v1 prefix, a
+// leading or trailing slash, or the scheme and host, so every form of the same
+// id maps to one path.
+func redfishPath(odataID string) string {
+	if odataID == "" {
+		return ""
+	}
+	if u, err := url.Parse(odataID); err == nil && u.IsAbs() {
+		odataID = u.RequestURI()
+		if u.Fragment != "" {
+			odataID += "#" + u.Fragment
+		}
+	}
+	path, suffix := odataID, ""
+	if i := strings.IndexAny(odataID, "?#"); i >= 0 {
+		path, suffix = odataID[:i], odataID[i:]
+	}
+	path = "/" + strings.Trim(path, "/")
+	if path == "/redfish/v1" {
+		path = "/"
+	} else if strings.HasPrefix(path, "/redfish/v1/") {
+		path = strings.TrimPrefix(path, "/redfish/v1")
+	}
+	return path + suffix
+}
+
+// mapCommonProperties maps Redfish fields to the API's DeviceSpec struct.
+func mapCommonProperties(rfProps CommonRedfishProperties, deviceType, redfishURI, parentURI, parentSerial string) *v1.DeviceSpec {
+	partNum := rfProps.PartNumber
+	if partNum == "" {
+		partNum = rfProps.Model
+	}
+	uriBytes, _ := json.Marshal(redfishURI)
+	parentURIBytes, _ := json.Marshal(parentURI)
+	props := map[string]json.RawMessage{
+		v1.PropertyRedfishURI:       uriBytes,
+		v1.PropertyRedfishParentURI: parentURIBytes,
+	}
+
+	// Optional fields are only recorded when the BMC reports them
+	for key, value := range map[string]string{
+		v1.PropertyFirmwareVersion: rfProps.FirmwareVersion,
+		v1.PropertySKU:             rfProps.SKU,
+		v1.PropertyHealth:          rfProps.Status.Health,
+		v1.PropertyState:           rfProps.Status.State,
+		v1.PropertyLocation:        rfProps.Location.PartLocation.ServiceLabel,
+		v1.PropertyPhysicalContext: rfProps.PhysicalContext,
+	} {
+		setProperty(props, key, value)
+	}
+
+	return &v1.DeviceSpec{
+		DeviceType:         deviceType,
+		Manufacturer:       rfProps.Manufacturer,
+		PartNumber:         partNum,
+		SerialNumber:       rfProps.SerialNumber,
+		Properties:         props,
+		ParentSerialNumber: parentSerial,
+	}
+}
+
+// mapCapacity records the size of components that report one.
+func mapCapacity(component interface{}, props map[string]json.RawMessage) {
+	switch c := component.(type) {
+	case *RedfishProcessor:
+		setProperty(props, v1.PropertyTotalCores, c.TotalCores)
+		setProperty(props, v1.PropertyTotalThreads, c.TotalThreads)
+	case *RedfishMemory:
+		setProperty(props, v1.PropertyCapacityMiB, c.CapacityMiB)
+	case *RedfishDrive:
+		setProperty(props, v1.PropertyCapacityBytes, c.CapacityBytes)
+	}
+}
+
+// setProperty stores value under key unless it is the zero value.
+func setProperty[T comparable](props map[string]json.RawMessage, key string, value T) {
+	var zero T
+	if value == zero {
+		return
+	}
+	if raw, err := json.Marshal(value); err == nil {
+		props[key] = raw
+	}
+}
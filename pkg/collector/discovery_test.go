@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/redfishmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// discoverFixture walks a mock BMC serving the named fixture.
+func discoverFixture(t *testing.T, fixture string, pageSize int) ([]*v1.DeviceSpec, *redfishmock.Server) {
+	t.Helper()
+	bmc := mockBMC(t, fixture)
+	bmc.PageSize = pageSize
+	return discover(t, bmc, DefaultRedfishOptions()), bmc
+}
+
+// mockBMC starts a mock BMC serving the named fixture behind session auth.
+func mockBMC(t *testing.T, fixture string) *redfishmock.Server {
+	t.Helper()
+	resources, err := redfishmock.Fixture(fixture)
+	require.NoError(t, err)
+	bmc := redfishmock.New(resources)
+	t.Cleanup(bmc.Close)
+	bmc.Username, bmc.Password, bmc.Sessions = "root", "secret", true
+	return bmc
+}
+
+// discover walks bmc with the given options.
+func discover(t *testing.T, bmc *redfishmock.Server, options RedfishOptions) []*v1.DeviceSpec {
+	t.Helper()
+	client, err := NewRedfishClient(bmc.URL, Credentials{Username: "root", Password: "secret"}, AuthSession, bmc.Client())
+	require.NoError(t, err)
+	client.SetOptions(options)
+	specs, err := Discover(context.Background(), client)
+	require.NoError(t, err)
+	require.NoError(t, client.Close(context.Background()))
+	return specs
+}
+
+// byURI indexes specs by their redfish_uri property.
+func byURI(t *testing.T, specs []*v1.DeviceSpec) map[string]*v1.DeviceSpec {
+	t.Helper()
+	index := make(map[string]*v1.DeviceSpec, len(specs))
+	for _, spec := range specs {
+		var uri string
+		require.NoError(t, json.Unmarshal(spec.Properties[v1.PropertyRedfishURI], &uri))
+		require.NotContains(t, index, uri, "device reported twice")
+		index[uri] = spec
+	}
+	return index
+}
+
+func countTypes(specs []*v1.DeviceSpec) map[string]int {
+	counts := make(map[string]int)
+	for _, spec := range specs {
+		counts[spec.DeviceType]++
+	}
+	return counts
+}
+
+func TestDiscoverStandardServer(t *testing.T) {
+	specs, _ := discoverFixture(t, "standard", 0)
+
+	assert.Equal(t, map[string]int{
+		"Node": 1, "CPU": 2, "GPU": 1, "DIMM": 4, "Drive": 2, "PSU": 2, "Fan": 2, "NIC": 1, "BMC": 1,
+	}, countTypes(specs))
+
+	devices := byURI(t, specs)
+	// The rack-mount chassis shares the system's serial, so it folds into the Node
+	node := devices["/Systems/1"]
+	require.NotNil(t, node)
+	assert.Equal(t, "SYS-0001", node.SerialNumber)
+	assert.Empty(t, node.ParentSerialNumber)
+	assert.NotContains(t, devices, "/Chassis/1")
+
+	for uri, spec := range devices {
+		if spec.DeviceType != "Node" {
+			assert.Equal(t, "SYS-0001", spec.ParentSerialNumber, uri)
+		}
+	}
+
+	cpu := devices["/Systems/1/Processors/CPU2"]
+	assert.JSONEq(t, `16`, string(cpu.Properties[v1.PropertyTotalCores]))
+	assert.JSONEq(t, `"Warning"`, string(cpu.Properties[v1.PropertyHealth]))
+	assert.JSONEq(t, `"CPU 2"`, string(cpu.Properties[v1.PropertyLocation]))
+	assert.Equal(t, "GPU", devices["/Systems/1/Processors/GPU1"].DeviceType)
+	assert.JSONEq(t, `"4.40.10.00"`, string(devices["/Managers/BMC"].Properties[v1.PropertyFirmwareVersion]))
+	assert.Contains(t, devices, "/Chassis/1/Thermal#/Fans/1")
+}
+
+func TestDiscoverVendorQuirks(t *testing.T) {
+	specs, bmc := discoverFixture(t, "quirks", 2)
+
+	assert.Equal(t, map[string]int{
+		"Node": 1, "CPU": 2, "DIMM": 5, "Drive": 1, "PSU": 2, "BMC": 1,
+	}, countTypes(specs))
+
+	// Five DIMMs in pages of two
+	assert.Equal(t, 3, bmc.Requests("/redfish/v1/Systems/Self/Memory"))
+
+	// Ids without the service root, with trailing slashes or as full URLs all
+	// map to the same path, so the drive linked from storage and chassis is
+	// reported once
+	devices := byURI(t, specs)
+	for _, uri := range []string{
+		"/Systems/Self",
+		"/Systems/Self/Processors/0",
+		"/Systems/Self/Memory/DIMM4",
+		"/Chassis/Self/Drives/0",
+		"/Chassis/Self/Power#/PowerSupplies/1",
+		"/Managers/Self",
+	} {
+		assert.Contains(t, devices, uri)
+	}
+
+	// Components without serials are still reported, under their Node
+	dimm := devices["/Systems/Self/Memory/DIMM3"]
+	require.NotNil(t, dimm)
+	assert.Empty(t, dimm.SerialNumber)
+	assert.Equal(t, "WB-0042", dimm.ParentSerialNumber)
+	assert.Empty(t, devices["/Chassis/Self/Power#/PowerSupplies/1"].SerialNumber)
+	assert.Equal(t, "WB-0042", devices["/Managers/Self"].ParentSerialNumber)
+}
+
+func TestDiscoverWithExpand(t *testing.T) {
+	plain, _ := discoverFixture(t, "standard", 0)
+
+	for _, pageSize := range []int{0, 2} {
+		bmc := mockBMC(t, "standard")
+		bmc.Expand = true
+		bmc.PageSize = pageSize
+		specs := discover(t, bmc, DefaultRedfishOptions())
+
+		// The same devices, in the same order, without a request per member
+		assert.Equal(t, plain, specs, "page size %d", pageSize)
+		assert.Zero(t, bmc.Requests("/redfish/v1/Systems/1/Memory/DIMM1"), "page size %d", pageSize)
+		assert.Zero(t, bmc.Requests("/redfish/v1/Systems/1"), "page size %d", pageSize)
+	}
+
+	// Switched off, members are fetched one by one again
+	bmc := mockBMC(t, "standard")
+	bmc.Expand = true
+	options := DefaultRedfishOptions()
+	options.NoExpand = true
+	assert.Equal(t, plain, discover(t, bmc, options))
+	assert.Equal(t, 1, bmc.Requests("/redfish/v1/Systems/1/Memory/DIMM1"))
+}
+
+func TestDiscoverRetriesBusyBMC(t *testing.T) {
+	bmc := mockBMC(t, "standard")
+	bmc.Fail("/redfish/v1/Systems", http.StatusTooManyRequests, 1, "")
+	bmc.Fail("/redfish/v1/Systems/1/Processors/CPU1", http.StatusServiceUnavailable, 2, "")
+	bmc.Fail("/redfish/v1/Systems/1/Memory/DIMM1", http.StatusInternalServerError, 10, "")
+
+	options := DefaultRedfishOptions()
+	options.Retries = 2
+	options.RetryDelay = time.Millisecond
+	devices := byURI(t, discover(t, bmc, options))
+
+	assert.Contains(t, devices, "/Systems/1")
+	assert.Equal(t, 2, bmc.Requests("/redfish/v1/Systems"))
+	assert.Contains(t, devices, "/Systems/1/Processors/CPU1")
+	assert.Equal(t, 3, bmc.Requests("/redfish/v1/Systems/1/Processors/CPU1"))
+	// A member that keeps failing is given up on after the retries
+	assert.NotContains(t, devices, "/Systems/1/Memory/DIMM1")
+	assert.Equal(t, 3, bmc.Requests("/redfish/v1/Systems/1/Memory/DIMM1"))
+}
+
+func TestDiscoverLimitsConcurrency(t *testing.T) {
+	bmc := mockBMC(t, "standard")
+	options := DefaultRedfishOptions()
+	options.Concurrency = 2
+	assert.Len(t, discover(t, bmc, options), 16)
+	assert.LessOrEqual(t, bmc.PeakConcurrency(), 2)
+}
+
+func TestRedfishPath(t *testing.T) {
+	for odataID, want := range map[string]string{
+		"":                       "",
+		"/redfish/v1":            "/",
+		"/redfish/v1/Systems/1":  "/Systems/1",
+		"/redfish/v1/Systems/1/": "/Systems/1",
+		"redfish/v1/Systems/1":   "/Systems/1",
+		"Systems/1":              "/Systems/1",
+		"https://bmc.example.com/redfish/v1/Systems/1": "/Systems/1",
+		"/redfish/v1/Chassis/1/Power#/PowerSupplies/0": "/Chassis/1/Power#/PowerSupplies/0",
+		"/redfish/v1/Systems?$skip=2":                  "/Systems?$skip=2",
+	} {
+		assert.Equal(t, want, redfishPath(odataID), odataID)
+	}
+}
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package host
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/pkg/collector"
+)
+
+// SMBIOS structure types the dmidecode source reads.
+const (
+	dmiTypeBIOS        = 0
+	dmiTypeSystem      = 1
+	dmiTypeProcessor   = 4
+	dmiTypeMemory      = 17
+	dmiTypePowerSupply = 39
+)
+
+func init() {
+	Register("dmidecode", func(node Node) collector.Source {
+		return &dmidecodeSource{node: node, run: runCommand}
+	})
+}
+
+// dmidecodeSource reads the node, its CPUs, DIMMs and PSUs from the SMBIOS
+// tables decoded by dmidecode. It needs root.
+type dmidecodeSource struct {
+	node Node
+	run  runner
+}
+
+func (s *dmidecodeSource) Name() string { return "dmidecode" }
+
+func (s *dmidecodeSource) Collect(ctx context.Context) ([]*v1.DeviceSpec, error) {
+	out, err := s.run(ctx, "dmidecode", "-t", "0,1,4,17,39")
+	if err != nil {
+		return nil, err
+	}
+	return s.node.mapDMI(parseDMIDecode(out)), nil
+}
+
+// dmiRecord is one SMBIOS structure from dmidecode's output.
+type dmiRecord struct {
+	Type   int
+	Fields map[string]string
+}
+
+// dmiHandle matches the line that starts each structure, e.g.
+// "Handle 0x0011, DMI type 17, 40 bytes".
+var dmiHandle = regexp.MustCompile(`^Handle 0x[0-9A-Fa-f]+, DMI type (\d+),`)
+
+// parseDMIDecode splits dmidecode's text output into records. Multi-line
+// values such as processor flags are skipped.
+func parseDMIDecode(out []byte) []dmiRecord {
+	var (
+		records []dmiRecord
+		current *dmiRecord
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := dmiHandle.FindStringSubmatch(line); match != nil {
+			dmiType, _ := strconv.Atoi(match[1])
+			records = append(records, dmiRecord{Type: dmiType, Fields: map[string]string{}})
+			current = &records[len(records)-1]
+			continue
+		}
+		if current == nil || !strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "\t\t") {
+			continue
+		}
+		if key, value, ok := strings.Cut(strings.TrimPrefix(line, "\t"), ":"); ok {
+			current.Fields[key] = strings.TrimSpace(value)
+		}
+	}
+	return records
+}
+
+// mapDMI turns SMBIOS records into specs for the node and its components.
+func (n Node) mapDMI(records []dmiRecord) []*v1.DeviceSpec {
+	node := n.node()
+	specs := []*v1.DeviceSpec{node}
+	for _, record := range records {
+		fields := record.Fields
+		switch record.Type {
+		case dmiTypeBIOS:
+			setProperty(node.Properties, v1.PropertyFirmwareVersion, clean(fields["Version"]))
+		case dmiTypeSystem:
+			node.Manufacturer = clean(fields["Manufacturer"])
+			node.PartNumber = clean(fields["Product Name"])
+			node.SerialNumber = clean(fields["Serial Number"])
+			setProperty(node.Properties, v1.PropertySKU, clean(fields["SKU Number"]))
+		case dmiTypeProcessor:
+			socket := clean(fields["Socket Designation"])
+			if socket == "" || strings.Contains(fields["Status"], "Unpopulated") {
+				continue
+			}
+			cpu := n.component("CPU", "cpu", socket)
+			cpu.Manufacturer = clean(fields["Manufacturer"])
+			cpu.PartNumber = firstOf(clean(fields["Part Number"]), clean(fields["Version"]))
+			cpu.SerialNumber = clean(fields["Serial Number"])
+			setProperty(cpu.Properties, v1.PropertyLocation, socket)
+			setProperty(cpu.Properties, v1.PropertyTotalCores, atoi(fields["Core Count"]))
+			setProperty(cpu.Properties, v1.PropertyTotalThreads, atoi(fields["Thread Count"]))
+			specs = append(specs, cpu)
+		case dmiTypeMemory:
+			slot := clean(fields["Locator"])
+			capacity := parseDMISize(fields["Size"])
+			if slot == "" || capacity == 0 {
+				continue
+			}
+			dimm := n.component("DIMM", "memory", slot)
+			dimm.Manufacturer = clean(fields["Manufacturer"])
+			dimm.PartNumber = clean(fields["Part Number"])
+			dimm.SerialNumber = clean(fields["Serial Number"])
+			setProperty(dimm.Properties, v1.PropertyLocation, slot)
+			setProperty(dimm.Properties, v1.PropertyCapacityMiB, capacity)
+			specs = append(specs, dimm)
+		case dmiTypePowerSupply:
+			location := firstOf(clean(fields["Location"]), clean(fields["Name"]))
+			if location == "" || strings.HasPrefix(fields["Status"], "Not Present") {
+				continue
+			}
+			psu := n.component("PSU", "psu", location)
+			psu.Manufacturer = clean(fields["Manufacturer"])
+			psu.PartNumber = clean(fields["Model Part Number"])
+			psu.SerialNumber = clean(fields["Serial Number"])
+			setProperty(psu.Properties, v1.PropertyLocation, location)
+			specs = append(specs, psu)
+		}
+	}
+	return specs
+}
+
+// parseDMISize converts a memory size such as "32 GB" or "16384 MB" to MiB.
+// Empty slots ("No Module Installed") are 0.
+func parseDMISize(size string) int {
+	value, unit, ok := strings.Cut(strings.TrimSpace(size), " ")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	switch unit {
+	case "MB":
+		return n
+	case "GB":
+		return n * 1024
+	case "TB":
+		return n * 1024 * 1024
+	}
+	return 0
+}
+
+func atoi(value string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(value))
+	return n
+}
+
+// firstOf returns the first non-empty value.
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
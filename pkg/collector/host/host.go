@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package host provides collector sources that read the hardware inventory of
+// the Linux machine the collector runs on, for nodes without a reachable BMC
+// or to inventory a node from inside the OS at boot.
+//
+// Every source parents its components to the node it runs on. Devices are
+// identified by serial number where the hardware reports one, and otherwise
+// by a locator such as host://<system uuid>/pci/0000:3b:00 stored in the
+// redfish_uri property, so repeated collections update the same devices.
+package host
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/pkg/collector"
+)
+
+// Factory creates a source reading the inventory of node.
+type Factory func(node Node) collector.Source
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes a source available under name. Sources outside this
+// package can register themselves from an init function.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[name]; exists {
+		panic("host: source registered twice: " + name)
+	}
+	registry[name] = factory
+}
+
+// New creates the source registered under name.
+func New(name string, node Node) (collector.Source, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown host source %s (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return factory(node), nil
+}
+
+// Names lists the registered sources in alphabetical order.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Node identifies the machine the sources run on.
+type Node struct {
+	Serial   string
+	UUID     string
+	Hostname string
+}
+
+// DetectNode reads the identity of the machine from the SMBIOS tables that
+// Linux exposes under root/sys/class/dmi/id. The serial number and UUID are
+// only readable by root; without them the node is known by its hostname.
+func DetectNode(root string) Node {
+	hostname, _ := os.Hostname()
+	dmi := filepath.Join(root, sysDMIPath)
+	return Node{
+		Serial:   clean(readFile(filepath.Join(dmi, "product_serial"))),
+		UUID:     strings.ToLower(clean(readFile(filepath.Join(dmi, "product_uuid")))),
+		Hostname: hostname,
+	}
+}
+
+// Locator returns the node's locator, built from the most stable identifier
+// it has.
+func (n Node) Locator() string {
+	switch {
+	case n.UUID != "":
+		return "host://" + n.UUID
+	case n.Serial != "":
+		return "host://" + n.Serial
+	}
+	return "host://" + n.Hostname
+}
+
+// node returns the spec of the node itself.
+func (n Node) node() *v1.DeviceSpec {
+	return n.spec("Node", n.Locator(), "")
+}
+
+// component returns the spec of a device in the node, located at kind/id
+// under the node's locator.
+func (n Node) component(deviceType, kind, id string) *v1.DeviceSpec {
+	return n.spec(deviceType, n.Locator()+"/"+kind+"/"+id, n.Locator())
+}
+
+func (n Node) spec(deviceType, locator, parentLocator string) *v1.DeviceSpec {
+	spec := &v1.DeviceSpec{
+		DeviceType: deviceType,
+		Properties: map[string]json.RawMessage{},
+	}
+	setProperty(spec.Properties, v1.PropertyRedfishURI, locator)
+	if parentLocator != "" {
+		spec.ParentSerialNumber = n.Serial
+		setProperty(spec.Properties, v1.PropertyRedfishParentURI, parentLocator)
+	}
+	return spec
+}
+
+// placeholders are values firmware fills unset SMBIOS fields with.
+var placeholders = map[string]bool{
+	"":                                     true,
+	"none":                                 true,
+	"n/a":                                  true,
+	"na":                                   true,
+	"unknown":                              true,
+	"not specified":                        true,
+	"not provided":                         true,
+	"not available":                        true,
+	"not applicable":                       true,
+	"to be filled by o.e.m.":               true,
+	"default string":                       true,
+	"system serial number":                 true,
+	"system product name":                  true,
+	"system manufacturer":                  true,
+	"0123456789":                           true,
+	"123456789":                            true,
+	"no dimm":                              true,
+	"unknown manufacturer":                 true,
+	"03000200-0400-0500-0006-000700080009": true,
+	"00000000-0000-0000-0000-000000000000": true,
+	"ffffffff-ffff-ffff-ffff-ffffffffffff": true,
+}
+
+// clean trims a reported value and drops firmware placeholders.
+func clean(value string) string {
+	value = strings.TrimSpace(value)
+	if placeholders[strings.ToLower(value)] {
+		return ""
+	}
+	return value
+}
+
+// setProperty stores value under key unless it is the zero value.
+func setProperty[T comparable](props map[string]json.RawMessage, key string, value T) {
+	var zero T
+	if value == zero {
+		return
+	}
+	if raw, err := json.Marshal(value); err == nil {
+		props[key] = raw
+	}
+}
+
+// runner executes a command and returns its standard output. Sources take one
+// so tests can feed them recorded output.
+type runner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("%s failed: %w", name, err)
+	}
+	return out, nil
+}
+
+func readFile(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package host
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/pkg/collector"
+)
+
+const testLocator = "host://4c4c4544-0042-3010-8052-b4c04f4e3332"
+
+// recorded returns a runner that answers each command with a file from
+// testdata.
+func recorded(outputs map[string]string) runner {
+	return func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		file, ok := outputs[name]
+		if !ok {
+			return nil, fmt.Errorf("%s: executable file not found", name)
+		}
+		return os.ReadFile(filepath.Join("testdata", file))
+	}
+}
+
+func testNode(t *testing.T) Node {
+	node := DetectNode("testdata")
+	require.Equal(t, "S123456X", node.Serial)
+	require.Equal(t, testLocator, node.Locator())
+	return node
+}
+
+// byLocator indexes specs by their locator.
+func byLocator(t *testing.T, specs []*v1.DeviceSpec) map[string]*v1.DeviceSpec {
+	index := make(map[string]*v1.DeviceSpec, len(specs))
+	for _, spec := range specs {
+		var locator string
+		require.NoError(t, json.Unmarshal(spec.Properties[v1.PropertyRedfishURI], &locator))
+		require.NotContains(t, index, locator, "duplicate device")
+		index[locator] = spec
+	}
+	return index
+}
+
+func TestSysfsSource(t *testing.T) {
+	source := &sysfsSource{node: testNode(t), root: "testdata"}
+	specs, err := source.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, specs, 1)
+
+	node := specs[0]
+	assert.Equal(t, "Node", node.DeviceType)
+	assert.Equal(t, "Supermicro", node.Manufacturer)
+	assert.Equal(t, "SYS-2029U", node.PartNumber)
+	assert.Equal(t, "S123456X", node.SerialNumber)
+	assert.JSONEq(t, `"3.4"`, string(node.Properties[v1.PropertyFirmwareVersion]))
+	// The SKU is a firmware placeholder
+	assert.NotContains(t, node.Properties, v1.PropertySKU)
+
+	_, err = (&sysfsSource{node: testNode(t), root: t.TempDir()}).Collect(context.Background())
+	assert.ErrorContains(t, err, "no SMBIOS information")
+}
+
+func TestDmidecodeSource(t *testing.T) {
+	source := &dmidecodeSource{node: testNode(t), run: recorded(map[string]string{"dmidecode": "dmidecode.txt"})}
+	specs, err := source.Collect(context.Background())
+	require.NoError(t, err)
+
+	devices := byLocator(t, specs)
+	// The node, one populated socket, two DIMMs and one present PSU
+	require.Len(t, devices, 5)
+
+	cpu := devices[testLocator+"/cpu/CPU1"]
+	require.NotNil(t, cpu)
+	assert.Equal(t, "CPU", cpu.DeviceType)
+	assert.Equal(t, "Intel(R) Xeon(R) Gold 6248 CPU @ 2.50GHz", cpu.PartNumber)
+	assert.Empty(t, cpu.SerialNumber)
+	assert.Equal(t, "S123456X", cpu.ParentSerialNumber)
+	assert.JSONEq(t, `20`, string(cpu.Properties[v1.PropertyTotalCores]))
+	assert.JSONEq(t, `40`, string(cpu.Properties[v1.PropertyTotalThreads]))
+	assert.JSONEq(t, `"`+testLocator+`"`, string(cpu.Properties[v1.PropertyRedfishParentURI]))
+
+	dimm := devices[testLocator+"/memory/DIMM_A1"]
+	require.NotNil(t, dimm)
+	assert.Equal(t, "41A2B3C4", dimm.SerialNumber)
+	assert.Equal(t, "M393A4K40DB2-CVF", dimm.PartNumber)
+	assert.JSONEq(t, `32768`, string(dimm.Properties[v1.PropertyCapacityMiB]))
+	assert.JSONEq(t, `16384`, string(devices[testLocator+"/memory/DIMM_B1"].Properties[v1.PropertyCapacityMiB]))
+
+	psu := devices[testLocator+"/psu/PSU1"]
+	require.NotNil(t, psu)
+	assert.Equal(t, "P1K6A0123", psu.SerialNumber)
+
+	node := devices[testLocator]
+	assert.Equal(t, "S123456X", node.SerialNumber)
+	assert.JSONEq(t, `"3.4"`, string(node.Properties[v1.PropertyFirmwareVersion]))
+}
+
+func TestLshwSource(t *testing.T) {
+	source := &lshwSource{node: testNode(t), run: recorded(map[string]string{"lshw": "lshw.json"})}
+	specs, err := source.Collect(context.Background())
+	require.NoError(t, err)
+
+	devices := byLocator(t, specs)
+	// The node, a CPU, a DIMM, the NIC's two ports as one card, a GPU, a
+	// drive and a PSU
+	require.Len(t, devices, 7)
+
+	assert.Equal(t, "S123456X", devices[testLocator].SerialNumber)
+	assert.JSONEq(t, `32768`, string(devices[testLocator+"/memory/DIMM_A1"].Properties[v1.PropertyCapacityMiB]))
+
+	nic := devices[testLocator+"/pci/0000:3b:00"]
+	require.NotNil(t, nic)
+	assert.Equal(t, "NIC", nic.DeviceType)
+	assert.Empty(t, nic.SerialNumber, "MAC addresses are not serial numbers")
+	assert.Equal(t, "GPU", devices[testLocator+"/pci/0000:af:00"].DeviceType)
+
+	drive := devices[testLocator+"/drive/nvme@0:1"]
+	require.NotNil(t, drive)
+	assert.Equal(t, "S64GNE0R000001", drive.SerialNumber)
+	assert.JSONEq(t, `1920383410176`, string(drive.Properties[v1.PropertyCapacityBytes]))
+
+	// A single object, as printed by older lshw releases
+	roots, err := parseLSHW([]byte(`{"id": "node01", "class": "system", "serial": "S1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "S1", roots[0].Serial)
+}
+
+func TestLspciSource(t *testing.T) {
+	source := &lspciSource{node: testNode(t), run: recorded(map[string]string{"lspci": "lspci.txt"})}
+	specs, err := source.Collect(context.Background())
+	require.NoError(t, err)
+
+	devices := byLocator(t, specs)
+	// Bridges and on-board controllers are not reported
+	require.Len(t, devices, 3)
+	assert.Equal(t, "NIC", devices[testLocator+"/pci/0000:3b:00"].DeviceType)
+	assert.JSONEq(t, `"2"`, string(devices[testLocator+"/pci/0000:3b:00"].Properties[v1.PropertyLocation]))
+	assert.Equal(t, "PCIeDevice", devices[testLocator+"/pci/0000:86:00"].DeviceType)
+	assert.Equal(t, "GPU", devices[testLocator+"/pci/0000:af:00"].DeviceType)
+}
+
+func TestSourcesMerge(t *testing.T) {
+	node := testNode(t)
+	run := recorded(map[string]string{"dmidecode": "dmidecode.txt", "lshw": "lshw.json", "lspci": "lspci.txt"})
+	specs, err := collector.CollectSources(context.Background(), []collector.Source{
+		&sysfsSource{node: node, root: "testdata"},
+		&dmidecodeSource{node: node, run: run},
+		&lshwSource{node: node, run: run},
+		&lspciSource{node: node, run: run},
+	})
+	require.NoError(t, err)
+
+	devices := byLocator(t, specs)
+	// dmidecode's five, plus the NIC, GPU and drive from lshw and the RAID
+	// controller only lspci sees
+	assert.Len(t, devices, 9)
+	cpu := devices[testLocator+"/cpu/CPU1"]
+	assert.Equal(t, "Intel(R) Corporation", cpu.Manufacturer, "the first source to report a field wins")
+}
+
+func TestRegistry(t *testing.T) {
+	assert.Equal(t, []string{"dmidecode", "lshw", "lspci", "sysfs"}, Names())
+
+	source, err := New("lspci", Node{Hostname: "node01"})
+	require.NoError(t, err)
+	assert.Equal(t, "lspci", source.Name())
+
+	_, err = New("ipmitool", Node{})
+	assert.ErrorContains(t, err, "unknown host source ipmitool")
+	assert.Panics(t, func() { Register("lspci", nil) })
+}
+
+func TestLocatorFallsBack(t *testing.T) {
+	assert.Equal(t, "host://S1", Node{Serial: "S1", Hostname: "node01"}.Locator())
+	assert.Equal(t, "host://node01", Node{Hostname: "node01"}.Locator())
+}
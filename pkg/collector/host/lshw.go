@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package host
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/pkg/collector"
+)
+
+func init() {
+	Register("lshw", func(node Node) collector.Source {
+		return &lshwSource{node: node, run: runCommand}
+	})
+}
+
+// lshwSource reads the node's CPUs, DIMMs, drives, NICs, GPUs and PSUs from
+// the device tree printed by lshw -json. It needs root to see serial numbers.
+type lshwSource struct {
+	node Node
+	run  runner
+}
+
+func (s *lshwSource) Name() string { return "lshw" }
+
+func (s *lshwSource) Collect(ctx context.Context) ([]*v1.DeviceSpec, error) {
+	out, err := s.run(ctx, "lshw", "-json", "-quiet")
+	if err != nil {
+		return nil, err
+	}
+	roots, err := parseLSHW(out)
+	if err != nil {
+		return nil, err
+	}
+	return s.node.mapLSHW(roots), nil
+}
+
+// lshwNode is one entry of lshw's device tree.
+type lshwNode struct {
+	ID          string     `json:"id"`
+	Class       string     `json:"class"`
+	Description string     `json:"description"`
+	Product     string     `json:"product"`
+	Vendor      string     `json:"vendor"`
+	Serial      string     `json:"serial"`
+	Slot        string     `json:"slot"`
+	BusInfo     string     `json:"businfo"`
+	Version     string     `json:"version"`
+	Size        uint64     `json:"size"`
+	Units       string     `json:"units"`
+	Children    []lshwNode `json:"children"`
+	Config      struct {
+		Cores   string `json:"cores"`
+		Threads string `json:"threads"`
+	} `json:"configuration"`
+}
+
+// parseLSHW decodes lshw -json output. Releases before B.02.19 print a single
+// object; later ones wrap it in an array.
+func parseLSHW(out []byte) ([]lshwNode, error) {
+	out = bytes.TrimSpace(out)
+	if bytes.HasPrefix(out, []byte("[")) {
+		var roots []lshwNode
+		if err := json.Unmarshal(out, &roots); err != nil {
+			return nil, fmt.Errorf("failed to decode lshw output: %w", err)
+		}
+		return roots, nil
+	}
+	var root lshwNode
+	if err := json.Unmarshal(out, &root); err != nil {
+		return nil, fmt.Errorf("failed to decode lshw output: %w", err)
+	}
+	return []lshwNode{root}, nil
+}
+
+// mapLSHW walks the device tree and turns the FRUs in it into specs.
+func (n Node) mapLSHW(roots []lshwNode) []*v1.DeviceSpec {
+	node := n.node()
+	specs := []*v1.DeviceSpec{node}
+
+	var walk func(entry lshwNode)
+	walk = func(entry lshwNode) {
+		if spec := n.lshwSpec(node, entry); spec != nil {
+			specs = append(specs, spec)
+		}
+		for _, child := range entry.Children {
+			walk(child)
+		}
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+	// Each function of a multi-port card is an entry of its own
+	return collector.MergeSpecs(specs)
+}
+
+// lshwSpec maps one entry to a spec. The system entry fills in node and
+// entries that are not FRUs return nil.
+func (n Node) lshwSpec(node *v1.DeviceSpec, entry lshwNode) *v1.DeviceSpec {
+	var spec *v1.DeviceSpec
+	switch {
+	case entry.Class == "system" && node.SerialNumber == "":
+		node.Manufacturer = clean(entry.Vendor)
+		node.PartNumber = clean(entry.Product)
+		node.SerialNumber = clean(entry.Serial)
+		return nil
+	case entry.Class == "processor" && strings.HasPrefix(entry.ID, "cpu") && clean(entry.Slot) != "" &&
+		!strings.Contains(entry.Description, "[empty]"):
+		spec = n.component("CPU", "cpu", clean(entry.Slot))
+		setProperty(spec.Properties, v1.PropertyLocation, clean(entry.Slot))
+		setProperty(spec.Properties, v1.PropertyTotalCores, atoi(entry.Config.Cores))
+		setProperty(spec.Properties, v1.PropertyTotalThreads, atoi(entry.Config.Threads))
+	case entry.Class == "memory" && strings.HasPrefix(entry.ID, "bank") && entry.Size > 0 && clean(entry.Slot) != "":
+		spec = n.component("DIMM", "memory", clean(entry.Slot))
+		setProperty(spec.Properties, v1.PropertyLocation, clean(entry.Slot))
+		setProperty(spec.Properties, v1.PropertyCapacityMiB, int(entry.Size>>20))
+	case entry.Class == "disk" && entry.Size > 0:
+		id := firstOf(entry.BusInfo, clean(entry.Serial))
+		if id == "" {
+			return nil
+		}
+		spec = n.component("Drive", "drive", id)
+		setProperty(spec.Properties, v1.PropertyCapacityBytes, entry.Size)
+	case entry.Class == "network" && pciAddress(entry.BusInfo) != "":
+		// The serial lshw reports for an interface is its MAC address
+		spec = n.component("NIC", "pci", pciAddress(entry.BusInfo))
+		spec.Manufacturer = clean(entry.Vendor)
+		spec.PartNumber = clean(entry.Product)
+		return spec
+	case entry.Class == "display" && entry.Description == "3D controller" && pciAddress(entry.BusInfo) != "":
+		spec = n.component("GPU", "pci", pciAddress(entry.BusInfo))
+	case entry.Class == "power":
+		id := firstOf(clean(entry.Slot), clean(entry.Serial), entry.ID)
+		spec = n.component("PSU", "psu", id)
+		setProperty(spec.Properties, v1.PropertyLocation, clean(entry.Slot))
+	default:
+		return nil
+	}
+	spec.Manufacturer = clean(entry.Vendor)
+	spec.PartNumber = clean(entry.Product)
+	spec.SerialNumber = clean(entry.Serial)
+	return spec
+}
+
+// pciAddress returns the bus:device part of a PCI address such as
+// "pci@0000:3b:00.1" or "0000:3b:00.1". The functions of a card share it,
+// so a dual-port NIC is one device.
+func pciAddress(address string) string {
+	address, ok := strings.CutPrefix(address, "pci@")
+	if !ok && strings.Contains(address, "@") {
+		return ""
+	}
+	if i := strings.LastIndexByte(address, '.'); i > 0 {
+		address = address[:i]
+	}
+	if strings.Count(address, ":") != 2 {
+		return ""
+	}
+	return address
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package host
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/pkg/collector"
+)
+
+func init() {
+	Register("lspci", func(node Node) collector.Source {
+		return &lspciSource{node: node, run: runCommand}
+	})
+}
+
+// lspciSource reads the node's PCIe cards from lspci -vmm. It runs
+// without root but sees no serial numbers, so cards are known by their
+// PCI address.
+type lspciSource struct {
+	node Node
+	run  runner
+}
+
+func (s *lspciSource) Name() string { return "lspci" }
+
+func (s *lspciSource) Collect(ctx context.Context) ([]*v1.DeviceSpec, error) {
+	out, err := s.run(ctx, "lspci", "-vmm", "-D")
+	if err != nil {
+		return nil, err
+	}
+	return s.node.mapLSPCI(parseLSPCI(out)), nil
+}
+
+// parseLSPCI splits lspci -vmm output into one field map per function.
+func parseLSPCI(out []byte) []map[string]string {
+	var (
+		functions []map[string]string
+		current   map[string]string
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			current = nil
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if current == nil {
+			current = map[string]string{}
+			functions = append(functions, current)
+		}
+		current[key] = strings.TrimSpace(value)
+	}
+	return functions
+}
+
+// pciDeviceTypes maps lspci classes to device types. Other classes are only
+// reported for cards in a physical slot.
+var pciDeviceTypes = map[string]string{
+	"Ethernet controller":     "NIC",
+	"Network controller":      "NIC",
+	"Infiniband controller":   "NIC",
+	"3D controller":           "GPU",
+	"Processing accelerators": "GPU",
+}
+
+// mapLSPCI turns PCI functions into one spec per card.
+func (n Node) mapLSPCI(functions []map[string]string) []*v1.DeviceSpec {
+	var specs []*v1.DeviceSpec
+	seen := make(map[string]bool)
+	for _, fields := range functions {
+		address := pciAddress(fields["Slot"])
+		if address == "" || seen[address] {
+			continue
+		}
+		deviceType, ok := pciDeviceTypes[fields["Class"]]
+		if !ok {
+			if fields["PhySlot"] == "" {
+				continue
+			}
+			deviceType = "PCIeDevice"
+		}
+		seen[address] = true
+
+		spec := n.component(deviceType, "pci", address)
+		spec.Manufacturer = clean(fields["Vendor"])
+		spec.PartNumber = clean(fields["Device"])
+		setProperty(spec.Properties, v1.PropertyLocation, clean(fields["PhySlot"]))
+		specs = append(specs, spec)
+	}
+	return specs
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package host
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/pkg/collector"
+)
+
+// sysDMIPath is where Linux exposes the SMBIOS system identification.
+const sysDMIPath = "sys/class/dmi/id"
+
+func init() {
+	Register("sysfs", func(node Node) collector.Source {
+		return &sysfsSource{node: node, root: "/"}
+	})
+}
+
+// sysfsSource reads the node itself from /sys/class/dmi/id. It needs no
+// external tools, and only the serial number needs root.
+type sysfsSource struct {
+	node Node
+	root string
+}
+
+func (s *sysfsSource) Name() string { return "sysfs" }
+
+func (s *sysfsSource) Collect(ctx context.Context) ([]*v1.DeviceSpec, error) {
+	dir := filepath.Join(s.root, sysDMIPath)
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("no SMBIOS information in %s: %w", dir, err)
+	}
+	read := func(name string) string {
+		return clean(readFile(filepath.Join(dir, name)))
+	}
+
+	node := s.node.node()
+	node.Manufacturer = read("sys_vendor")
+	node.PartNumber = read("product_name")
+	node.SerialNumber = read("product_serial")
+	setProperty(node.Properties, v1.PropertySKU, read("product_sku"))
+	setProperty(node.Properties, v1.PropertyFirmwareVersion, read("bios_version"))
+	return []*v1.DeviceSpec{node}, nil
+}
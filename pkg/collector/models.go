@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import "encoding/json"
+
+// --- Redfish Helper Structs ---
+// These are used for unmarshaling Redfish JSON
+
+// RedfishServiceRoot defines the parts of the service root the collector
+// reads to learn what the service supports.
+type RedfishServiceRoot struct {
+	ProtocolFeaturesSupported struct {
+		ExpandQuery struct {
+			ExpandAll bool `json:"ExpandAll"`
+			NoLinks   bool `json:"NoLinks"`
+		} `json:"ExpandQuery"`
+	} `json:"ProtocolFeaturesSupported"`
+}
+
+// RedfishCollection defines the structure for Redfish collection responses.
+// Large collections may be split into pages linked by NextLink. Members are
+// links, or whole resources when the collection was requested with $expand.
+type RedfishCollection struct {
+	Members  []json.RawMessage `json:"Members"`
+	Count    int               `json:"Members@odata.count"`
+	NextLink string            `json:"Members@odata.nextLink"`
+}
+
+// CommonRedfishProperties contains the fields required by the Device model,
+// plus the firmware, health and placement fields most resources share.
+type CommonRedfishProperties struct {
+	Manufacturer    string          `json:"Manufacturer,omitempty"`
+	Model           string          `json:"Model,omitempty"`
+	PartNumber      string          `json:"PartNumber,omitempty"`
+	SerialNumber    string          `json:"SerialNumber,omitempty"`
+	FirmwareVersion string          `json:"FirmwareVersion,omitempty"`
+	SKU             string          `json:"SKU,omitempty"`
+	Status          RedfishStatus   `json:"Status"`
+	Location        RedfishLocation `json:"Location"`
+	PhysicalContext string          `json:"PhysicalContext,omitempty"`
+}
+
+// RedfishStatus defines the Status object common to Redfish resources.
+type RedfishStatus struct {
+	Health string `json:"Health,omitempty"`
+	State  string `json:"State,omitempty"`
+}
+
+// RedfishLocation defines the Location object; only the label printed on the
+// slot or bay is kept.
+type RedfishLocation struct {
+	PartLocation struct {
+		ServiceLabel string `json:"ServiceLabel,omitempty"`
+	} `json:"PartLocation"`
+}
+
+// RedfishLink is a reference to another Redfish resource.
+type RedfishLink struct {
+	ODataID string `json:"@odata.id"`
+}
+
+// RedfishSystem defines the structure for a System resource (the Node).
+type RedfishSystem struct {
+	CommonRedfishProperties               // Embeds the common fields
+	Processors              RedfishLink   `json:"Processors"`
+	Memory                  RedfishLink   `json:"Memory"`
+	Storage                 RedfishLink   `json:"Storage"`
+	PCIeDevices             []RedfishLink `json:"PCIeDevices"`
+	Links                   struct {
+		Chassis []RedfishLink `json:"Chassis"`
+	} `json:"Links"`
+}
+
+// RedfishChassis defines the structure for a Chassis resource (an enclosure).
+type RedfishChassis struct {
+	CommonRedfishProperties             // Embeds the common fields
+	Power                   RedfishLink `json:"Power"`
+	Thermal                 RedfishLink `json:"Thermal"`
+	PowerSubsystem          RedfishLink `json:"PowerSubsystem"`
+	ThermalSubsystem        RedfishLink `json:"ThermalSubsystem"`
+	NetworkAdapters         RedfishLink `json:"NetworkAdapters"`
+	PCIeDevices             RedfishLink `json:"PCIeDevices"`
+	Drives                  RedfishLink `json:"Drives"`
+	Links                   struct {
+		ContainedBy     RedfishLink   `json:"ContainedBy"`
+		ComputerSystems []RedfishLink `json:"ComputerSystems"`
+		Drives          []RedfishLink `json:"Drives"`
+	} `json:"Links"`
+}
+
+// RedfishManager defines the structure for a Manager resource (the BMC).
+type RedfishManager struct {
+	CommonRedfishProperties // Embeds the common fields
+	Links                   struct {
+		ManagerInChassis  RedfishLink   `json:"ManagerInChassis"`
+		ManagerForChassis []RedfishLink `json:"ManagerForChassis"`
+		ManagerForServers []RedfishLink `json:"ManagerForServers"`
+	} `json:"Links"`
+}
+
+// RedfishProcessor defines the structure for a Processor resource (the CPU or GPU).
+type RedfishProcessor struct {
+	CommonRedfishProperties        // Embeds the common fields
+	ProcessorType           string `json:"ProcessorType,omitempty"`
+	TotalCores              int    `json:"TotalCores,omitempty"`
+	TotalThreads            int    `json:"TotalThreads,omitempty"`
+}
+
+// RedfishMemory defines the structure for a Memory resource (the DIMM).
+type RedfishMemory struct {
+	CommonRedfishProperties     // Embeds the common fields
+	CapacityMiB             int `json:"CapacityMiB,omitempty"`
+}
+
+// RedfishStorage defines the structure for a Storage resource (a storage controller).
+type RedfishStorage struct {
+	Drives []RedfishLink `json:"Drives"`
+}
+
+// RedfishDrive defines the structure for a Drive resource.
+type RedfishDrive struct {
+	CommonRedfishProperties       // Embeds the common fields
+	CapacityBytes           int64 `json:"CapacityBytes,omitempty"`
+}
+
+// RedfishPCIeDevice defines the structure for a PCIeDevice resource.
+type RedfishPCIeDevice struct {
+	CommonRedfishProperties // Embeds the common fields
+}
+
+// RedfishNetworkAdapter defines the structure for a NetworkAdapter resource (the NIC).
+type RedfishNetworkAdapter struct {
+	CommonRedfishProperties // Embeds the common fields
+}
+
+// RedfishPowerSupply defines the structure for a power supply, either a
+// PowerSubsystem member or an entry of the legacy Power resource.
+type RedfishPowerSupply struct {
+	CommonRedfishProperties        // Embeds the common fields
+	ODataID                 string `json:"@odata.id"`
+}
+
+// RedfishFan defines the structure for a fan, either a ThermalSubsystem
+// member or an entry of the legacy Thermal resource.
+type RedfishFan struct {
+	CommonRedfishProperties        // Embeds the common fields
+	ODataID                 string `json:"@odata.id"`
+}
+
+// RedfishPowerSubsystem defines the structure for a PowerSubsystem resource.
+type RedfishPowerSubsystem struct {
+	PowerSupplies RedfishLink `json:"PowerSupplies"`
+}
+
+// RedfishThermalSubsystem defines the structure for a ThermalSubsystem resource.
+type RedfishThermalSubsystem struct {
+	Fans RedfishLink `json:"Fans"`
+}
+
+// RedfishPower defines the structure for the legacy Power resource.
+type RedfishPower struct {
+	PowerSupplies []RedfishPowerSupply `json:"PowerSupplies"`
+}
+
+// RedfishThermal defines the structure for the legacy Thermal resource.
+type RedfishThermal struct {
+	Fans []RedfishFan `json:"Fans"`
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	fabricaclient "github.com/example/fru-tracker/pkg/client"
+)
+
+// SetOutput makes Sweep save each snapshot to a file instead of posting it,
+// for BMC networks that cannot reach the API. When dir is set, output is a
+// directory that gets one <snapshot name>.json per BMC; otherwise it is the
+// file to write.
+func (c *Collector) SetOutput(output string, dir bool) {
+	c.output = output
+	c.outputDir = dir
+}
+
+// save writes specs collected from bmc to the configured output and returns
+// the file written.
+func (c *Collector) save(bmc string, specs []*v1.DeviceSpec) (string, error) {
+	createReq, err := NewSnapshotRequest(bmc, specs, time.Now())
+	if err != nil {
+		return "", err
+	}
+	path := c.output
+	if c.outputDir {
+		if err := os.MkdirAll(c.output, 0o755); err != nil {
+			return "", fmt.Errorf("failed to create output directory: %w", err)
+		}
+		path = filepath.Join(c.output, createReq.Metadata.Name+".json")
+	}
+	return path, WriteSnapshotFile(path, createReq)
+}
+
+// WriteSnapshotFile saves a DiscoverySnapshot request to path. The file is
+// written under a temporary name and renamed, so a copy taken mid-write is
+// never mistaken for a complete snapshot.
+func WriteSnapshotFile(path string, createReq fabricaclient.CreateDiscoverySnapshotRequest) error {
+	data, err := json.MarshalIndent(createReq, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	return nil
+}
+
+// ReadSnapshotFile loads a DiscoverySnapshot request saved by
+// WriteSnapshotFile.
+func ReadSnapshotFile(path string) (fabricaclient.CreateDiscoverySnapshotRequest, error) {
+	var createReq fabricaclient.CreateDiscoverySnapshotRequest
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return createReq, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+	if err := json.Unmarshal(data, &createReq); err != nil {
+		return createReq, fmt.Errorf("failed to decode snapshot file %s: %w", path, err)
+	}
+	if createReq.Metadata.Name == "" {
+		return createReq, fmt.Errorf("snapshot file %s has no metadata.name", path)
+	}
+	var specs []v1.DeviceSpec
+	if err := json.Unmarshal(createReq.Spec.RawData, &specs); err != nil {
+		return createReq, fmt.Errorf("snapshot file %s has no device list in spec.rawData: %w", path, err)
+	}
+	if len(specs) == 0 {
+		return createReq, errors.New("snapshot file " + path + " lists no devices")
+	}
+	return createReq, nil
+}
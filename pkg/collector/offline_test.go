@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/redfishmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepSavesSnapshots(t *testing.T) {
+	resources, err := redfishmock.Fixture("standard")
+	require.NoError(t, err)
+	bmc := redfishmock.New(resources)
+	defer bmc.Close()
+
+	// No API: an air-gapped collector only writes files
+	c := New(nil, bmc.Client(), NewCredentialStore(Credentials{Username: "root"}), AuthBasic)
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	c.SetOutput(dir, true)
+
+	started := time.Now().UTC().Truncate(time.Second)
+	summary := c.Sweep(context.Background(), []string{bmc.URL}, 1, 0)
+	require.Empty(t, summary.Failed())
+	result := summary.Results[0]
+	assert.Empty(t, result.SnapshotUID)
+	assert.Equal(t, dir, filepath.Dir(result.File))
+
+	createReq, err := ReadSnapshotFile(result.File)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Base(result.File), createReq.Metadata.Name+".json")
+	assert.Equal(t, bmc.URL, createReq.Metadata.Annotations[v1.AnnotationSourceBMC])
+	collectedAt, err := time.Parse(time.RFC3339, createReq.Metadata.Annotations[v1.AnnotationCollectedAt])
+	require.NoError(t, err)
+	assert.False(t, collectedAt.Before(started))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary file is left behind")
+}
+
+func TestReadSnapshotFileRejectsIncompleteSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"unnamed.json":   `{"metadata": {}, "spec": {"rawData": [{"deviceType": "Node"}]}}`,
+		"empty.json":     `{"metadata": {"name": "snapshot-1"}, "spec": {"rawData": []}}`,
+		"notjson.json":   `snapshot`,
+		"norawdata.json": `{"metadata": {"name": "snapshot-1"}, "spec": {}}`,
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		_, err := ReadSnapshotFile(path)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,372 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Authentication modes for Redfish requests.
+const (
+	// AuthSession logs in through the SessionService once and sends the
+	// returned X-Auth-Token on every request.
+	AuthSession = "session"
+	// AuthBasic sends the credentials with every request.
+	AuthBasic = "basic"
+)
+
+// sessionsPath is the SessionService collection sessions are created in.
+const sessionsPath = "/SessionService/Sessions"
+
+// maxRetryAfter caps how long a Retry-After header can hold up a walk.
+const maxRetryAfter = time.Minute
+
+// RedfishOptions tune how the collector talks to each BMC.
+type RedfishOptions struct {
+	// Retries is how often a request answered with 429 or a 5xx status is
+	// retried. The first retry waits RetryDelay, or the Retry-After the BMC
+	// asks for, and the delay doubles with each further one.
+	Retries    int
+	RetryDelay time.Duration
+	// Concurrency bounds the requests in flight to one BMC. BMCs are small
+	// embedded systems, so it is kept low.
+	Concurrency int
+	// NoExpand stops collections being requested with $expand even when the
+	// service supports it.
+	NoExpand bool
+}
+
+// DefaultRedfishOptions returns the options used when none are configured.
+func DefaultRedfishOptions() RedfishOptions {
+	return RedfishOptions{
+		Retries:     3,
+		RetryDelay:  time.Second,
+		Concurrency: 4,
+	}
+}
+
+// RedfishClient reads resources from the Redfish service of one BMC.
+// It is safe for concurrent use.
+type RedfishClient struct {
+	baseURL     string
+	credentials Credentials
+	httpClient  *http.Client
+	options     RedfishOptions
+	// slots holds one token per request in flight
+	slots chan struct{}
+
+	mu           sync.Mutex
+	auth         string
+	sessionToken string
+	sessionURI   string
+}
+
+// NewRedfishClient creates a client for the BMC at endpoint, a host[:port]
+// or a base URL. A bare host is reached over HTTPS. auth is AuthSession or
+// AuthBasic; sessions fall back to basic auth on BMCs without a SessionService.
+func NewRedfishClient(endpoint string, credentials Credentials, auth string, httpClient *http.Client) (*RedfishClient, error) {
+	if auth != AuthSession && auth != AuthBasic {
+		return nil, fmt.Errorf("unsupported auth mode: %s (use '%s' or '%s')", auth, AuthSession, AuthBasic)
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	baseURL, err := url.JoinPath(endpoint, "/redfish/v1")
+	if err != nil {
+		return nil, fmt.Errorf("invalid BMC endpoint %s: %w", endpoint, err)
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	c := &RedfishClient{
+		baseURL:     baseURL,
+		credentials: credentials,
+		httpClient:  httpClient,
+		auth:        auth,
+	}
+	c.SetOptions(DefaultRedfishOptions())
+	return c, nil
+}
+
+// SetOptions replaces the client's retry, concurrency and $expand settings.
+// It must be called before the client is used.
+func (c *RedfishClient) SetOptions(options RedfishOptions) {
+	defaults := DefaultRedfishOptions()
+	if options.Retries < 0 {
+		options.Retries = 0
+	}
+	if options.RetryDelay <= 0 {
+		options.RetryDelay = defaults.RetryDelay
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaults.Concurrency
+	}
+	c.options = options
+	c.slots = make(chan struct{}, options.Concurrency)
+}
+
+// Host returns the host[:port] of the BMC.
+func (c *RedfishClient) Host() string {
+	if u, err := url.Parse(c.baseURL); err == nil {
+		return u.Host
+	}
+	return c.baseURL
+}
+
+// Get makes an authenticated GET request to a Redfish path relative to the
+// service root. An expired session is renewed once.
+func (c *RedfishClient) Get(ctx context.Context, path string) ([]byte, error) {
+	body, status, err := c.get(ctx, path)
+	if err == nil || status != http.StatusUnauthorized {
+		return body, err
+	}
+
+	c.mu.Lock()
+	renew := c.auth == AuthSession && c.sessionToken != ""
+	c.sessionToken, c.sessionURI = "", ""
+	c.mu.Unlock()
+	if !renew {
+		return nil, err
+	}
+	body, _, err = c.get(ctx, path)
+	return body, err
+}
+
+// Close logs out of the Redfish session, if one was opened.
+func (c *RedfishClient) Close(ctx context.Context) error {
+	c.mu.Lock()
+	token, sessionURI := c.sessionToken, c.sessionURI
+	c.sessionToken, c.sessionURI = "", ""
+	c.mu.Unlock()
+	if token == "" || sessionURI == "" {
+		return nil
+	}
+
+	target, err := c.resolve(sessionURI)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create logout request for %s: %w", target, err)
+	}
+	req.Header.Set("X-Auth-Token", token)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to log out of %s: %w", target, err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("redfish API returned status code %d logging out of %s", resp.StatusCode, target)
+	}
+	return nil
+}
+
+// get makes one GET request, retrying while the BMC answers that it is busy
+// or failing.
+func (c *RedfishClient) get(ctx context.Context, path string) ([]byte, int, error) {
+	// The query of a paged collection's next link must not be escaped into the path
+	path, query, _ := strings.Cut(path, "?")
+	targetURL, err := url.JoinPath(c.baseURL, path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to join path: %w", err)
+	}
+	if query != "" {
+		targetURL += "?" + query
+	}
+
+	delay := c.options.RetryDelay
+	for attempt := 0; ; attempt++ {
+		body, status, retryAfter, err := c.do(ctx, targetURL)
+		if !retryable(status) || attempt >= c.options.Retries {
+			return body, status, err
+		}
+		wait := delay
+		if retryAfter > 0 {
+			wait = min(retryAfter, maxRetryAfter)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, status, err
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// do sends a GET request once, waiting for a free slot first. It returns the
+// delay a Retry-After header asks for along with the status.
+func (c *RedfishClient) do(ctx context.Context, targetURL string) ([]byte, int, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to create Redfish request for %s: %w", targetURL, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if err := c.authorize(ctx, req); err != nil {
+		return nil, 0, 0, err
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, 0, 0, ctx.Err()
+	}
+	defer func() { <-c.slots }()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to execute Redfish request for %s: %w", targetURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, resp.StatusCode, parseRetryAfter(resp.Header.Get("Retry-After")),
+			fmt.Errorf("redfish API returned status code %d for %s", resp.StatusCode, targetURL)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, 0, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return body, resp.StatusCode, 0, nil
+}
+
+// retryable reports whether a request that got status may succeed if sent
+// again.
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError && status != http.StatusNotImplemented
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date. It returns 0 when the header is absent or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
+// authorize adds credentials to req, logging in first when a session is
+// needed.
+func (c *RedfishClient) authorize(ctx context.Context, req *http.Request) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.auth == AuthSession && c.sessionToken == "" {
+		if err := c.login(ctx); err != nil {
+			return err
+		}
+	}
+	if c.auth == AuthSession {
+		req.Header.Set("X-Auth-Token", c.sessionToken)
+	} else {
+		req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+	}
+	return nil
+}
+
+// login creates a Redfish session. Callers hold c.mu.
+func (c *RedfishClient) login(ctx context.Context) error {
+	target := c.baseURL + sessionsPath
+	payload, err := json.Marshal(map[string]string{
+		"UserName": c.credentials.Username,
+		"Password": c.credentials.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode session request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create session request for %s: %w", target, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to create Redfish session at %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed ||
+		resp.StatusCode == http.StatusNotImplemented:
+		log.Printf("Warning: %s has no SessionService (status %d), using basic auth", c.baseURL, resp.StatusCode)
+		c.auth = AuthBasic
+		return nil
+	case resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("redfish API returned status code %d creating a session at %s", resp.StatusCode, target)
+	}
+
+	token := resp.Header.Get("X-Auth-Token")
+	if token == "" {
+		return fmt.Errorf("redfish session at %s returned no X-Auth-Token", target)
+	}
+	c.sessionToken = token
+	c.sessionURI = resp.Header.Get("Location")
+	return nil
+}
+
+// resolve turns a session Location, which BMCs return either as a path or a
+// full URL, into a URL.
+func (c *RedfishClient) resolve(location string) (string, error) {
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("invalid session location %s: %w", location, err)
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// NewTLSConfig returns the TLS settings for BMC and API connections. caBundle,
+// when set, is a PEM file of CAs trusted in addition to the system roots.
+// insecure disables certificate verification altogether.
+func NewTLSConfig(caBundle string, insecure bool) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecure, //nolint:gosec // Opt-in for lab BMCs with self-signed certificates
+	}
+	if caBundle == "" {
+		return config, nil
+	}
+
+	pem, err := os.ReadFile(caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", caBundle)
+	}
+	config.RootCAs = pool
+	return config, nil
+}
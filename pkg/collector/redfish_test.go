@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sessionBMC is a Redfish service that only accepts session tokens.
+type sessionBMC struct {
+	mu       sync.Mutex
+	logins   int
+	logouts  int
+	basic    int
+	token    string
+	sessions bool
+}
+
+func (b *sessionBMC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/redfish/v1/SessionService/Sessions":
+		if !b.sessions {
+			http.NotFound(w, r)
+			return
+		}
+		var login struct{ UserName, Password string }
+		if err := json.NewDecoder(r.Body).Decode(&login); err != nil || login.UserName != "root" || login.Password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		b.logins++
+		b.token = fmt.Sprintf("token-%d", b.logins)
+		w.Header().Set("X-Auth-Token", b.token)
+		w.Header().Set("Location", fmt.Sprintf("/redfish/v1/SessionService/Sessions/%d", b.logins))
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodDelete:
+		b.logouts++
+		b.token = ""
+	case r.Method == http.MethodGet:
+		if user, pass, ok := r.BasicAuth(); ok && !b.sessions && user == "root" && pass == "secret" {
+			b.basic++
+		} else if b.token == "" || r.Header.Get("X-Auth-Token") != b.token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"Members":[]}`))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestRedfishClientSessionAuth(t *testing.T) {
+	ctx := context.Background()
+	bmc := &sessionBMC{sessions: true}
+	server := httptest.NewServer(bmc)
+	defer server.Close()
+
+	client, err := NewRedfishClient(server.URL, Credentials{Username: "root", Password: "secret"}, AuthSession, server.Client())
+	require.NoError(t, err)
+
+	// One login serves every request
+	for i := 0; i < 3; i++ {
+		_, err := client.Get(ctx, "/Systems")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, bmc.logins)
+
+	// An expired session is renewed transparently
+	bmc.mu.Lock()
+	bmc.token = "rotated"
+	bmc.mu.Unlock()
+	_, err = client.Get(ctx, "/Systems")
+	require.NoError(t, err)
+	assert.Equal(t, 2, bmc.logins)
+
+	require.NoError(t, client.Close(ctx))
+	assert.Equal(t, 1, bmc.logouts)
+}
+
+func TestRedfishClientFallsBackToBasicAuth(t *testing.T) {
+	ctx := context.Background()
+	bmc := &sessionBMC{}
+	server := httptest.NewServer(bmc)
+	defer server.Close()
+
+	client, err := NewRedfishClient(server.URL, Credentials{Username: "root", Password: "secret"}, AuthSession, server.Client())
+	require.NoError(t, err)
+
+	_, err = client.Get(ctx, "/Systems")
+	require.NoError(t, err)
+	_, err = client.Get(ctx, "/Chassis")
+	require.NoError(t, err)
+	assert.Equal(t, 2, bmc.basic)
+	require.NoError(t, client.Close(ctx))
+	assert.Zero(t, bmc.logouts)
+}
+
+func TestRedfishClientRejectsBadLogin(t *testing.T) {
+	server := httptest.NewServer(&sessionBMC{sessions: true})
+	defer server.Close()
+
+	client, err := NewRedfishClient(server.URL, Credentials{Username: "root", Password: "wrong"}, AuthSession, server.Client())
+	require.NoError(t, err)
+	_, err = client.Get(context.Background(), "/Systems")
+	assert.ErrorContains(t, err, "status code 401")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5"))
+	assert.Zero(t, parseRetryAfter(""))
+	assert.Zero(t, parseRetryAfter("soon"))
+	assert.InDelta(t, float64(time.Minute), float64(parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))), float64(2*time.Second))
+}
+
+func TestSnapshotName(t *testing.T) {
+	ts := int64(1767225600)
+	assert.Equal(t, fmt.Sprintf("snapshot-10.0.0.5-%d", ts), SnapshotName("10.0.0.5", time.Unix(ts, 0)))
+	assert.Equal(t, fmt.Sprintf("snapshot-bmc01.example.com-8443-%d", ts), SnapshotName("https://BMC01.example.com:8443", time.Unix(ts, 0)))
+}
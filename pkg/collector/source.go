@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+)
+
+// RedfishSourceName names the Redfish walk in snapshot annotations.
+const RedfishSourceName = "redfish"
+
+// Source produces the devices of one machine. The Redfish walk of a BMC is
+// one source; package host provides sources that read the inventory of the
+// machine the collector runs on, for nodes without a reachable BMC.
+type Source interface {
+	// Name identifies the source in logs and snapshot annotations.
+	Name() string
+	// Collect returns the devices the source can see.
+	Collect(ctx context.Context) ([]*v1.DeviceSpec, error)
+}
+
+// redfishSource walks the Redfish tree of one BMC.
+type redfishSource struct {
+	client *RedfishClient
+}
+
+// NewRedfishSource returns a Source that walks the BMC behind client.
+func NewRedfishSource(client *RedfishClient) Source {
+	return &redfishSource{client: client}
+}
+
+func (s *redfishSource) Name() string { return RedfishSourceName }
+
+func (s *redfishSource) Collect(ctx context.Context) ([]*v1.DeviceSpec, error) {
+	return Discover(ctx, s.client)
+}
+
+// CollectSources runs every source and merges what they report about the
+// same device into one spec. A failing source is logged and skipped; an
+// error is only returned when no source produced anything.
+func CollectSources(ctx context.Context, sources []Source) ([]*v1.DeviceSpec, error) {
+	var (
+		specs []*v1.DeviceSpec
+		errs  []error
+	)
+	for _, source := range sources {
+		found, err := source.Collect(ctx)
+		if err != nil {
+			log.Printf("Warning: Source %s failed: %v", source.Name(), err)
+			errs = append(errs, fmt.Errorf("%s: %w", source.Name(), err))
+			continue
+		}
+		specs = append(specs, found...)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(specs) == 0 {
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+		return nil, errors.New("no source found any devices")
+	}
+	return MergeSpecs(specs), nil
+}
+
+// MergeSpecs combines specs that describe the same device, keeping the first
+// non-empty value of each field and every property reported. Two specs are
+// the same device when they share a locator (the redfish_uri property) or,
+// lacking one, a device type and serial number. Order of first appearance is
+// kept.
+func MergeSpecs(specs []*v1.DeviceSpec) []*v1.DeviceSpec {
+	merged := make([]*v1.DeviceSpec, 0, len(specs))
+	byKey := make(map[string]*v1.DeviceSpec, len(specs))
+	for _, spec := range specs {
+		key := specKey(spec)
+		existing, ok := byKey[key]
+		if key == "" || !ok {
+			if key != "" {
+				byKey[key] = spec
+			}
+			merged = append(merged, spec)
+			continue
+		}
+
+		fill(&existing.Manufacturer, spec.Manufacturer)
+		fill(&existing.PartNumber, spec.PartNumber)
+		fill(&existing.SerialNumber, spec.SerialNumber)
+		fill(&existing.ParentSerialNumber, spec.ParentSerialNumber)
+		if existing.Properties == nil {
+			existing.Properties = make(map[string]json.RawMessage, len(spec.Properties))
+		}
+		for name, value := range spec.Properties {
+			if _, ok := existing.Properties[name]; !ok {
+				existing.Properties[name] = value
+			}
+		}
+	}
+	return merged
+}
+
+// specKey identifies the device a spec describes, or "" when it cannot be told
+// apart from others.
+func specKey(spec *v1.DeviceSpec) string {
+	var locator string
+	if raw, ok := spec.Properties[v1.PropertyRedfishURI]; ok && json.Unmarshal(raw, &locator) == nil && locator != "" {
+		return "uri:" + locator
+	}
+	if spec.SerialNumber != "" {
+		return "serial:" + spec.DeviceType + "/" + spec.SerialNumber
+	}
+	return ""
+}
+
+// fill sets *field to value when it is empty.
+func fill(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
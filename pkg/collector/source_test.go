@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+)
+
+// staticSource reports fixed devices or an error.
+type staticSource struct {
+	name  string
+	specs []*v1.DeviceSpec
+	err   error
+}
+
+func (s *staticSource) Name() string { return s.name }
+
+func (s *staticSource) Collect(ctx context.Context) ([]*v1.DeviceSpec, error) {
+	return s.specs, s.err
+}
+
+func located(deviceType, locator string) *v1.DeviceSpec {
+	raw, _ := json.Marshal(locator)
+	return &v1.DeviceSpec{DeviceType: deviceType, Properties: map[string]json.RawMessage{v1.PropertyRedfishURI: raw}}
+}
+
+func TestMergeSpecs(t *testing.T) {
+	cpu := located("CPU", "host://n1/cpu/CPU1")
+	cpu.Manufacturer = "Intel"
+	cpuAgain := located("CPU", "host://n1/cpu/CPU1")
+	cpuAgain.Manufacturer = "Intel Corp."
+	cpuAgain.PartNumber = "Xeon Gold 6248"
+	cpuAgain.Properties[v1.PropertyTotalCores] = json.RawMessage(`20`)
+
+	dimm := &v1.DeviceSpec{DeviceType: "DIMM", SerialNumber: "D1"}
+	dimmAgain := &v1.DeviceSpec{DeviceType: "DIMM", SerialNumber: "D1", PartNumber: "M393A4K40DB2"}
+	// Without a locator or serial there is nothing to merge on
+	anonymous := &v1.DeviceSpec{DeviceType: "PSU"}
+
+	merged := MergeSpecs([]*v1.DeviceSpec{cpu, dimm, anonymous, cpuAgain, dimmAgain, &v1.DeviceSpec{DeviceType: "PSU"}})
+	require.Len(t, merged, 4)
+	assert.Same(t, cpu, merged[0])
+	assert.Equal(t, "Intel", cpu.Manufacturer)
+	assert.Equal(t, "Xeon Gold 6248", cpu.PartNumber)
+	assert.JSONEq(t, `20`, string(cpu.Properties[v1.PropertyTotalCores]))
+	assert.Equal(t, "M393A4K40DB2", merged[1].PartNumber)
+}
+
+func TestCollectSources(t *testing.T) {
+	failing := &staticSource{name: "broken", err: errors.New("tool not installed")}
+	specs, err := CollectSources(context.Background(), []Source{
+		failing,
+		&staticSource{name: "ok", specs: []*v1.DeviceSpec{located("Node", "host://n1")}},
+	})
+	require.NoError(t, err)
+	assert.Len(t, specs, 1)
+
+	_, err = CollectSources(context.Background(), []Source{failing})
+	assert.ErrorContains(t, err, "broken: tool not installed")
+}
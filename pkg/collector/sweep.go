@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+)
+
+// DefaultWorkers is how many BMCs a sweep collects at once.
+const DefaultWorkers = 16
+
+// SweepResult is the outcome of collecting one BMC.
+type SweepResult struct {
+	BMC         string
+	SnapshotUID string
+	// File is where the snapshot was saved when the collector has an output
+	File     string
+	Devices  int
+	Duration time.Duration
+	Err      error
+}
+
+// SweepSummary is the outcome of a sweep. Results are in the order the BMCs
+// were given.
+type SweepSummary struct {
+	Results  []SweepResult
+	Duration time.Duration
+}
+
+// Failed returns the results of BMCs that could not be collected or posted.
+func (s SweepSummary) Failed() []SweepResult {
+	var failed []SweepResult
+	for _, result := range s.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Write prints a one-line total followed by a line per BMC.
+func (s SweepSummary) Write(w io.Writer) error {
+	failed := len(s.Failed())
+	if _, err := fmt.Fprintf(w, "Sweep of %d BMCs finished in %s: %d succeeded, %d failed\n",
+		len(s.Results), s.Duration.Round(time.Millisecond), len(s.Results)-failed, failed); err != nil {
+		return err
+	}
+	for _, result := range s.Results {
+		var err error
+		if result.Err != nil {
+			_, err = fmt.Fprintf(w, "  FAILED %s after %s: %v\n", result.BMC, result.Duration.Round(time.Millisecond), result.Err)
+		} else {
+			destination := "snapshot " + result.SnapshotUID
+			if result.File != "" {
+				destination = "saved to " + result.File
+			}
+			_, err = fmt.Fprintf(w, "  OK     %s in %s: %d devices, %s\n", result.BMC, result.Duration.Round(time.Millisecond), result.Devices, destination)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sweep collects every BMC in bmcs with at most workers running at once and
+// posts, or saves when an output is set, one DiscoverySnapshot per BMC. Each BMC gets hostTimeout to be
+// walked and posted; zero means no limit beyond ctx. A failing BMC never stops
+// the others.
+func (c *Collector) Sweep(ctx context.Context, bmcs []string, workers int, hostTimeout time.Duration) SweepSummary {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	started := time.Now()
+	results := make([]SweepResult, len(bmcs))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < len(bmcs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				results[index] = c.collectOne(ctx, bmcs[index], hostTimeout)
+			}
+		}()
+	}
+
+	for index := range bmcs {
+		select {
+		case jobs <- index:
+		case <-ctx.Done():
+			// Record what never started so the summary still covers every BMC
+			results[index] = SweepResult{BMC: bmcs[index], Err: ctx.Err()}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return SweepSummary{Results: results, Duration: time.Since(started)}
+}
+
+// collectOne collects and posts or saves a single BMC within hostTimeout.
+func (c *Collector) collectOne(ctx context.Context, bmc string, hostTimeout time.Duration) SweepResult {
+	started := time.Now()
+	if hostTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, hostTimeout)
+		defer cancel()
+	}
+
+	result := SweepResult{BMC: bmc}
+	specs, err := c.Collect(ctx, bmc)
+	if err == nil {
+		result.Devices = len(specs)
+		if c.output != "" {
+			result.File, err = c.save(bmc, specs)
+		} else {
+			var snapshot *v1.DiscoverySnapshot
+			if snapshot, err = c.Post(ctx, bmc, specs); err == nil {
+				result.SnapshotUID = snapshot.Metadata.UID
+			}
+		}
+	}
+	result.Err = err
+	result.Duration = time.Since(started)
+	return result
+}
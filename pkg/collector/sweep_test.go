@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	fabricaclient "github.com/example/fru-tracker/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweep(t *testing.T) {
+	const workers = 2
+
+	// Every BMC serves one system; running counts walks in flight
+	var running, peak int32
+	bmc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/SessionService/Sessions":
+			http.NotFound(w, r)
+		case "/redfish/v1/Systems":
+			now := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				seen := atomic.LoadInt32(&peak)
+				if now <= seen || atomic.CompareAndSwapInt32(&peak, seen, now) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"Members":[{"@odata.id":"/redfish/v1/Systems/1"}]}`))
+		case "/redfish/v1/Systems/1":
+			_, _ = w.Write([]byte(`{"SerialNumber":"NODE-1"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer bmc.Close()
+
+	var (
+		mu     sync.Mutex
+		posted []string
+	)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req fabricaclient.CreateDiscoverySnapshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		mu.Lock()
+		posted = append(posted, req.Metadata.Name)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"metadata":{"name":"` + req.Metadata.Name + `","uid":"discoverysnapshot-1"}}`))
+	}))
+	defer api.Close()
+
+	apiClient, err := fabricaclient.NewClient(api.URL, api.Client())
+	require.NoError(t, err)
+	c := New(apiClient, bmc.Client(), NewCredentialStore(Credentials{Username: "root", Password: "secret"}), AuthSession)
+
+	// The same BMC under different URLs, plus one that refuses connections
+	bmcs := []string{bmc.URL, bmc.URL + "/", strings.Replace(bmc.URL, "127.0.0.1", "localhost", 1), "http://127.0.0.1:1"}
+	summary := c.Sweep(context.Background(), bmcs, workers, 5*time.Second)
+
+	require.Len(t, summary.Results, len(bmcs))
+	for i, result := range summary.Results[:3] {
+		assert.Equal(t, bmcs[i], result.BMC)
+		assert.NoError(t, result.Err)
+		assert.Equal(t, 1, result.Devices)
+		assert.Equal(t, "discoverysnapshot-1", result.SnapshotUID)
+	}
+	failed := summary.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "http://127.0.0.1:1", failed[0].BMC)
+	assert.Len(t, posted, 3)
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(workers))
+
+	var report bytes.Buffer
+	require.NoError(t, summary.Write(&report))
+	assert.Contains(t, report.String(), "Sweep of 4 BMCs finished in")
+	assert.Contains(t, report.String(), "3 succeeded, 1 failed")
+	assert.Contains(t, report.String(), "FAILED http://127.0.0.1:1")
+}
+
+func TestSweepStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := New(nil, http.DefaultClient, NewCredentialStore(Credentials{Username: "root"}), AuthBasic)
+	summary := c.Sweep(ctx, []string{"http://127.0.0.1:1", "http://127.0.0.1:2"}, 1, 0)
+	require.Len(t, summary.Failed(), 2)
+	for _, result := range summary.Results {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
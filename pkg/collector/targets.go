@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// MaxCIDRTargets caps how many addresses one CIDR range may expand to, so a
+// mistyped prefix length does not start a sweep of millions of hosts.
+const MaxCIDRTargets = 65536
+
+// smdEndpointsPath lists the BMCs known to the OpenCHAMI State Management Database.
+const smdEndpointsPath = "/hsm/v2/Inventory/RedfishEndpoints"
+
+// ReadTargetsFile reads BMC addresses from a file, one per line. Blank lines
+// and lines starting with # are skipped.
+func ReadTargetsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open BMC list: %w", err)
+	}
+	defer f.Close()
+
+	var targets []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		targets = append(targets, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read BMC list %s: %w", path, err)
+	}
+	return targets, nil
+}
+
+// ExpandCIDR returns every host address in an IPv4 or IPv6 range. For IPv4
+// ranges larger than /31 the network and broadcast addresses are left out.
+func ExpandCIDR(cidr string) ([]string, error) {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR range %s: %w", cidr, err)
+	}
+	prefix = prefix.Masked()
+
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+	if hostBits > 16 {
+		return nil, fmt.Errorf("CIDR range %s has more than %d addresses", cidr, MaxCIDRTargets)
+	}
+
+	var targets []string
+	for addr := prefix.Addr(); prefix.Contains(addr); addr = addr.Next() {
+		targets = append(targets, addr.String())
+	}
+	if prefix.Addr().Is4() && hostBits > 1 {
+		targets = targets[1 : len(targets)-1]
+	}
+	return targets, nil
+}
+
+// smdRedfishEndpoint is the part of an SMD RedfishEndpoint the collector uses.
+type smdRedfishEndpoint struct {
+	ID        string `json:"ID"`
+	FQDN      string `json:"FQDN"`
+	IPAddress string `json:"IPAddress"`
+	Enabled   *bool  `json:"Enabled"`
+}
+
+// LoadSMDTargets lists the enabled BMCs registered in the OpenCHAMI State
+// Management Database at smdURL, preferring each one's FQDN over its IP.
+func LoadSMDTargets(ctx context.Context, httpClient *http.Client, smdURL, token string) ([]string, error) {
+	target, err := url.JoinPath(smdURL, smdEndpointsPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMD URL %s: %w", smdURL, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SMD request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list BMCs from SMD: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SMD returned status code %d for %s", resp.StatusCode, target)
+	}
+
+	var body struct {
+		RedfishEndpoints []smdRedfishEndpoint `json:"RedfishEndpoints"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode SMD RedfishEndpoints: %w", err)
+	}
+
+	var targets []string
+	for _, endpoint := range body.RedfishEndpoints {
+		if endpoint.Enabled != nil && !*endpoint.Enabled {
+			continue
+		}
+		switch {
+		case endpoint.FQDN != "":
+			targets = append(targets, endpoint.FQDN)
+		case endpoint.IPAddress != "":
+			targets = append(targets, endpoint.IPAddress)
+		}
+	}
+	return targets, nil
+}
+
+// UniqueTargets drops repeated BMCs, keeping the first occurrence of each.
+func UniqueTargets(targets []string) []string {
+	seen := make(map[string]bool, len(targets))
+	unique := make([]string, 0, len(targets))
+	for _, target := range targets {
+		key := strings.ToLower(strings.TrimSpace(target))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, strings.TrimSpace(target))
+	}
+	return unique
+}
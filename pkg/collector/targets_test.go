@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package collector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandCIDR(t *testing.T) {
+	tests := []struct {
+		cidr    string
+		want    []string
+		wantErr string
+	}{
+		{cidr: "10.0.0.0/30", want: []string{"10.0.0.1", "10.0.0.2"}},
+		// A host address is masked down to its network
+		{cidr: "10.0.0.5/30", want: []string{"10.0.0.5", "10.0.0.6"}},
+		{cidr: "10.0.0.4/31", want: []string{"10.0.0.4", "10.0.0.5"}},
+		{cidr: "10.0.0.7/32", want: []string{"10.0.0.7"}},
+		{cidr: "fd00::/127", want: []string{"fd00::", "fd00::1"}},
+		{cidr: "10.0.0.0/8", wantErr: "more than 65536 addresses"},
+		{cidr: "10.0.0.0", wantErr: "invalid CIDR range"},
+	}
+	for _, tt := range tests {
+		got, err := ExpandCIDR(tt.cidr)
+		if tt.wantErr != "" {
+			assert.ErrorContains(t, err, tt.wantErr, tt.cidr)
+			continue
+		}
+		require.NoError(t, err, tt.cidr)
+		assert.Equal(t, tt.want, got, tt.cidr)
+	}
+
+	all, err := ExpandCIDR("10.1.0.0/16")
+	require.NoError(t, err)
+	assert.Len(t, all, MaxCIDRTargets-2)
+}
+
+func TestReadTargetsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bmcs.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# rack 1\n10.0.0.5\n\n  bmc02.example.com  \n"), 0o644))
+
+	targets, err := ReadTargetsFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.5", "bmc02.example.com"}, targets)
+}
+
+func TestLoadSMDTargets(t *testing.T) {
+	smd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hsm/v2/Inventory/RedfishEndpoints", r.URL.Path)
+		assert.Equal(t, "Bearer smd-token", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"RedfishEndpoints": [
+			{"ID": "x3000c0s1b0", "FQDN": "x3000c0s1b0.example.com", "IPAddress": "10.0.0.1", "Enabled": true},
+			{"ID": "x3000c0s2b0", "IPAddress": "10.0.0.2"},
+			{"ID": "x3000c0s3b0", "IPAddress": "10.0.0.3", "Enabled": false}
+		]}`))
+	}))
+	defer smd.Close()
+
+	targets, err := LoadSMDTargets(context.Background(), smd.Client(), smd.URL, "smd-token")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x3000c0s1b0.example.com", "10.0.0.2"}, targets)
+}
+
+func TestUniqueTargets(t *testing.T) {
+	assert.Equal(t,
+		[]string{"10.0.0.1", "BMC.example.com"},
+		UniqueTargets([]string{"10.0.0.1", " BMC.example.com", "bmc.example.com", "10.0.0.1", ""}))
+}
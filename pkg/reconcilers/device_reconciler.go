@@ -8,6 +8,7 @@ package reconcilers
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
 )
@@ -73,7 +74,7 @@ func (r *DeviceReconciler) reconcileDevice(ctx context.Context, res *v1.Device)
 	//
 	//   return nil
 
-	r.Logger.Infof("Device reconciliation not yet implemented for %s", res.GetUID())
+	slog.InfoContext(ctx, "Device reconciliation not yet implemented", "device_uid", res.GetUID())
 
 	return nil
 }
@@ -7,48 +7,156 @@ package reconcilers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 
 	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/logging"
 	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/tracing"
+	"github.com/openchami/fabrica/pkg/events"
 	"github.com/openchami/fabrica/pkg/fabrica"
 	"github.com/openchami/fabrica/pkg/resource"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func (r *DiscoverySnapshotReconciler) reconcileDiscoverySnapshot(ctx context.Context, snapshot *v1.DiscoverySnapshot) error {
+	// The controller queues snapshots by UID, so continue the trace of the
+	// event that queued this one
+	ctx = tracing.Recall(ctx, snapshot.GetUID())
+	ctx = logging.With(ctx, "snapshot_uid", snapshot.GetUID(), "snapshot", snapshot.GetName())
+
 	if snapshot.Status.Phase == "Completed" {
-		r.Logger.Infof("Reconciling %s: Already completed, skipping.", snapshot.GetName())
+		slog.InfoContext(ctx, "Snapshot already completed, skipping")
+		snapshotReconciles.WithLabelValues(resultSkipped).Inc()
 		return nil
 	}
 
-	r.Logger.Infof("Reconciling %s: Starting reconciliation", snapshot.GetName())
-	snapshot.Status.Phase = "Processing"
-	snapshot.Status.Message = "Reconciler has started processing the snapshot."
-	snapshot.Status.Ready = false
+	ctx, span := tracing.Start(ctx, "reconcile DiscoverySnapshot", trace.SpanKindInternal)
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("fru_tracker.snapshot.uid", snapshot.GetUID()),
+		attribute.String("fru_tracker.snapshot.name", snapshot.GetName()),
+	)
+
+	result, err := r.runSnapshot(ctx, snapshot)
+	span.SetAttributes(attribute.String("fru_tracker.result", result))
+	tracing.RecordError(span, err)
+	snapshotReconciles.WithLabelValues(result).Inc()
+	return err
+}
 
+// runSnapshot reconciles a snapshot once it holds the locks on its devices
+// and a worker slot, retrying failed attempts as tuned by Configure.
+func (r *DiscoverySnapshotReconciler) runSnapshot(ctx context.Context, snapshot *v1.DiscoverySnapshot) (string, error) {
 	var payloadSpecs []v1.DeviceSpec
 	if err := json.Unmarshal(snapshot.Spec.RawData, &payloadSpecs); err != nil {
-		snapshot.Status.Phase = "Error"
-		snapshot.Status.Message = fmt.Sprintf("Failed to parse rawData: %v", err)
-		snapshot.Status.Ready = false
-		if updateErr := r.UpdateStatus(ctx, snapshot); updateErr != nil {
-			return fmt.Errorf("failed to persist error status: %w", updateErr)
+		return resultError, r.failSnapshot(ctx, snapshot, "failed to parse rawData", err)
+	}
+
+	// Snapshots sharing a device wait for each other, and a snapshot
+	// delivered twice waits for its first reconcile to finish
+	unlock := snapshotLocks.lock(snapshotLockKeys(snapshot.GetUID(), payloadSpecs))
+	defer unlock()
+	if latest, err := storage.LoadDiscoverySnapshot(ctx, snapshot.GetUID()); err == nil && latest.Status.Phase == "Completed" {
+		slog.InfoContext(ctx, "Snapshot completed while waiting, skipping")
+		*snapshot = *latest
+		return resultSkipped, nil
+	}
+
+	tuning := current.Load()
+	select {
+	case tuning.slots <- struct{}{}:
+	case <-ctx.Done():
+		return resultError, ctx.Err()
+	}
+	defer func() { <-tuning.slots }()
+
+	start := time.Now()
+	snapshotReconcilesInProgress.Inc()
+	defer snapshotReconcilesInProgress.Dec()
+
+	err := r.attemptSnapshot(ctx, tuning.Timeout, snapshot, payloadSpecs)
+	for retry := 1; err != nil && !errors.Is(err, storage.ErrDeviceConflict) && retry <= tuning.MaxRetries && ctx.Err() == nil; retry++ {
+		delay := tuning.retryDelay(retry)
+		slog.WarnContext(ctx, "Retrying snapshot reconcile", "retry", retry, "max_retries", tuning.MaxRetries, "delay", delay, "error", err)
+		snapshotReconcileRetries.Inc()
+		select {
+		case <-time.After(delay):
+			err = r.attemptSnapshot(ctx, tuning.Timeout, snapshot, payloadSpecs)
+		case <-ctx.Done():
 		}
-		return fmt.Errorf("failed to parse rawData: %w", err)
 	}
 
+	result := resultCompleted
+	if err != nil {
+		result = resultError
+	}
+	snapshotReconcileDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+	return result, err
+}
+
+// maxConflictRetries bounds how often an attempt that lost the race to
+// create a device starts over. The next try prefetches the winner's device,
+// so more than one retry means the snapshot itself is contradictory.
+const maxConflictRetries = 3
+
+// attemptSnapshot runs processSnapshot, giving up after timeout when it is
+// set, and starts over when another writer created one of the snapshot's
+// devices first.
+func (r *DiscoverySnapshotReconciler) attemptSnapshot(ctx context.Context, timeout time.Duration, snapshot *v1.DiscoverySnapshot, payloadSpecs []v1.DeviceSpec) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	err := r.processSnapshot(ctx, snapshot, payloadSpecs)
+	for retry := 1; errors.Is(err, storage.ErrDeviceConflict) && retry <= maxConflictRetries; retry++ {
+		slog.InfoContext(ctx, "Device created concurrently, starting over", "retry", retry, "error", err)
+		snapshotReconcileConflicts.Inc()
+		err = r.processSnapshot(ctx, snapshot, payloadSpecs)
+	}
+	if errors.Is(err, storage.ErrDeviceConflict) {
+		return r.failSnapshot(ctx, snapshot, "failed to persist device changes", err)
+	}
+	return err
+}
+
+// failSnapshot moves a snapshot to the Error phase with err as the reason and
+// returns err wrapped in doing. The status is saved even when ctx has timed out.
+func (r *DiscoverySnapshotReconciler) failSnapshot(ctx context.Context, snapshot *v1.DiscoverySnapshot, doing string, err error) error {
+	snapshot.Status.Phase = "Error"
+	snapshot.Status.Message = fmt.Sprintf("%s%s: %v", strings.ToUpper(doing[:1]), doing[1:], err)
+	snapshot.Status.Ready = false
+	if updateErr := r.UpdateStatus(context.WithoutCancel(ctx), snapshot); updateErr != nil {
+		return fmt.Errorf("failed to persist error status: %w", updateErr)
+	}
+	return fmt.Errorf("%s: %w", doing, err)
+}
+
+// processSnapshot merges the devices in a snapshot into the inventory and
+// links them to their parents.
+func (r *DiscoverySnapshotReconciler) processSnapshot(ctx context.Context, snapshot *v1.DiscoverySnapshot, payloadSpecs []v1.DeviceSpec) error {
+	slog.InfoContext(ctx, "Reconciling snapshot")
+	// Inventory events raised by the device writes name this snapshot
+	ctx = storage.WithSnapshotUID(ctx, snapshot.GetUID())
+
+	// Pass 1 merges the payload into the inventory, pass 2 links parents
+	passCtx, pass := tracing.Start(ctx, "reconcile pass 1: merge devices", trace.SpanKindInternal)
+	defer func() { pass.End() }()
+
+	snapshot.Status.Phase = "Processing"
+	snapshot.Status.Message = "Reconciler has started processing the snapshot."
+	snapshot.Status.Ready = false
+
 	lookupKeys := collectLookupKeys(payloadSpecs)
-	existingDevices, err := storage.LoadDevicesByIdentifiers(ctx, lookupKeys)
+	existingDevices, err := storage.LoadDevicesByIdentifiers(passCtx, lookupKeys)
 	if err != nil {
-		snapshot.Status.Phase = "Error"
-		snapshot.Status.Message = fmt.Sprintf("Failed to prefetch devices: %v", err)
-		snapshot.Status.Ready = false
-		if updateErr := r.UpdateStatus(ctx, snapshot); updateErr != nil {
-			return fmt.Errorf("failed to persist error status: %w", updateErr)
-		}
-		return fmt.Errorf("failed to prefetch devices: %w", err)
+		return r.failSnapshot(ctx, snapshot, "failed to prefetch devices", err)
 	}
 
 	bySerial := make(map[string]*v1.Device)
@@ -61,11 +169,12 @@ func (r *DiscoverySnapshotReconciler) reconcileDiscoverySnapshot(ctx context.Con
 	processedDevices := make([]*v1.Device, 0, len(payloadSpecs))
 	createdCount := 0
 	updatedCount := 0
+	createdUIDs := make(map[string]bool)
 
 	for _, spec := range payloadSpecs {
 		device := deviceFromSpec(spec)
 		if device == nil {
-			r.Logger.Warnf("Reconciling %s: Skipping invalid device spec", snapshot.GetName())
+			slog.WarnContext(passCtx, "Skipping invalid device spec", "serial_number", spec.SerialNumber, "device_type", spec.DeviceType)
 			continue
 		}
 
@@ -79,26 +188,34 @@ func (r *DiscoverySnapshotReconciler) reconcileDiscoverySnapshot(ctx context.Con
 
 		processedDevices = append(processedDevices, device)
 		indexDevice(device, bySerial, byURI, byUID)
+		createdUIDs[device.GetUID()] = true
 		createdCount++
 	}
 
-	if err := storage.SaveDevicesBulk(ctx, processedDevices); err != nil {
-		snapshot.Status.Phase = "Error"
-		snapshot.Status.Message = fmt.Sprintf("Failed to persist device changes: %v", err)
-		snapshot.Status.Ready = false
-		if updateErr := r.UpdateStatus(ctx, snapshot); updateErr != nil {
-			return fmt.Errorf("failed to persist error status: %w", updateErr)
+	if err := storage.SaveDevicesBulk(passCtx, processedDevices); err != nil {
+		if errors.Is(err, storage.ErrDeviceConflict) {
+			// Another writer created one of these devices first; the caller
+			// starts over to merge into it
+			return err
 		}
-		return fmt.Errorf("failed to persist device changes: %w", err)
-	}
+		return r.failSnapshot(ctx, snapshot, "failed to persist device changes", err)
+	}
+	reconciledDevices.WithLabelValues("created").Add(float64(createdCount))
+	reconciledDevices.WithLabelValues("updated").Add(float64(updatedCount))
+	pass.SetAttributes(
+		attribute.Int("fru_tracker.devices.created", createdCount),
+		attribute.Int("fru_tracker.devices.updated", updatedCount),
+	)
+	pass.End()
 
-	r.Logger.Infof("Reconciling %s (Pass 2): Linking parent relationships...", snapshot.GetName())
+	passCtx, pass = tracing.Start(ctx, "reconcile pass 2: link parents", trace.SpanKindInternal)
+	slog.InfoContext(passCtx, "Linking parent relationships")
 	linksUpdated := 0
 	cycleSkips := 0
 	linkUpdates := make([]*v1.Device, 0, len(processedDevices))
 	for _, dev := range processedDevices {
 		parentKey := dev.Spec.ParentSerialNumber
-		parentURI := propertyString(dev.Spec.Properties, "redfish_parent_uri")
+		parentURI := propertyString(dev.Spec.Properties, v1.PropertyRedfishParentURI)
 		if parentKey == "" {
 			parentKey = parentURI
 		}
@@ -117,11 +234,11 @@ func (r *DiscoverySnapshotReconciler) reconcileDiscoverySnapshot(ctx context.Con
 			}
 		}
 		if parentDevice == nil {
-			r.Logger.Errorf("Reconciling %s (Pass 2): Parent device %s not found for child %s", snapshot.GetName(), parentKey, deviceLabel(dev))
+			slog.ErrorContext(passCtx, "Parent device not found", "device_uid", dev.GetUID(), "device", deviceLabel(dev), "parent", parentKey)
 			continue
 		}
 		if dev.GetUID() == parentDevice.GetUID() {
-			r.Logger.Warnf("Reconciling %s (Pass 2): Skipping self-parenting link for %s", snapshot.GetName(), deviceLabel(dev))
+			slog.WarnContext(passCtx, "Skipping self-parenting link", "device_uid", dev.GetUID(), "device", deviceLabel(dev))
 			continue
 		}
 		if dev.Spec.ParentID == parentDevice.GetUID() {
@@ -129,11 +246,13 @@ func (r *DiscoverySnapshotReconciler) reconcileDiscoverySnapshot(ctx context.Con
 		}
 		if wouldCreateCycle(dev.GetUID(), parentDevice.GetUID(), byUID) {
 			cycleSkips++
-			r.Logger.Warnf("Reconciling %s (Pass 2): Skipping cyclic parent link %s -> %s", snapshot.GetName(), deviceLabel(dev), deviceLabel(parentDevice))
+			slog.WarnContext(passCtx, "Skipping cyclic parent link", "device_uid", dev.GetUID(), "device", deviceLabel(dev),
+				"parent_uid", parentDevice.GetUID(), "parent", deviceLabel(parentDevice))
 			continue
 		}
 
-		r.Logger.Infof("Reconciling %s (Pass 2): Linking %s (UID: %s) to parent %s (UID: %s)", snapshot.GetName(), deviceLabel(dev), dev.GetUID(), deviceLabel(parentDevice), parentDevice.GetUID())
+		slog.InfoContext(passCtx, "Linking device to parent", "device_uid", dev.GetUID(), "device", deviceLabel(dev),
+			"parent_uid", parentDevice.GetUID(), "parent", deviceLabel(parentDevice))
 		dev.Spec.ParentID = parentDevice.GetUID()
 		dev.Metadata.UpdatedAt = time.Now()
 		linkUpdates = append(linkUpdates, dev)
@@ -141,15 +260,19 @@ func (r *DiscoverySnapshotReconciler) reconcileDiscoverySnapshot(ctx context.Con
 		indexDevice(dev, bySerial, byURI, byUID)
 	}
 
-	if err := storage.SaveDevicesBulk(ctx, linkUpdates); err != nil {
-		snapshot.Status.Phase = "Error"
-		snapshot.Status.Message = fmt.Sprintf("Failed to persist parent links: %v", err)
-		snapshot.Status.Ready = false
-		if updateErr := r.UpdateStatus(ctx, snapshot); updateErr != nil {
-			return fmt.Errorf("failed to persist error status: %w", updateErr)
-		}
-		return fmt.Errorf("failed to persist parent links: %w", err)
-	}
+	if err := storage.SaveDevicesBulk(passCtx, linkUpdates); err != nil {
+		return r.failSnapshot(ctx, snapshot, "failed to persist parent links", err)
+	}
+	reconciledDevices.WithLabelValues("linked").Add(float64(linksUpdated))
+	reconciledDevices.WithLabelValues("cycle_skipped").Add(float64(cycleSkips))
+	pass.SetAttributes(
+		attribute.Int("fru_tracker.devices.linked", linksUpdated),
+		attribute.Int("fru_tracker.devices.cycle_skipped", cycleSkips),
+	)
+	pass.End()
+
+	r.publishDeviceEvents(ctx, snapshot, processedDevices, createdUIDs)
+	r.publishInventoryEvents(ctx, snapshot, processedDevices, createdUIDs)
 
 	snapshot.Status.Phase = "Completed"
 	snapshot.Status.Message = fmt.Sprintf("Snapshot processed. %d devices created, %d updated, %d parent links established.", createdCount, updatedCount, linksUpdated)
@@ -158,17 +281,52 @@ func (r *DiscoverySnapshotReconciler) reconcileDiscoverySnapshot(ctx context.Con
 	}
 	snapshot.Status.Ready = true
 
-	r.Logger.Infof("Reconciling %s: Successfully reconciled", snapshot.GetName())
+	slog.InfoContext(ctx, "Reconciled snapshot", "created", createdCount, "updated", updatedCount,
+		"linked", linksUpdated, "cycle_skipped", cycleSkips)
 	return nil
 }
 
+// publishDeviceEvents announces the devices a snapshot created or updated so
+// that watchers see reconciler-driven changes just like API-driven ones.
+// Events are best-effort: failures are logged and never fail the reconcile.
+func (r *DiscoverySnapshotReconciler) publishDeviceEvents(ctx context.Context, snapshot *v1.DiscoverySnapshot, devices []*v1.Device, created map[string]bool) {
+	for _, device := range devices {
+		var err error
+		if created[device.GetUID()] {
+			err = events.PublishResourceCreated(ctx, "Device", device.GetUID(), device.GetName(), device)
+		} else {
+			metadata := map[string]interface{}{
+				"updatedAt":   device.Metadata.UpdatedAt,
+				"snapshotUID": snapshot.GetUID(),
+			}
+			err = events.PublishResourceUpdated(ctx, "Device", device.GetUID(), device.GetName(), device, metadata)
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to publish device event", "device_uid", device.GetUID(), "error", err)
+		}
+	}
+}
+
 func collectLookupKeys(specs []v1.DeviceSpec) []string {
 	keys := make([]string, 0, len(specs)*4)
 	for _, spec := range specs {
 		keys = append(keys, spec.SerialNumber)
-		keys = append(keys, propertyString(spec.Properties, "redfish_uri"))
+		keys = append(keys, propertyString(spec.Properties, v1.PropertyRedfishURI))
 		keys = append(keys, spec.ParentSerialNumber)
-		keys = append(keys, propertyString(spec.Properties, "redfish_parent_uri"))
+		keys = append(keys, propertyString(spec.Properties, v1.PropertyRedfishParentURI))
+	}
+	return keys
+}
+
+// snapshotLockKeys returns the keys a reconcile of the snapshot uid locks:
+// the snapshot itself and the serial numbers it names, its devices' and their
+// parents'. Unlike collectLookupKeys it leaves out Redfish URIs, which every
+// node of a model shares and so would serialise all snapshots.
+func snapshotLockKeys(uid string, specs []v1.DeviceSpec) []string {
+	keys := make([]string, 0, len(specs)*2+1)
+	keys = append(keys, "snapshot/"+uid)
+	for _, spec := range specs {
+		keys = append(keys, spec.SerialNumber, spec.ParentSerialNumber)
 	}
 	return keys
 }
@@ -222,7 +380,7 @@ func chooseDeviceName(spec v1.DeviceSpec) string {
 	if spec.SerialNumber != "" {
 		return spec.SerialNumber
 	}
-	if uri := propertyString(spec.Properties, "redfish_uri"); uri != "" {
+	if uri := propertyString(spec.Properties, v1.PropertyRedfishURI); uri != "" {
 		return uri
 	}
 	if spec.DeviceType != "" {
@@ -267,7 +425,7 @@ func indexDevice(device *v1.Device, bySerial, byURI, byUID map[string]*v1.Device
 	if device.Spec.SerialNumber != "" {
 		bySerial[device.Spec.SerialNumber] = device
 	}
-	if uri := propertyString(device.Spec.Properties, "redfish_uri"); uri != "" {
+	if uri := propertyString(device.Spec.Properties, v1.PropertyRedfishURI); uri != "" {
 		byURI[uri] = device
 	}
 }
@@ -278,7 +436,7 @@ func matchDevice(spec v1.DeviceSpec, bySerial, byURI map[string]*v1.Device) *v1.
 			return device
 		}
 	}
-	if uri := propertyString(spec.Properties, "redfish_uri"); uri != "" {
+	if uri := propertyString(spec.Properties, v1.PropertyRedfishURI); uri != "" {
 		if device := byURI[uri]; device != nil {
 			return device
 		}
@@ -293,7 +451,7 @@ func deviceLabel(device *v1.Device) string {
 	if device.Spec.SerialNumber != "" {
 		return device.Spec.SerialNumber
 	}
-	if uri := propertyString(device.Spec.Properties, "redfish_uri"); uri != "" {
+	if uri := propertyString(device.Spec.Properties, v1.PropertyRedfishURI); uri != "" {
 		return uri
 	}
 	return device.GetName()
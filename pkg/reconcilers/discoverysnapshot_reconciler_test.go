@@ -7,15 +7,19 @@ package reconcilers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"sync"
 	"testing"
 
 	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
 	"github.com/example/fru-tracker/internal/storage"
-	"github.com/example/fru-tracker/internal/storage/ent/enttest"
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
 	"github.com/openchami/fabrica/pkg/events"
 	"github.com/openchami/fabrica/pkg/fabrica"
 	"github.com/openchami/fabrica/pkg/resource"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -106,10 +110,7 @@ func TestDiscoverySnapshotReconciler(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			client := enttest.Open(t, "sqlite3", "file:reconcile?mode=memory&cache=shared&_fk=1")
-			t.Cleanup(func() {
-				require.NoError(t, client.Close())
-			})
+			client := storagetest.Open(t)
 			storage.SetEntClient(client)
 
 			bus := events.NewInMemoryEventBus(10, 10)
@@ -139,8 +140,12 @@ func TestDiscoverySnapshotReconciler(t *testing.T) {
 			}
 			snapshot.Metadata.Initialize(snapshot.Metadata.Name, snapshot.Metadata.UID)
 
+			completed := testutil.ToFloat64(snapshotReconciles.WithLabelValues(resultCompleted))
+			timed := observations(t, snapshotReconcileDuration.WithLabelValues(resultCompleted))
 			err = reconciler.reconcileDiscoverySnapshot(ctx, snapshot)
 			require.NoError(t, err)
+			assert.Equal(t, completed+1, testutil.ToFloat64(snapshotReconciles.WithLabelValues(resultCompleted)))
+			assert.Equal(t, timed+1, observations(t, snapshotReconcileDuration.WithLabelValues(resultCompleted)))
 			assert.Equal(t, tt.expectedPhase, snapshot.Status.Phase)
 			assert.Equal(t, tt.expectedReady, snapshot.Status.Ready)
 			for _, fragment := range tt.expectedMessageHas {
@@ -202,3 +207,149 @@ func rawJSONString(t *testing.T, value string) json.RawMessage {
 	require.NoError(t, err)
 	return raw
 }
+
+// TestConcurrentSnapshotsConverge posts snapshots of the same node from many
+// collectors at once and checks they all merge into one Device, whether the
+// per-device locks serialise them or they race to create it.
+func TestConcurrentSnapshotsConverge(t *testing.T) {
+	resource.RegisterResourcePrefix("Device", "device")
+	resource.RegisterResourcePrefix("DiscoverySnapshot", "discoverysnapshot")
+
+	ctx := context.Background()
+	client := storagetest.Open(t)
+	storage.EnforceDeviceIdentities(client)
+	storage.SetEntClient(client)
+
+	// Storage refuses a second device with the same serial, on every write path
+	renamed := newDevice(t, "NODE-0", "rack12-node0", "Node", nil)
+	require.NoError(t, storage.SaveDevicesBulk(ctx, []*v1.Device{renamed}))
+	duplicate := newDevice(t, "NODE-0", "NODE-0", "Node", nil)
+	assert.ErrorIs(t, storage.SaveDevicesBulk(ctx, []*v1.Device{duplicate}), storage.ErrDeviceConflict)
+	assert.ErrorIs(t, storage.SaveDevice(ctx, duplicate), storage.ErrDeviceConflict)
+	found, err := storage.LoadDevicesByIdentifiers(ctx, []string{"NODE-0"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, renamed.GetUID(), found[0].GetUID())
+
+	// but devices written together may hand serials to each other
+	first := newDevice(t, "SWAP-1", "first", "PSU", nil)
+	second := newDevice(t, "SWAP-2", "second", "PSU", nil)
+	require.NoError(t, storage.SaveDevicesBulk(ctx, []*v1.Device{first, second}))
+	first.Spec.SerialNumber, second.Spec.SerialNumber = "SWAP-2", "SWAP-1"
+	require.NoError(t, storage.SaveDevicesBulk(ctx, []*v1.Device{first, second}))
+	found, err = storage.LoadDevicesByIdentifiers(ctx, []string{"SWAP-1"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, second.GetUID(), found[0].GetUID())
+	assert.ErrorIs(t, storage.SaveDevice(ctx, newDevice(t, "SWAP-2", "SWAP-2", "PSU", nil)), storage.ErrDeviceConflict)
+
+	const collectors = 8
+	reconciler := NewDefaultDiscoverySnapshotReconciler(storage.NewStorageClient(), nil)
+	snapshotOf := func(name string) (*v1.DiscoverySnapshot, []v1.DeviceSpec) {
+		specs := []v1.DeviceSpec{
+			{DeviceType: "Node", SerialNumber: "NODE-1"},
+			{DeviceType: "DIMM", SerialNumber: "DIMM-" + name, ParentSerialNumber: "NODE-1"},
+		}
+		rawData, err := json.Marshal(specs)
+		require.NoError(t, err)
+		snapshot := &v1.DiscoverySnapshot{
+			APIVersion: "example.fabrica.dev/v1",
+			Kind:       "DiscoverySnapshot",
+			Metadata:   fabrica.Metadata{Name: name, UID: "discoverysnapshot-" + name},
+			Spec:       v1.DiscoverySnapshotSpec{RawData: rawData},
+		}
+		snapshot.Metadata.Initialize(snapshot.Metadata.Name, snapshot.Metadata.UID)
+		return snapshot, specs
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*collectors)
+	for i := range collectors {
+		locked, _ := snapshotOf(fmt.Sprintf("locked-%d", i))
+		wg.Go(func() { errs <- reconciler.reconcileDiscoverySnapshot(ctx, locked) })
+		// Around the locks, as reconciles in two server processes would be
+		racing, specs := snapshotOf(fmt.Sprintf("racing-%d", i))
+		wg.Go(func() { errs <- reconciler.attemptSnapshot(ctx, 0, racing, specs) })
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	devices, err := storage.LoadAllDevices(ctx)
+	require.NoError(t, err)
+	var nodes, dimms []*v1.Device
+	for _, device := range devices {
+		switch device.Spec.DeviceType {
+		case "Node":
+			if device.Spec.SerialNumber == "NODE-1" {
+				nodes = append(nodes, device)
+			}
+		case "DIMM":
+			dimms = append(dimms, device)
+		}
+	}
+	require.Len(t, nodes, 1)
+	require.Len(t, dimms, 2*collectors)
+	for _, dimm := range dimms {
+		assert.Equal(t, nodes[0].GetUID(), dimm.Spec.ParentID, dimm.Spec.SerialNumber)
+	}
+}
+
+// TestNodesShareRedfishLayout reconciles two nodes whose BMCs report the same
+// Redfish URIs, as every node of one model does, and checks they stay apart.
+func TestNodesShareRedfishLayout(t *testing.T) {
+	resource.RegisterResourcePrefix("Device", "device")
+	resource.RegisterResourcePrefix("DiscoverySnapshot", "discoverysnapshot")
+
+	ctx := context.Background()
+	client := storagetest.Open(t)
+	storage.EnforceDeviceIdentities(client)
+	storage.SetEntClient(client)
+
+	reconciler := NewDefaultDiscoverySnapshotReconciler(storage.NewStorageClient(), nil)
+	for _, node := range []string{"A", "B"} {
+		specs := []v1.DeviceSpec{
+			{DeviceType: "Node", SerialNumber: "NODE-" + node, Properties: map[string]json.RawMessage{
+				v1.PropertyRedfishURI: rawJSONString(t, "/Systems/1"),
+			}},
+			{DeviceType: "DIMM", SerialNumber: "DIMM-" + node, Properties: map[string]json.RawMessage{
+				v1.PropertyRedfishURI:       rawJSONString(t, "/Systems/1/Memory/DIMM1"),
+				v1.PropertyRedfishParentURI: rawJSONString(t, "/Systems/1"),
+			}},
+		}
+		rawData, err := json.Marshal(specs)
+		require.NoError(t, err)
+		snapshot := &v1.DiscoverySnapshot{
+			APIVersion: "example.fabrica.dev/v1",
+			Kind:       "DiscoverySnapshot",
+			Metadata:   fabrica.Metadata{Name: "node-" + node, UID: "discoverysnapshot-node-" + node},
+			Spec:       v1.DiscoverySnapshotSpec{RawData: rawData},
+		}
+		snapshot.Metadata.Initialize(snapshot.Metadata.Name, snapshot.Metadata.UID)
+		require.NoError(t, reconciler.reconcileDiscoverySnapshot(ctx, snapshot))
+		assert.Equal(t, "Completed", snapshot.Status.Phase, snapshot.Status.Message)
+	}
+
+	devices, err := storage.LoadAllDevices(ctx)
+	require.NoError(t, err)
+	require.Len(t, devices, 4)
+	bySerial := make(map[string]*v1.Device)
+	for _, device := range devices {
+		bySerial[device.Spec.SerialNumber] = device
+	}
+	for _, node := range []string{"A", "B"} {
+		require.Contains(t, bySerial, "NODE-"+node)
+		require.Contains(t, bySerial, "DIMM-"+node)
+		assert.Equal(t, bySerial["NODE-"+node].GetUID(), bySerial["DIMM-"+node].Spec.ParentID, node)
+	}
+}
+
+// observations returns how many values histogram has recorded.
+func observations(t *testing.T, histogram prometheus.Observer) uint64 {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, histogram.(prometheus.Metric).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
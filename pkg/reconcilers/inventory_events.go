@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package reconcilers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/openchami/fabrica/pkg/events"
+)
+
+// publishInventoryEvents publishes an inventory event for each device a
+// snapshot found for the first time. Re-parented devices and part number
+// swaps are recorded by storage as the devices are written, see
+// storage.RecordInventoryChanges. Like publishDeviceEvents this is
+// best-effort and never fails the reconcile.
+func (r *DiscoverySnapshotReconciler) publishInventoryEvents(ctx context.Context, snapshot *v1.DiscoverySnapshot, devices []*v1.Device, created map[string]bool) {
+	if r.EventBus == nil {
+		return
+	}
+
+	// A payload may list a device more than once; report its final state once
+	final := make(map[string]*v1.Device, len(devices))
+	order := make([]string, 0, len(devices))
+	for _, device := range devices {
+		if !created[device.GetUID()] {
+			continue
+		}
+		if _, seen := final[device.GetUID()]; !seen {
+			order = append(order, device.GetUID())
+		}
+		final[device.GetUID()] = device
+	}
+
+	for _, uid := range order {
+		change := v1.NewDeviceChange(final[uid])
+		change.SnapshotUID = snapshot.GetUID()
+		if err := publishInventoryEvent(ctx, r.EventBus, v1.DeviceAddedEventType, change); err != nil {
+			slog.WarnContext(ctx, "Failed to publish inventory event", "event_type", v1.DeviceAddedEventType, "device_uid", uid, "error", err)
+		}
+	}
+}
+
+// RelayDeviceRemovals subscribes to the lifecycle event of deleted Devices and
+// republishes each one as a DeviceRemovedEventType inventory event, carrying
+// the last known state of the device from its tombstone.
+func RelayDeviceRemovals(bus events.EventBus, prefix string) error {
+	eventType := fmt.Sprintf("%s.device.deleted", prefix)
+	handler := func(ctx context.Context, event events.Event) error {
+		uid := event.ResourceUID()
+		change := v1.DeviceChange{DeviceUID: uid}
+		device, err := storage.LoadDeletedDevice(ctx, uid)
+		switch {
+		case err == nil:
+			change = v1.NewDeviceChange(device)
+		case !errors.Is(err, storage.ErrNotFound):
+			return fmt.Errorf("failed to load deleted device %s: %w", uid, err)
+		}
+		return publishInventoryEvent(ctx, bus, v1.DeviceRemovedEventType, change)
+	}
+	if _, err := bus.Subscribe(eventType, handler); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", eventType, err)
+	}
+	return nil
+}
+
+func publishInventoryEvent(ctx context.Context, bus events.EventBus, eventType string, change v1.DeviceChange) error {
+	event, err := events.NewEvent(eventType, storage.InventoryEventSource, change)
+	if err != nil {
+		return fmt.Errorf("failed to create %s event: %w", eventType, err)
+	}
+	return bus.Publish(ctx, *event)
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/fabrica"
+	"github.com/openchami/fabrica/pkg/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventoryChanges(t *testing.T) {
+	device := &v1.Device{
+		Spec: v1.DeviceSpec{
+			DeviceType:   "DIMM",
+			SerialNumber: "DIMM-1",
+			PartNumber:   "PN-2",
+			ParentID:     "device-node-b",
+		},
+	}
+	device.Metadata.UID = "device-dimm-1"
+
+	tests := []struct {
+		name     string
+		previous v1.DeviceSpec
+		expected map[string]v1.DeviceChange
+	}{
+		{
+			name:     "unchanged device",
+			previous: device.Spec,
+			expected: map[string]v1.DeviceChange{},
+		},
+		{
+			name:     "first parent link and first part number are not changes",
+			previous: v1.DeviceSpec{DeviceType: "DIMM", SerialNumber: "DIMM-1"},
+			expected: map[string]v1.DeviceChange{},
+		},
+		{
+			name:     "moved and swapped",
+			previous: v1.DeviceSpec{DeviceType: "DIMM", SerialNumber: "DIMM-1", PartNumber: "PN-1", ParentID: "device-node-a"},
+			expected: map[string]v1.DeviceChange{
+				v1.DeviceReparentedEventType: {
+					DeviceUID: "device-dimm-1", DeviceType: "DIMM", SerialNumber: "DIMM-1", PartNumber: "PN-2",
+					ParentID: "device-node-b", PreviousParentID: "device-node-a",
+				},
+				v1.DevicePartNumberChangedEventType: {
+					DeviceUID: "device-dimm-1", DeviceType: "DIMM", SerialNumber: "DIMM-1", PartNumber: "PN-2",
+					ParentID: "device-node-b", PreviousPartNumber: "PN-1",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, storage.InventoryChanges(device, tt.previous))
+		})
+	}
+}
+
+// TestSnapshotInventoryEvents checks the inventory events of a snapshot that
+// finds a new DIMM and moves a known one to another node: the first is
+// announced by the reconciler, the move by storage as it is written.
+func TestSnapshotInventoryEvents(t *testing.T) {
+	resource.RegisterResourcePrefix("Device", "device")
+	resource.RegisterResourcePrefix("DiscoverySnapshot", "discoverysnapshot")
+
+	ctx := context.Background()
+	client := storagetest.Open(t)
+	storage.SetEntClient(client)
+
+	memoryBus := events.NewInMemoryEventBus(10, 1)
+	memoryBus.Start()
+	bus := storage.NewDurableEventBus(memoryBus, 0)
+	bus.RecordInventoryChanges(client)
+	t.Cleanup(func() { _ = bus.Close() })
+	received := make(chan events.Event, 10)
+	for _, eventType := range v1.InventoryEventTypes {
+		_, err := bus.Subscribe(eventType, func(ctx context.Context, event events.Event) error {
+			received <- event
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	bus.Start()
+
+	nodeA := newDevice(t, "NODE-A", "NODE-A", "Node", nil)
+	nodeB := newDevice(t, "NODE-B", "NODE-B", "Node", nil)
+	require.NoError(t, storage.SaveDevicesBulk(ctx, []*v1.Device{nodeA, nodeB}))
+	moved := newDevice(t, "DIMM-1", "DIMM-1", "DIMM", nil)
+	moved.Spec.ParentID = nodeA.GetUID()
+	require.NoError(t, storage.SaveDevicesBulk(ctx, []*v1.Device{moved}))
+
+	rawData, err := json.Marshal([]v1.DeviceSpec{
+		{DeviceType: "Node", SerialNumber: "NODE-B"},
+		{DeviceType: "DIMM", SerialNumber: "DIMM-1", ParentSerialNumber: "NODE-B"},
+		{DeviceType: "DIMM", SerialNumber: "DIMM-2", ParentSerialNumber: "NODE-B"},
+	})
+	require.NoError(t, err)
+	snapshot := &v1.DiscoverySnapshot{
+		APIVersion: "example.fabrica.dev/v1",
+		Kind:       "DiscoverySnapshot",
+		Metadata:   fabrica.Metadata{Name: "node-b", UID: "discoverysnapshot-node-b"},
+		Spec:       v1.DiscoverySnapshotSpec{RawData: rawData},
+	}
+	snapshot.Metadata.Initialize(snapshot.Metadata.Name, snapshot.Metadata.UID)
+	reconciler := NewDefaultDiscoverySnapshotReconciler(storage.NewStorageClient(), bus)
+	require.NoError(t, reconciler.reconcileDiscoverySnapshot(ctx, snapshot))
+
+	changes := make(map[string]v1.DeviceChange)
+	for len(changes) < 2 {
+		select {
+		case event := <-received:
+			var change v1.DeviceChange
+			require.NoError(t, event.DataAs(&change))
+			changes[event.Type()] = change
+		case <-time.After(5 * time.Second):
+			t.Fatalf("missing inventory events, got %v", changes)
+		}
+	}
+	assert.Equal(t, "DIMM-2", changes[v1.DeviceAddedEventType].SerialNumber)
+	assert.Equal(t, snapshot.GetUID(), changes[v1.DeviceAddedEventType].SnapshotUID)
+	reparented := changes[v1.DeviceReparentedEventType]
+	assert.Equal(t, moved.GetUID(), reparented.DeviceUID)
+	assert.Equal(t, nodeB.GetUID(), reparented.ParentID)
+	assert.Equal(t, nodeA.GetUID(), reparented.PreviousParentID)
+	assert.Equal(t, snapshot.GetUID(), reparented.SnapshotUID)
+
+	select {
+	case event := <-received:
+		t.Errorf("unexpected %s event", event.Type())
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package reconcilers
+
+import (
+	"context"
+
+	"github.com/example/fru-tracker/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Reconcile outcomes, used as the result label of the snapshot metrics.
+const (
+	resultCompleted = "completed"
+	resultError     = "error"
+	resultSkipped   = "skipped"
+)
+
+var (
+	snapshotReconciles = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fru_tracker_snapshot_reconciles_total",
+		Help: "Discovery snapshot reconciles by result: completed, error, or skipped because the snapshot was already completed.",
+	}, []string{"result"})
+	snapshotReconcileDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fru_tracker_snapshot_reconcile_duration_seconds",
+		Help:    "Time taken to reconcile a discovery snapshot, by result.",
+		Buckets: metrics.DefaultBuckets,
+	}, []string{"result"})
+	snapshotReconcilesInProgress = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "fru_tracker_snapshot_reconciles_in_progress",
+		Help: "Discovery snapshots being reconciled right now.",
+	})
+	snapshotReconcileRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "fru_tracker_snapshot_reconcile_retries_total",
+		Help: "Failed discovery snapshot reconcile attempts that were retried.",
+	})
+	snapshotReconcileConflicts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "fru_tracker_snapshot_reconcile_conflicts_total",
+		Help: "Discovery snapshot reconcile attempts started over because another writer created one of their devices first.",
+	})
+	reconciledDevices = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fru_tracker_reconciled_devices_total",
+		Help: "Devices written by snapshot reconciles, by action: created, updated, linked to a parent, or link skipped because it would create a cycle.",
+	}, []string{"action"})
+)
+
+func init() {
+	prometheus.MustRegister(metrics.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "fru_tracker_snapshot_reconcile_queue_depth",
+		Help: "Discovery snapshots accepted and not yet completed or failed.",
+	}, "", func(ctx context.Context) (map[string]float64, error) {
+		backlog, err := SnapshotBacklog(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]float64{"": float64(backlog)}, nil
+	}))
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/openchami/fabrica/pkg/events"
+)
+
+// RequeueUnfinishedSnapshots publishes a created event for every
+// DiscoverySnapshot that never reached Completed or Error, so snapshots
+// accepted before a crash are still reconciled. Snapshots in pending, whose
+// events are already waiting to be delivered, are skipped.
+//
+// It returns the number of snapshots requeued.
+func RequeueUnfinishedSnapshots(ctx context.Context, pending map[string]bool) (int, error) {
+	snapshots, err := storage.LoadAllDiscoverySnapshots(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load discovery snapshots: %w", err)
+	}
+
+	requeued := 0
+	for _, snapshot := range snapshots {
+		if snapshot.Status.Phase == "Completed" || snapshot.Status.Phase == "Error" || pending[snapshot.GetUID()] {
+			continue
+		}
+		if err := events.PublishResourceCreated(ctx, "DiscoverySnapshot", snapshot.GetUID(), snapshot.GetName(), snapshot); err != nil {
+			return requeued, fmt.Errorf("failed to requeue DiscoverySnapshot %s: %w", snapshot.GetUID(), err)
+		}
+		requeued++
+	}
+	return requeued, nil
+}
+
+// SnapshotBacklog returns the number of discovery snapshots accepted and not
+// yet completed or failed.
+func SnapshotBacklog(ctx context.Context) (int, error) {
+	phases, err := storage.CountDiscoverySnapshotsByPhase(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return phases[storage.PhasePending] + phases["Processing"], nil
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package reconcilers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/fabrica"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnfinishedSnapshotsSurviveRestart(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.Open(t)
+	storage.SetEntClient(client)
+
+	for uid, phase := range map[string]string{
+		"discoverysnapshot-new":        "",
+		"discoverysnapshot-crashed":    "Processing",
+		"discoverysnapshot-done":       "Completed",
+		"discoverysnapshot-unparsable": "Error",
+	} {
+		snapshot := &v1.DiscoverySnapshot{
+			APIVersion: "example.fabrica.dev/v1",
+			Kind:       "DiscoverySnapshot",
+			Metadata:   fabrica.Metadata{Name: uid, UID: uid},
+			Status:     v1.DiscoverySnapshotStatus{Phase: phase},
+		}
+		snapshot.Metadata.Initialize(snapshot.Metadata.Name, snapshot.Metadata.UID)
+		require.NoError(t, storage.SaveDiscoverySnapshot(ctx, snapshot))
+	}
+
+	// First run: the created event for the new snapshot is recorded, then the
+	// server dies before relaying it
+	crashed := storage.NewDurableEventBus(events.NewInMemoryEventBus(10, 1), 0)
+	t.Cleanup(func() {
+		_ = crashed.Close()
+	})
+	events.SetGlobalEventBus(crashed)
+	require.NoError(t, events.PublishResourceCreated(ctx, "DiscoverySnapshot", "discoverysnapshot-new", "discoverysnapshot-new", nil))
+
+	// Second run: subscribe, requeue what the outbox does not already hold, relay
+	memoryBus := events.NewInMemoryEventBus(10, 1)
+	memoryBus.Start()
+	bus := storage.NewDurableEventBus(memoryBus, 0)
+	t.Cleanup(func() {
+		_ = bus.Close()
+	})
+	events.SetGlobalEventBus(bus)
+	t.Cleanup(func() {
+		events.SetGlobalEventBus(nil)
+	})
+
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+	eventType := events.GetEventConfig().EventTypePrefix + ".discoverysnapshot.created"
+	_, err := bus.Subscribe(eventType, func(ctx context.Context, event events.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, event.ResourceUID())
+		return nil
+	})
+	require.NoError(t, err)
+
+	pending, err := bus.PendingResourceUIDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"discoverysnapshot-new": true}, pending)
+
+	requeued, err := RequeueUnfinishedSnapshots(ctx, pending)
+	require.NoError(t, err)
+	assert.Equal(t, 1, requeued)
+
+	bus.Start()
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	// Delivered once each, in publish order
+	assert.Equal(t, []string{"discoverysnapshot-new", "discoverysnapshot-crashed"}, received)
+
+	require.Eventually(t, func() bool {
+		pending, err := bus.PendingResourceUIDs(ctx)
+		return err == nil && len(pending) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// TestEventsStayPendingUntilHandled checks that an event is only marked
+// delivered once its subscribers are done with it, including work they
+// finish in the background, and is delivered again after a restart if not.
+func TestEventsStayPendingUntilHandled(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.Open(t)
+	storage.SetEntClient(client)
+	t.Cleanup(func() {
+		events.SetGlobalEventBus(nil)
+	})
+	eventType := events.GetEventConfig().EventTypePrefix + ".device.created"
+
+	// First run: the subscriber starts background work, which is abandoned
+	// when the server stops
+	memoryBus := events.NewInMemoryEventBus(10, 1)
+	memoryBus.Start()
+	stopped := storage.NewDurableEventBus(memoryBus, 0)
+	releases := make(chan func(bool), 1)
+	_, err := stopped.Subscribe(eventType, func(ctx context.Context, event events.Event) error {
+		releases <- storage.DeferAck(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+	stopped.Start()
+	events.SetGlobalEventBus(stopped)
+	require.NoError(t, events.PublishResourceCreated(ctx, "Device", "device-1", "DIMM-1", nil))
+
+	var release func(bool)
+	select {
+	case release = <-releases:
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not delivered")
+	}
+	time.Sleep(20 * time.Millisecond)
+	pending, err := stopped.PendingResourceUIDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"device-1": true}, pending, "acknowledged before the work finished")
+
+	release(false)
+	require.NoError(t, stopped.Close())
+	pending, err = stopped.PendingResourceUIDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"device-1": true}, pending)
+
+	// Second run: the event is delivered again and acknowledged once done
+	memoryBus = events.NewInMemoryEventBus(10, 1)
+	memoryBus.Start()
+	bus := storage.NewDurableEventBus(memoryBus, 0)
+	t.Cleanup(func() {
+		_ = bus.Close()
+	})
+	redelivered := make(chan string, 1)
+	_, err = bus.Subscribe(eventType, func(ctx context.Context, event events.Event) error {
+		release := storage.DeferAck(ctx)
+		go func() {
+			redelivered <- event.ResourceUID()
+			release(true)
+		}()
+		return nil
+	})
+	require.NoError(t, err)
+	bus.Start()
+	select {
+	case uid := <-redelivered:
+		assert.Equal(t, "device-1", uid)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not delivered again")
+	}
+	require.Eventually(t, func() bool {
+		pending, err := bus.PendingResourceUIDs(ctx)
+		return err == nil && len(pending) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -41,6 +41,11 @@ func RegisterReconcilers(controller *reconcile.Controller, client reconcile.Clie
 	if err := controller.RegisterReconciler(discoverysnapshotsReconciler); err != nil {
 		return err
 	}
+	// Register WebhookSubscription reconciler
+	webhooksubscriptionsReconciler := NewDefaultWebhookSubscriptionReconciler(client, eventBus)
+	if err := controller.RegisterReconciler(webhooksubscriptionsReconciler); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -52,5 +57,6 @@ func GetRegisteredReconcilers() []string {
 	return []string{
 		"Device",
 		"DiscoverySnapshot",
+		"WebhookSubscription",
 	}
 }
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package reconcilers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/reconcile"
+)
+
+// RegisterControllerReconcilers registers every reconciler with controller
+// except the DiscoverySnapshot one, which a SnapshotRunner runs instead. The
+// controller's worker pool is fixed, so left to it, snapshots could never be
+// reconciled more than that many at once whatever Tuning.Workers says.
+func RegisterControllerReconcilers(controller *reconcile.Controller, client reconcile.ClientInterface, eventBus events.EventBus) error {
+	if err := controller.RegisterReconciler(NewDefaultDeviceReconciler(client, eventBus)); err != nil {
+		return err
+	}
+	return controller.RegisterReconciler(NewDefaultWebhookSubscriptionReconciler(client, eventBus))
+}
+
+// SnapshotRunner reconciles each DiscoverySnapshot its lifecycle events name
+// in a goroutine of its own, so that Tuning.Workers alone bounds how many are
+// reconciled at once. A failed snapshot is retried as tuned and then left in
+// the Error phase; it is not requeued.
+type SnapshotRunner struct {
+	reconciler *DiscoverySnapshotReconciler
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewSnapshotRunner creates a SnapshotRunner. Call Subscribe to start
+// reconciling and Close to stop.
+func NewSnapshotRunner(client reconcile.ClientInterface, eventBus events.EventBus) *SnapshotRunner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SnapshotRunner{
+		reconciler: NewDefaultDiscoverySnapshotReconciler(client, eventBus),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Subscribe reconciles the snapshot of every DiscoverySnapshot lifecycle
+// event published on bus with the given type prefix.
+func (r *SnapshotRunner) Subscribe(bus events.EventBus, prefix string) error {
+	eventType := prefix + ".discoverysnapshot.*"
+	if _, err := bus.Subscribe(eventType, r.handleEvent); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", eventType, err)
+	}
+	return nil
+}
+
+// Close cancels reconciles in progress and waits for them to return. Their
+// events are delivered again on the next start.
+func (r *SnapshotRunner) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// handleEvent starts reconciling the event's snapshot and returns without
+// waiting, deferring the event's acknowledgement until the reconcile is done.
+func (r *SnapshotRunner) handleEvent(ctx context.Context, event events.Event) error {
+	release := storage.DeferAck(ctx)
+	// Keep the event's trace and log fields, but stop with the runner
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(r.ctx, cancel)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer cancel()
+		defer stop()
+		r.run(ctx, event.ResourceUID())
+		release(r.ctx.Err() == nil)
+	}()
+	return nil
+}
+
+// run reconciles the snapshot uid as it is stored now. Deleted snapshots are
+// skipped.
+func (r *SnapshotRunner) run(ctx context.Context, uid string) {
+	raw, err := storage.Backend.Load(ctx, "DiscoverySnapshot", uid)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) && ctx.Err() == nil {
+			slog.WarnContext(ctx, "Failed to load snapshot to reconcile", "snapshot_uid", uid, "error", err)
+		}
+		return
+	}
+	// Failures are logged and recorded in the snapshot's status
+	_, _ = r.reconciler.Reconcile(ctx, raw)
+}
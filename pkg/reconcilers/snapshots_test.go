@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/ent"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/fabrica"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReconcileWorkersBoundConcurrency checks that raising Tuning.Workers
+// lets more snapshots be reconciled at once, past the reconcile
+// controller's own pool.
+func TestReconcileWorkersBoundConcurrency(t *testing.T) {
+	for _, workers := range []int{1, 3, 8} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			assert.Equal(t, workers, peakSnapshotReconciles(t, workers, 10))
+		})
+	}
+}
+
+// peakSnapshotReconciles reconciles snapshots of that many distinct nodes
+// through a SnapshotRunner and returns how many were reconciling at once.
+func peakSnapshotReconciles(t *testing.T, workers, snapshots int) int {
+	ctx := context.Background()
+	client := storagetest.Open(t)
+	storage.EnforceDeviceIdentities(client)
+	storage.SetEntClient(client)
+	require.NoError(t, Configure(Tuning{Workers: workers}))
+	t.Cleanup(func() { require.NoError(t, Configure(DefaultTuning())) })
+	t.Cleanup(func() { events.SetGlobalEventBus(nil) })
+
+	// Every reconcile in a worker slot starts by looking up its devices;
+	// hold those lookups until as many reconciles as will run are waiting
+	var (
+		mu      sync.Mutex
+		running int
+		peak    int
+	)
+	release := make(chan struct{})
+	releaseAll := sync.OnceFunc(func() { close(release) })
+	client.DeviceIdentity.Intercept(ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, query ent.Query) (ent.Value, error) {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+			<-release
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return next.Query(ctx, query)
+		})
+	}))
+
+	memoryBus := events.NewInMemoryEventBus(snapshots, 1)
+	memoryBus.Start()
+	bus := storage.NewDurableEventBus(memoryBus, 0)
+	t.Cleanup(func() { _ = bus.Close() })
+	runner := NewSnapshotRunner(storage.NewStorageClient(), bus)
+	require.NoError(t, runner.Subscribe(bus, events.GetEventConfig().EventTypePrefix))
+	t.Cleanup(runner.Close)
+	t.Cleanup(releaseAll)
+	bus.Start()
+	events.SetGlobalEventBus(bus)
+
+	for i := range snapshots {
+		rawData, err := json.Marshal([]v1.DeviceSpec{{DeviceType: "Node", SerialNumber: fmt.Sprintf("NODE-%d", i)}})
+		require.NoError(t, err)
+		snapshot := &v1.DiscoverySnapshot{
+			APIVersion: "example.fabrica.dev/v1",
+			Kind:       "DiscoverySnapshot",
+			Metadata:   fabrica.Metadata{Name: fmt.Sprintf("node-%d", i), UID: fmt.Sprintf("discoverysnapshot-%d", i)},
+			Spec:       v1.DiscoverySnapshotSpec{RawData: rawData},
+		}
+		snapshot.Metadata.Initialize(snapshot.Metadata.Name, snapshot.Metadata.UID)
+		require.NoError(t, storage.SaveDiscoverySnapshot(ctx, snapshot))
+		require.NoError(t, events.PublishResourceCreated(ctx, "DiscoverySnapshot", snapshot.GetUID(), snapshot.GetName(), snapshot))
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return running == min(workers, snapshots)
+	}, 5*time.Second, 10*time.Millisecond)
+	// Give any reconcile over the limit time to show up
+	time.Sleep(50 * time.Millisecond)
+	releaseAll()
+
+	require.Eventually(t, func() bool {
+		phases, err := storage.CountDiscoverySnapshotsByPhase(ctx)
+		return err == nil && phases["Completed"] == snapshots
+	}, 10*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	return peak
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package reconcilers
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Tuning controls how discovery snapshots are reconciled.
+type Tuning struct {
+	// Workers is the most snapshots reconciled at once.
+	Workers int
+	// Timeout bounds each attempt at reconciling a snapshot; 0 means none.
+	Timeout time.Duration
+	// MaxRetries is how many times a failed attempt is retried before the
+	// snapshot is left in the Error phase.
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry. It doubles for each
+	// retry after that.
+	RetryBackoff time.Duration
+}
+
+// DefaultTuning returns the tuning used until Configure is called.
+func DefaultTuning() Tuning {
+	return Tuning{
+		Workers:      5,
+		Timeout:      5 * time.Minute,
+		MaxRetries:   3,
+		RetryBackoff: time.Second,
+	}
+}
+
+// retryDelay is how long to wait before the given retry (starting at 1).
+func (t Tuning) retryDelay(retry int) time.Duration {
+	return t.RetryBackoff << min(retry-1, 10)
+}
+
+// tuned is a Tuning with the worker slots it allows.
+type tuned struct {
+	Tuning
+	slots chan struct{}
+}
+
+var current atomic.Pointer[tuned]
+
+func init() {
+	current.Store(&tuned{Tuning: DefaultTuning(), slots: make(chan struct{}, DefaultTuning().Workers)})
+}
+
+// Configure sets the tuning of snapshot reconciles that start after it
+// returns. Call it before subscribing a SnapshotRunner.
+func Configure(t Tuning) error {
+	switch {
+	case t.Workers < 1:
+		return fmt.Errorf("reconcile workers must be at least 1, got %d", t.Workers)
+	case t.Timeout < 0:
+		return fmt.Errorf("reconcile timeout must not be negative, got %s", t.Timeout)
+	case t.MaxRetries < 0:
+		return fmt.Errorf("reconcile max retries must not be negative, got %d", t.MaxRetries)
+	case t.RetryBackoff < 0:
+		return fmt.Errorf("reconcile retry backoff must not be negative, got %s", t.RetryBackoff)
+	}
+	current.Store(&tuned{Tuning: t, slots: make(chan struct{}, t.Workers)})
+	return nil
+}
+
+// keyLocks hands out exclusive locks on sets of keys. Sets are locked in
+// sorted order, so two holders waiting on each other's keys cannot deadlock.
+type keyLocks struct {
+	mu   sync.Mutex
+	held map[string]*keyLock
+}
+
+type keyLock struct {
+	sync.Mutex
+	refs int
+}
+
+// snapshotLocks serialises reconciles of snapshots that share a serial
+// number, so two snapshots of the same node never merge it at once.
+var snapshotLocks = &keyLocks{held: make(map[string]*keyLock)}
+
+// lock blocks until every key is held by the caller and returns the function
+// releasing them. Empty and repeated keys are ignored.
+func (l *keyLocks) lock(keys []string) (unlock func()) {
+	keys = slices.DeleteFunc(slices.Clone(keys), func(key string) bool { return key == "" })
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	locks := make([]*keyLock, len(keys))
+	for i, key := range keys {
+		l.mu.Lock()
+		lock := l.held[key]
+		if lock == nil {
+			lock = &keyLock{}
+			l.held[key] = lock
+		}
+		lock.refs++
+		l.mu.Unlock()
+
+		lock.Lock()
+		locks[i] = lock
+	}
+
+	return func() {
+		for i, lock := range locks {
+			lock.Unlock()
+			l.mu.Lock()
+			if lock.refs--; lock.refs == 0 {
+				delete(l.held, keys[i])
+			}
+			l.mu.Unlock()
+		}
+	}
+}
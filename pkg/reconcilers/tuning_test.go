@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/openchami/fabrica/pkg/fabrica"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, Configure(DefaultTuning())) })
+
+	for _, bad := range []Tuning{
+		{Workers: 0},
+		{Workers: 1, Timeout: -time.Second},
+		{Workers: 1, MaxRetries: -1},
+		{Workers: 1, RetryBackoff: -time.Second},
+	} {
+		assert.Error(t, Configure(bad), "%+v", bad)
+	}
+
+	require.NoError(t, Configure(Tuning{Workers: 2, RetryBackoff: time.Second}))
+	assert.Equal(t, 2, cap(current.Load().slots))
+	assert.Equal(t, time.Second, current.Load().retryDelay(1))
+	assert.Equal(t, 4*time.Second, current.Load().retryDelay(3))
+}
+
+func TestKeyLocks(t *testing.T) {
+	locks := &keyLocks{held: make(map[string]*keyLock)}
+
+	// Sets sharing a key wait for each other, in whatever order they list it
+	unlock := locks.lock([]string{"NODE-1", "DIMM-1", ""})
+	var overlapping atomic.Bool
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer locks.lock([]string{"DIMM-2", "NODE-1", "NODE-1"})()
+		overlapping.Store(true)
+	}()
+
+	// Disjoint sets do not
+	locks.lock([]string{"NODE-2"})()
+	time.Sleep(10 * time.Millisecond)
+	assert.False(t, overlapping.Load())
+
+	unlock()
+	<-done
+	assert.True(t, overlapping.Load())
+	assert.Empty(t, locks.held)
+}
+
+// TestSnapshotsOfDifferentNodesRunConcurrently checks that snapshots of two
+// nodes do not wait for each other even though their BMCs report the same
+// Redfish URIs, while a second snapshot of the same node does.
+func TestSnapshotsOfDifferentNodesRunConcurrently(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.Open(t)
+	storage.EnforceDeviceIdentities(client)
+	storage.SetEntClient(client)
+	require.NoError(t, Configure(Tuning{Workers: 2}))
+	t.Cleanup(func() { require.NoError(t, Configure(DefaultTuning())) })
+
+	snapshotOf := func(name, node string) (*v1.DiscoverySnapshot, []v1.DeviceSpec) {
+		specs := []v1.DeviceSpec{
+			{DeviceType: "Node", SerialNumber: node, Properties: map[string]json.RawMessage{
+				v1.PropertyRedfishURI: json.RawMessage(`"/Systems/1"`),
+			}},
+			{DeviceType: "DIMM", SerialNumber: "DIMM-" + name, Properties: map[string]json.RawMessage{
+				v1.PropertyRedfishURI:       json.RawMessage(`"/Systems/1/Memory/DIMM1"`),
+				v1.PropertyRedfishParentURI: json.RawMessage(`"/Systems/1"`),
+			}},
+		}
+		rawData, err := json.Marshal(specs)
+		require.NoError(t, err)
+		snapshot := &v1.DiscoverySnapshot{
+			APIVersion: "example.fabrica.dev/v1",
+			Kind:       "DiscoverySnapshot",
+			Metadata:   fabrica.Metadata{Name: name, UID: "discoverysnapshot-" + name},
+			Spec:       v1.DiscoverySnapshotSpec{RawData: rawData},
+		}
+		snapshot.Metadata.Initialize(snapshot.Metadata.Name, snapshot.Metadata.UID)
+		return snapshot, specs
+	}
+	reconcile := func(snapshot *v1.DiscoverySnapshot) <-chan error {
+		done := make(chan error, 1)
+		reconciler := NewDefaultDiscoverySnapshotReconciler(storage.NewStorageClient(), nil)
+		go func() { done <- reconciler.reconcileDiscoverySnapshot(ctx, snapshot) }()
+		return done
+	}
+
+	// Node A's snapshot is being reconciled
+	nodeA, specsA := snapshotOf("node-a", "NODE-A")
+	unlock := snapshotLocks.lock(snapshotLockKeys(nodeA.GetUID(), specsA))
+
+	nodeB, _ := snapshotOf("node-b", "NODE-B")
+	select {
+	case err := <-reconcile(nodeB):
+		require.NoError(t, err)
+		assert.Equal(t, "Completed", nodeB.Status.Phase, nodeB.Status.Message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("a snapshot of node B waited for one of node A")
+	}
+
+	againA, _ := snapshotOf("node-a-again", "NODE-A")
+	waiting := reconcile(againA)
+	select {
+	case <-waiting:
+		t.Fatal("a second snapshot of node A did not wait for the first")
+	case <-time.After(20 * time.Millisecond):
+	}
+	unlock()
+	require.NoError(t, <-waiting)
+	assert.Equal(t, "Completed", againA.Status.Phase, againA.Status.Message)
+}
+
+func TestReconcileRetries(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.Open(t)
+	storage.SetEntClient(client)
+
+	// Every attempt runs out of time before its first query
+	require.NoError(t, Configure(Tuning{Workers: 1, Timeout: time.Nanosecond, MaxRetries: 2, RetryBackoff: time.Millisecond}))
+	t.Cleanup(func() { require.NoError(t, Configure(DefaultTuning())) })
+
+	rawData, err := json.Marshal([]v1.DeviceSpec{{DeviceType: "Node", SerialNumber: "NODE-1"}})
+	require.NoError(t, err)
+	snapshot := &v1.DiscoverySnapshot{
+		APIVersion: "example.fabrica.dev/v1",
+		Kind:       "DiscoverySnapshot",
+		Metadata:   fabrica.Metadata{Name: "slow", UID: "discoverysnapshot-slow"},
+		Spec:       v1.DiscoverySnapshotSpec{RawData: rawData},
+	}
+	snapshot.Metadata.Initialize(snapshot.Metadata.Name, snapshot.Metadata.UID)
+	require.NoError(t, storage.SaveDiscoverySnapshot(ctx, snapshot))
+
+	reconciler := NewDefaultDiscoverySnapshotReconciler(storage.NewStorageClient(), nil)
+	retries := testutil.ToFloat64(snapshotReconcileRetries)
+	failed := testutil.ToFloat64(snapshotReconciles.WithLabelValues(resultError))
+	err = reconciler.reconcileDiscoverySnapshot(ctx, snapshot)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, retries+2, testutil.ToFloat64(snapshotReconcileRetries))
+	assert.Equal(t, failed+1, testutil.ToFloat64(snapshotReconciles.WithLabelValues(resultError)))
+
+	// The failure is saved despite the expired attempt
+	saved, err := storage.LoadDiscoverySnapshot(ctx, snapshot.GetUID())
+	require.NoError(t, err)
+	assert.Equal(t, "Error", saved.Status.Phase)
+	assert.Contains(t, saved.Status.Message, "Failed to prefetch devices")
+}
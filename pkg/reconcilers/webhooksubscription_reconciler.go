@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package reconcilers
+
+import (
+	"context"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+)
+
+// reconcileWebhookSubscription reports whether a subscription is receiving
+// deliveries. Deliveries themselves are made by pkg/webhooks, which records
+// them in the status delivery log.
+func (r *WebhookSubscriptionReconciler) reconcileWebhookSubscription(ctx context.Context, subscription *v1.WebhookSubscription) error {
+	if err := subscription.Validate(ctx); err != nil {
+		subscription.Status.Phase = "Error"
+		subscription.Status.Message = err.Error()
+		subscription.Status.Ready = false
+		return nil
+	}
+
+	if subscription.Spec.Paused {
+		subscription.Status.Phase = "Paused"
+		subscription.Status.Message = "Deliveries are paused."
+		subscription.Status.Ready = false
+		return nil
+	}
+
+	subscription.Status.Phase = "Active"
+	subscription.Status.Message = "Receiving inventory events."
+	subscription.Status.Ready = true
+	return nil
+}
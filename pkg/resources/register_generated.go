@@ -24,6 +24,9 @@ func RegisterAllResources(gen *codegen.Generator) error {
 	if err := gen.RegisterResource(&v1.DiscoverySnapshot{}); err != nil {
 		return fmt.Errorf("failed to register DiscoverySnapshot: %w", err)
 	}
+	if err := gen.RegisterResource(&v1.WebhookSubscription{}); err != nil {
+		return fmt.Errorf("failed to register WebhookSubscription: %w", err)
+	}
 
 	return nil
 }
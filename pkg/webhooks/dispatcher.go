@@ -0,0 +1,311 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+// Package webhooks delivers inventory events to WebhookSubscription endpoints.
+//
+// Every matching event is POSTed as a structured-mode CloudEvent
+// (application/cloudevents+json). Bodies are signed with HMAC-SHA256 when the
+// subscription has a secret, failed deliveries are retried with exponential
+// backoff, and every attempt is recorded in the subscription's status.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/openchami/fabrica/pkg/events"
+)
+
+const (
+	// ContentType is the media type of every delivery body.
+	ContentType = "application/cloudevents+json"
+
+	// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the
+	// body, keyed with the subscription secret.
+	SignatureHeader = "X-Fru-Tracker-Signature"
+
+	// DefaultMaxAttempts is used for subscriptions that do not set maxAttempts.
+	DefaultMaxAttempts = 5
+)
+
+// Backoff returns how long to wait after the given failed attempt (starting at 1).
+type Backoff func(attempt int) time.Duration
+
+// ExponentialBackoff doubles the delay after each failed attempt, starting at
+// base and never exceeding limit.
+func ExponentialBackoff(base, limit time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		delay := base
+		for i := 1; i < attempt && delay < limit; i++ {
+			delay *= 2
+		}
+		if delay > limit {
+			delay = limit
+		}
+		return delay
+	}
+}
+
+// Sign returns the SignatureHeader value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Option configures a Dispatcher.
+type Option func(*Dispatcher)
+
+// WithHTTPClient sets the client used for deliveries.
+func WithHTTPClient(client *http.Client) Option {
+	return func(d *Dispatcher) { d.client = client }
+}
+
+// WithBackoff sets the delay between delivery attempts.
+func WithBackoff(backoff Backoff) Option {
+	return func(d *Dispatcher) { d.backoff = backoff }
+}
+
+// Dispatcher delivers inventory events to every matching WebhookSubscription.
+type Dispatcher struct {
+	client  *http.Client
+	backoff Backoff
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// locks serialises status updates per subscription UID
+	locks sync.Map
+}
+
+// NewDispatcher creates a Dispatcher. Call Subscribe to start delivering and
+// Close to stop.
+func NewDispatcher(opts ...Option) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &Dispatcher{
+		client:  &http.Client{Timeout: 10 * time.Second},
+		backoff: ExponentialBackoff(time.Second, 5*time.Minute),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Subscribe registers the dispatcher for every inventory event type.
+func (d *Dispatcher) Subscribe(bus events.EventBus) error {
+	for _, eventType := range v1.InventoryEventTypes {
+		if _, err := bus.Subscribe(eventType, d.handleEvent); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", eventType, err)
+		}
+	}
+	return nil
+}
+
+// Close abandons pending retries and waits for in-flight deliveries to finish.
+func (d *Dispatcher) Close() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+// cloudEvent is the structured-mode CloudEvents envelope of a delivery.
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            v1.DeviceChange `json:"data"`
+}
+
+// handleEvent starts a delivery to each subscription that wants event. It
+// returns without waiting so slow endpoints never hold up the event bus, and
+// defers the event's acknowledgement until the deliveries are finished, so
+// that ones cut short by a restart are made again.
+func (d *Dispatcher) handleEvent(ctx context.Context, event events.Event) error {
+	var change v1.DeviceChange
+	if err := event.DataAs(&change); err != nil {
+		return fmt.Errorf("failed to decode %s event %s: %w", event.Type(), event.ID(), err)
+	}
+
+	subscriptions, err := storage.LoadAllWebhookSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load webhook subscriptions: %w", err)
+	}
+
+	body, err := json.Marshal(cloudEvent{
+		SpecVersion:     "1.0",
+		ID:              event.ID(),
+		Source:          event.Source(),
+		Type:            event.Type(),
+		Subject:         change.DeviceUID,
+		Time:            event.Time(),
+		DataContentType: "application/json",
+		Data:            change,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode event %s: %w", event.ID(), err)
+	}
+
+	for _, subscription := range subscriptions {
+		if !matches(subscription, event.Type(), change.DeviceType) {
+			continue
+		}
+		delivery := v1.WebhookDelivery{
+			EventID:   event.ID(),
+			EventType: event.Type(),
+			DeviceUID: change.DeviceUID,
+		}
+		release := storage.DeferAck(ctx)
+		d.wg.Add(1)
+		go func(subscription *v1.WebhookSubscription) {
+			defer d.wg.Done()
+			release(d.deliver(subscription, delivery, body))
+		}(subscription)
+	}
+	return nil
+}
+
+// matches reports whether subscription wants events of eventType about devices of deviceType.
+func matches(subscription *v1.WebhookSubscription, eventType, deviceType string) bool {
+	if subscription.Spec.Paused {
+		return false
+	}
+	if len(subscription.Spec.EventTypes) > 0 && !contains(subscription.Spec.EventTypes, eventType) {
+		return false
+	}
+	if len(subscription.Spec.DeviceTypes) > 0 && !contains(subscription.Spec.DeviceTypes, deviceType) {
+		return false
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// deliver POSTs body until the endpoint accepts it, the attempts run out or
+// the endpoint rejects it outright with a 4xx. It reports false when Close
+// abandoned the delivery before then.
+func (d *Dispatcher) deliver(subscription *v1.WebhookSubscription, delivery v1.WebhookDelivery, body []byte) bool {
+	maxAttempts := subscription.Spec.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		delivery.Attempt = attempt
+		delivery.StatusCode, delivery.Error = d.post(subscription, body)
+		delivery.Succeeded = delivery.Error == ""
+		delivery.Time = time.Now().UTC()
+
+		if d.ctx.Err() != nil {
+			// The attempt was cut short by Close, not refused by the endpoint
+			return false
+		}
+		done := delivery.Succeeded || attempt >= maxAttempts || permanentFailure(delivery.StatusCode)
+		d.record(subscription.GetUID(), delivery, done)
+		if done {
+			return true
+		}
+
+		select {
+		case <-d.ctx.Done():
+			return false
+		case <-time.After(d.backoff(attempt)):
+		}
+	}
+}
+
+// post sends one delivery attempt and returns the response status and, if it
+// failed, why.
+func (d *Dispatcher) post(subscription *v1.WebhookSubscription, body []byte) (int, string) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, subscription.Spec.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Sprintf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", ContentType)
+	if subscription.Spec.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(subscription.Spec.Secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err.Error()
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Sprintf("endpoint returned %s", resp.Status)
+	}
+	return resp.StatusCode, ""
+}
+
+// permanentFailure reports whether a response status means retrying is pointless.
+func permanentFailure(statusCode int) bool {
+	if statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests {
+		return false
+	}
+	return statusCode >= 400 && statusCode < 500
+}
+
+// record adds delivery to the subscription's log. When done is set the event
+// is finished and the consecutive failure count is updated.
+func (d *Dispatcher) record(uid string, delivery v1.WebhookDelivery, done bool) {
+	lock, _ := d.locks.LoadOrStore(uid, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	// Reload so concurrent deliveries and spec edits are not overwritten
+	ctx := context.Background()
+	subscription, err := storage.LoadWebhookSubscription(ctx, uid)
+	if err != nil {
+		// Deleted while delivering: nothing left to record against
+		slog.DebugContext(ctx, "Not recording webhook delivery", "event_id", delivery.EventID,
+			"subscription_uid", uid, "error", err)
+		return
+	}
+
+	status := &subscription.Status
+	status.Deliveries = append([]v1.WebhookDelivery{delivery}, status.Deliveries...)
+	if len(status.Deliveries) > v1.WebhookDeliveryLogSize {
+		status.Deliveries = status.Deliveries[:v1.WebhookDeliveryLogSize]
+	}
+	if done {
+		if delivery.Succeeded {
+			status.ConsecutiveFailures = 0
+		} else {
+			status.ConsecutiveFailures++
+		}
+	}
+
+	if err := storage.SaveWebhookSubscription(ctx, subscription); err != nil {
+		slog.WarnContext(ctx, "Failed to record webhook delivery", "event_id", delivery.EventID,
+			"subscription_uid", uid, "error", err)
+	}
+}
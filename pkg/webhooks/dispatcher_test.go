@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2026 OpenCHAMI Contributors
+//
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "github.com/example/fru-tracker/apis/example.fabrica.dev/v1"
+	"github.com/example/fru-tracker/internal/storage"
+	"github.com/example/fru-tracker/internal/storage/storagetest"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/fabrica"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcherDeliversSignedCloudEvents(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.Open(t)
+	storage.SetEntClient(client)
+
+	// The endpoint fails the first attempt of every event, then accepts it
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	var (
+		mu       sync.Mutex
+		requests []received
+		attempts = make(map[string]int)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var event cloudEvent
+		_ = json.Unmarshal(body, &event)
+
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, received{header: r.Header.Clone(), body: body})
+		attempts[event.ID]++
+		if attempts[event.ID] == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	subscribe := func(uid string, spec v1.WebhookSubscriptionSpec) {
+		subscription := &v1.WebhookSubscription{
+			APIVersion: "example.fabrica.dev/v1",
+			Kind:       "WebhookSubscription",
+			Metadata:   fabrica.Metadata{Name: uid, UID: uid},
+			Spec:       spec,
+		}
+		subscription.Metadata.Initialize(subscription.Metadata.Name, subscription.Metadata.UID)
+		require.NoError(t, storage.SaveWebhookSubscription(ctx, subscription))
+	}
+	subscribe("webhooksubscription-dimms", v1.WebhookSubscriptionSpec{
+		URL:         server.URL,
+		EventTypes:  []string{v1.DevicePartNumberChangedEventType},
+		DeviceTypes: []string{"dimm"},
+		Secret:      "s3cret",
+	})
+	subscribe("webhooksubscription-nodes", v1.WebhookSubscriptionSpec{URL: server.URL, DeviceTypes: []string{"Node"}})
+	subscribe("webhooksubscription-paused", v1.WebhookSubscriptionSpec{URL: server.URL, Paused: true})
+
+	bus := events.NewInMemoryEventBus(10, 2)
+	bus.Start()
+	t.Cleanup(func() {
+		_ = bus.Close()
+	})
+
+	dispatcher := NewDispatcher(WithBackoff(func(int) time.Duration { return 0 }))
+	require.NoError(t, dispatcher.Subscribe(bus))
+	t.Cleanup(dispatcher.Close)
+
+	change := v1.DeviceChange{
+		DeviceUID:          "device-1",
+		DeviceType:         "DIMM",
+		PartNumber:         "NEW-PN",
+		PreviousPartNumber: "OLD-PN",
+	}
+	event, err := events.NewEvent(v1.DevicePartNumberChangedEventType, "/fru-tracker/inventory", change)
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(ctx, *event))
+
+	// Only the DIMM subscription matches: one failed attempt, one retry
+	require.Eventually(t, func() bool {
+		subscription, err := storage.LoadWebhookSubscription(ctx, "webhooksubscription-dimms")
+		return err == nil && len(subscription.Status.Deliveries) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, requests, 2)
+	for _, request := range requests {
+		assert.Equal(t, ContentType, request.header.Get("Content-Type"))
+		assert.Equal(t, Sign("s3cret", request.body), request.header.Get(SignatureHeader))
+	}
+
+	var delivered cloudEvent
+	require.NoError(t, json.Unmarshal(requests[1].body, &delivered))
+	assert.Equal(t, "1.0", delivered.SpecVersion)
+	assert.Equal(t, event.ID(), delivered.ID)
+	assert.Equal(t, v1.DevicePartNumberChangedEventType, delivered.Type)
+	assert.Equal(t, "device-1", delivered.Subject)
+	assert.Equal(t, change, delivered.Data)
+
+	subscription, err := storage.LoadWebhookSubscription(ctx, "webhooksubscription-dimms")
+	require.NoError(t, err)
+	latest, first := subscription.Status.Deliveries[0], subscription.Status.Deliveries[1]
+	assert.Equal(t, 2, latest.Attempt)
+	assert.True(t, latest.Succeeded)
+	assert.Equal(t, http.StatusNoContent, latest.StatusCode)
+	assert.Equal(t, 1, first.Attempt)
+	assert.False(t, first.Succeeded)
+	assert.Equal(t, http.StatusServiceUnavailable, first.StatusCode)
+	assert.Zero(t, subscription.Status.ConsecutiveFailures)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second, 5*time.Second)
+	assert.Equal(t, time.Second, backoff(1))
+	assert.Equal(t, 2*time.Second, backoff(2))
+	assert.Equal(t, 4*time.Second, backoff(3))
+	assert.Equal(t, 5*time.Second, backoff(4))
+	assert.Equal(t, 5*time.Second, backoff(10))
+}
+
+// TestDispatcherDeliversAgainAfterRestart checks that an event whose delivery
+// was still being retried when the server stopped is delivered on the next
+// start.
+func TestDispatcherDeliversAgainAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.Open(t)
+	storage.SetEntClient(client)
+
+	var accept atomic.Bool
+	delivered := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !accept.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var event cloudEvent
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &event)
+		delivered <- event.ID
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	subscription := &v1.WebhookSubscription{
+		APIVersion: "example.fabrica.dev/v1",
+		Kind:       "WebhookSubscription",
+		Metadata:   fabrica.Metadata{Name: "fru-swaps", UID: "webhooksubscription-swaps"},
+		Spec:       v1.WebhookSubscriptionSpec{URL: server.URL},
+	}
+	subscription.Metadata.Initialize(subscription.Metadata.Name, subscription.Metadata.UID)
+	require.NoError(t, storage.SaveWebhookSubscription(ctx, subscription))
+
+	start := func(backoff time.Duration) (*storage.DurableEventBus, *Dispatcher) {
+		memoryBus := events.NewInMemoryEventBus(10, 1)
+		memoryBus.Start()
+		bus := storage.NewDurableEventBus(memoryBus, 0)
+		dispatcher := NewDispatcher(WithBackoff(func(int) time.Duration { return backoff }))
+		require.NoError(t, dispatcher.Subscribe(bus))
+		bus.Start()
+		return bus, dispatcher
+	}
+
+	// First run: the endpoint is down and the retry is still waiting at shutdown
+	bus, dispatcher := start(time.Hour)
+	event, err := events.NewEvent(v1.DeviceReparentedEventType, "/fru-tracker/inventory",
+		v1.DeviceChange{DeviceUID: "device-1", DeviceType: "DIMM"})
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(ctx, *event))
+	require.Eventually(t, func() bool {
+		subscription, err := storage.LoadWebhookSubscription(ctx, subscription.GetUID())
+		return err == nil && len(subscription.Status.Deliveries) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	dispatcher.Close()
+	require.NoError(t, bus.Close())
+
+	// Second run: the event is delivered again
+	accept.Store(true)
+	bus, dispatcher = start(0)
+	t.Cleanup(func() {
+		dispatcher.Close()
+		_ = bus.Close()
+	})
+	select {
+	case id := <-delivered:
+		assert.Equal(t, event.ID(), id)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not delivered after restart")
+	}
+}